@@ -30,6 +30,8 @@ var dbCodec = codec.BC
 
 const (
 	keyLastBlockHeight = "block.lastHeight"
+	keyBodyBaseHeight  = "block.bodyBase"
+	keyStateGCBase     = "stategc.base"
 	genesisHeight      = 0
 	configCacheCap     = 10
 )
@@ -942,7 +944,11 @@ func (m *manager) Commit(block module.BlockCandidate) error {
 }
 
 func (m *manager) bucketFor(id db.BucketID) (*bucket, error) {
-	b, err := m.db().GetBucket(id)
+	return bucketOf(m.db(), id)
+}
+
+func bucketOf(database db.Database, id db.BucketID) (*bucket, error) {
+	b, err := database.GetBucket(id)
 	if err != nil {
 		return nil, err
 	}
@@ -990,7 +996,9 @@ func (m *manager) finalize(bn *bnode) error {
 	}
 
 	if blockV2, ok := block.(*blockV2); ok {
-		hb, err := m.bucketFor(db.BytesByHash)
+		// writes to the buckets are applied at once through a batch
+		layer := db.NewLayerDB(m.db())
+		hb, err := bucketOf(layer, db.BytesByHash)
 		if err != nil {
 			return err
 		}
@@ -1000,7 +1008,7 @@ func (m *manager) finalize(bn *bnode) error {
 		if err = hb.set(raw(block.Votes().Hash()), raw(block.Votes().Bytes())); err != nil {
 			return err
 		}
-		lb, err := m.bucketFor(db.TransactionLocatorByHash)
+		lb, err := bucketOf(layer, db.TransactionLocatorByHash)
 		if err != nil {
 			return err
 		}
@@ -1032,20 +1040,23 @@ func (m *manager) finalize(bn *bnode) error {
 				return err
 			}
 		}
-		b, err := m.bucketFor(db.BlockHeaderHashByHeight)
+		b, err := bucketOf(layer, db.BlockHeaderHashByHeight)
 		if err != nil {
 			return err
 		}
 		if err = b.set(block.Height(), raw(block.ID())); err != nil {
 			return err
 		}
-		chainProp, err := m.bucketFor(db.ChainProperty)
+		chainProp, err := bucketOf(layer, db.ChainProperty)
 		if err != nil {
 			return err
 		}
 		if err = chainProp.set(raw(keyLastBlockHeight), block.Height()); err != nil {
 			return err
 		}
+		if err = layer.Flush(true); err != nil {
+			return err
+		}
 	}
 	m.logger.Debugf("Finalize(%x)\n", block.ID())
 	for i := 0; i < len(m.finalizationCBs); {
@@ -1242,6 +1253,10 @@ func (m *manager) getTransactionInfo(id []byte) (module.TransactionInfo, error)
 	if err != nil {
 		return nil, errors.ErrNotFound
 	}
+	if base := GetBodyBaseOf(m.db()); loc.BlockHeight < base {
+		return nil, PrunedBlockError.Errorf(
+			"PrunedBlock(height=%d,base=%d)", loc.BlockHeight, base)
+	}
 	block, err := m.getBlockByHeight(loc.BlockHeight)
 	if err != nil {
 		return nil, errors.InvalidStateError.Wrapf(err, "block h=%d not found", loc.BlockHeight)
@@ -1527,12 +1542,16 @@ func (m *manager) ExportBlocks(from, to int64, dst db.Database, on func(h int64)
 
 func (m *manager) _exportBlocks(from, to int64, dst db.Database, flag int, on func(h int64) error) error {
 	ctx := merkle.NewCopyContext(m.db(), dst)
+	sbase, err := GetStateGCBaseOf(m.db())
+	if err != nil {
+		return err
+	}
 	if hasBits(flag, exportValidator) && from > 0 {
 		blk, err := m.getBlockByHeight(from - 1)
 		if err != nil {
 			return errors.Wrapf(err, "fail to get previous block height=%d", from-1)
 		}
-		if err := m._export(blk, ctx, flag); err != nil {
+		if err := m._export(blk, ctx, flag, sbase); err != nil {
 			return errors.Wrapf(err, "fail to export block height=%d", blk.Height())
 		}
 	}
@@ -1546,16 +1565,26 @@ func (m *manager) _exportBlocks(from, to int64, dst db.Database, flag int, on fu
 		if err != nil {
 			return errors.Wrapf(err, "fail to get a block height=%d", h)
 		}
-		if err := m._export(blk, ctx, flag); err != nil {
+		if err := m._export(blk, ctx, flag, sbase); err != nil {
 			return errors.Wrapf(err, "fail to export block height=%d", blk.Height())
 		}
 	}
 	return nil
 }
 
-func (m *manager) _export(blk module.Block, ctx *merkle.CopyContext, flag int) error {
+// _export exports the entries of the block selected by flag. The world
+// state of the block below sbase is removed by state GC, so only receipts
+// and validators are exported for its result.
+func (m *manager) _export(blk module.Block, ctx *merkle.CopyContext, flag int, sbase int64) error {
 	if hasBits(flag, exportResult) {
-		if err := m.sm.ExportResult(blk.Result(), blk.NextValidatorsHash(), ctx.TargetDB()); err != nil {
+		if blk.Height() < sbase {
+			if err := m.sm.ExportReceipts(blk.Result(), ctx.TargetDB()); err != nil {
+				return err
+			}
+			if err := ctx.Copy(db.BytesByHash, blk.NextValidatorsHash()); err != nil {
+				return err
+			}
+		} else if err := m.sm.ExportResult(blk.Result(), blk.NextValidatorsHash(), ctx.TargetDB()); err != nil {
 			return err
 		}
 	}
@@ -1655,3 +1684,75 @@ func GetLastHeightOf(dbase db.Database) int64 {
 	}
 	return height
 }
+
+// GetBlockHashOf returns the hash of the block at the height. It returns nil
+// if there is no such block.
+func GetBlockHashOf(dbase db.Database, height int64) []byte {
+	bk, err := dbase.GetBucket(db.BlockHeaderHashByHeight)
+	if err != nil {
+		return nil
+	}
+	bs, err := bk.Get(dbCodec.MustMarshalToBytes(height))
+	if err != nil {
+		return nil
+	}
+	return bs
+}
+
+// GetBodyBaseOf returns the lowest height of the blocks whose transactions
+// and receipts are kept. Those of the blocks below it are removed by the
+// retention policy of the chain, but the headers and votes are kept.
+func GetBodyBaseOf(dbase db.Database) int64 {
+	bk, err := dbase.GetBucket(db.ChainProperty)
+	if err != nil {
+		return 0
+	}
+	bs, err := bk.Get([]byte(keyBodyBaseHeight))
+	if err != nil || bs == nil {
+		return 0
+	}
+	var height int64
+	if _, err := dbCodec.UnmarshalFromBytes(bs, &height); err != nil {
+		return 0
+	}
+	return height
+}
+
+// SetBodyBaseOf sets the lowest height of the blocks whose transactions and
+// receipts are kept. It should be set before removing them.
+func SetBodyBaseOf(dbase db.Database, height int64) error {
+	bk, err := dbase.GetBucket(db.ChainProperty)
+	if err != nil {
+		return err
+	}
+	return bk.Set([]byte(keyBodyBaseHeight), dbCodec.MustMarshalToBytes(height))
+}
+
+// GetStateGCBaseOf returns the lowest height of the blocks whose world states
+// are kept. The states of the blocks below it are removed by state GC. It
+// returns 0 if the states are never removed.
+func GetStateGCBaseOf(dbase db.Database) (int64, error) {
+	bk, err := dbase.GetBucket(db.ChainProperty)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := bk.Get([]byte(keyStateGCBase))
+	if err != nil || bs == nil {
+		return 0, err
+	}
+	var height int64
+	if _, err := dbCodec.UnmarshalFromBytes(bs, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// SetStateGCBaseOf sets the lowest height of the blocks whose world states
+// are kept. It should be set before removing them.
+func SetStateGCBaseOf(dbase db.Database, height int64) error {
+	bk, err := dbase.GetBucket(db.ChainProperty)
+	if err != nil {
+		return err
+	}
+	return bk.Set([]byte(keyStateGCBase), dbCodec.MustMarshalToBytes(height))
+}
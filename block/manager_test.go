@@ -289,6 +289,25 @@ func TestBlockManager_Import_OK(t *testing.T) {
 	}
 }
 
+func TestBlockManager_ExportBlocks_StateGCBase(t *testing.T) {
+	s := newBlockManagerTestSetUp(t)
+	for i := int64(1); i <= 5; i++ {
+		br := importSync(s.bm, s.bg.getReaderForBlock(i))
+		br.assertOK(t)
+		assert.NoError(t, s.bm.Finalize(br.blk))
+	}
+	assert.NoError(t, SetStateGCBaseOf(s.database, 3))
+
+	// the block before the first one is exported too, and the world states
+	// of the blocks below the base are not exported.
+	bm := s.bm.(*manager)
+	err := bm._exportBlocks(1, 4, db.NewMapDB(), exportValidator|exportResult, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"receipts", "receipts", "receipts", "result", "result",
+	}, s.sm.exported)
+}
+
 func TestBlockManager_Import_Cancel(t *testing.T) {
 	s := newBlockManagerTestSetUp(t)
 	ec := make(chan struct{})
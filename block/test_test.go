@@ -528,6 +528,7 @@ type testServiceManager struct {
 	transactions [][]*testTransaction
 	bucket       *bucket
 	exeChan      chan struct{}
+	exported     []string
 }
 
 func newTestServiceManager(database db.Database) *testServiceManager {
@@ -690,6 +691,16 @@ func (sm *testServiceManager) SendTransaction(tx interface{}) ([]byte, error) {
 	return nil, errors.Errorf("bad type")
 }
 
+func (sm *testServiceManager) ExportResult(result []byte, vh []byte, dst db.Database) error {
+	sm.exported = append(sm.exported, "result")
+	return nil
+}
+
+func (sm *testServiceManager) ExportReceipts(result []byte, dst db.Database) error {
+	sm.exported = append(sm.exported, "receipts")
+	return nil
+}
+
 func (sm *testServiceManager) ValidatorListFromHash(hash []byte) module.ValidatorList {
 	tvl := &testValidatorList{}
 	err := sm.bucket.get(raw(hash), tvl)
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package archive implements the block archive format, which is a portable
+// stream of the blocks in a range of heights.
+//
+// An archive starts with Magic and the version in two bytes (big endian),
+// followed by a gzip stream of frames. A frame consists of the type in a
+// byte, the length of the payload in four bytes (big endian), the payload
+// and CRC32 (IEEE) of the type and the payload in four bytes (big endian).
+//
+// The first frame has Meta, and a frame for each Record follows in order of
+// height. The last frame has the number of records and SHA3-256 digest of
+// the payloads of the record frames, so a truncated archive is detected.
+package archive
+
+const (
+	Magic   = "GOLOOPAR"
+	Version = 1
+
+	// MaxFrameSize is the maximum size of the payload of a frame.
+	MaxFrameSize = 256 * 1024 * 1024
+)
+
+const (
+	frameMeta   byte = 'M'
+	frameRecord byte = 'R'
+	frameEnd    byte = 'E'
+)
+
+// Meta describes the chain and the range of the blocks in an archive.
+type Meta struct {
+	NID  int
+	CID  int
+	From int64
+	To   int64
+}
+
+// Record has the data of a block. Votes are the commit votes for the block,
+// which are stored in the next block. Receipts are the receipts in the
+// result of the block.
+type Record struct {
+	Height         int64
+	Header         []byte
+	Body           []byte
+	Votes          []byte
+	PatchReceipts  [][]byte
+	NormalReceipts [][]byte
+}
+
+type endOfArchive struct {
+	Records int64
+	Digest  []byte
+}
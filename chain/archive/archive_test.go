@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func newRecord(h int64) *Record {
+	return &Record{
+		Height: h,
+		Header: []byte(fmt.Sprintf("header%d", h)),
+		Body:   []byte(fmt.Sprintf("body%d", h)),
+		Votes:  []byte(fmt.Sprintf("votes%d", h)),
+		NormalReceipts: [][]byte{
+			[]byte(fmt.Sprintf("receipt%d-0", h)),
+			[]byte(fmt.Sprintf("receipt%d-1", h)),
+		},
+	}
+}
+
+func writeArchive(t *testing.T, meta *Meta) []byte {
+	buf := bytes.NewBuffer(nil)
+	aw, err := NewWriter(buf, meta)
+	assert.NoError(t, err)
+	for h := meta.From; h <= meta.To; h++ {
+		assert.NoError(t, aw.WriteRecord(newRecord(h)))
+	}
+	assert.NoError(t, aw.Close())
+	return buf.Bytes()
+}
+
+// writeFrames writes an archive having the meta and the frames written by
+// frames.
+func writeFrames(t *testing.T, meta *Meta, frames func(w io.Writer)) []byte {
+	buf := bytes.NewBuffer(nil)
+	var head [len(Magic) + 2]byte
+	copy(head[:], Magic)
+	binary.BigEndian.PutUint16(head[len(Magic):], Version)
+	buf.Write(head[:])
+	zw := gzip.NewWriter(buf)
+	assert.NoError(t, writeFrame(zw, frameMeta, codec.BC.MustMarshalToBytes(meta)))
+	frames(zw)
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// readArchive reads the records in the archive until an error.
+func readArchive(t *testing.T, bs []byte) ([]*Record, error) {
+	ar, err := NewReader(bytes.NewReader(bs))
+	if err != nil {
+		return nil, err
+	}
+	defer ar.Close()
+	var recs []*Record
+	for {
+		rec, err := ar.ReadRecord()
+		if err == io.EOF {
+			return recs, nil
+		} else if err != nil {
+			return recs, err
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func assertError(t *testing.T, err error, code errors.Code, msg string) {
+	if assert.Error(t, err) {
+		assert.True(t, code.Equals(err), "err=%+v", err)
+		assert.True(t, strings.Contains(err.Error(), msg), "err=%+v", err)
+	}
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	meta := &Meta{NID: 1, CID: 0x123, From: 10, To: 19}
+	bs := writeArchive(t, meta)
+
+	ar, err := NewReader(bytes.NewReader(bs))
+	assert.NoError(t, err)
+	assert.Equal(t, meta, ar.Meta())
+	for h := meta.From; h <= meta.To; h++ {
+		rec, err := ar.ReadRecord()
+		assert.NoError(t, err)
+		assert.Equal(t, newRecord(h), rec)
+	}
+	_, err = ar.ReadRecord()
+	assert.Equal(t, io.EOF, err)
+	_, err = ar.ReadRecord()
+	assert.Equal(t, io.EOF, err)
+	assert.NoError(t, ar.Close())
+}
+
+func TestWriter_Invalid(t *testing.T) {
+	_, err := NewWriter(bytes.NewBuffer(nil), &Meta{From: 10, To: 9})
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+
+	aw, err := NewWriter(bytes.NewBuffer(nil), &Meta{From: 10, To: 11})
+	assert.NoError(t, err)
+	err = aw.WriteRecord(newRecord(11))
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	assert.NoError(t, aw.WriteRecord(newRecord(10)))
+	err = aw.Close()
+	assert.True(t, errors.InvalidStateError.Equals(err))
+	assert.NoError(t, aw.WriteRecord(newRecord(11)))
+	err = aw.WriteRecord(newRecord(12))
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	assert.NoError(t, aw.Close())
+}
+
+func TestReader_InvalidHeader(t *testing.T) {
+	bs := writeArchive(t, &Meta{From: 0, To: 1})
+
+	_, err := NewReader(bytes.NewReader([]byte("NOTARCHIVE")))
+	assertError(t, err, errors.CriticalFormatError, "NotBlockArchive")
+
+	inv := append([]byte(nil), bs...)
+	binary.BigEndian.PutUint16(inv[len(Magic):], Version+1)
+	_, err = NewReader(bytes.NewReader(inv))
+	assert.True(t, errors.UnsupportedError.Equals(err))
+
+	_, err = NewReader(bytes.NewReader(bs[:len(Magic)]))
+	assertError(t, err, errors.CriticalFormatError, "UnexpectedEndOfArchive")
+}
+
+func TestReader_InvalidChecksum(t *testing.T) {
+	meta := &Meta{From: 0, To: 1}
+	bs := writeFrames(t, meta, func(w io.Writer) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, writeFrame(buf, frameRecord,
+			codec.BC.MustMarshalToBytes(newRecord(0))))
+		frame := buf.Bytes()
+		frame[len(frame)-1] ^= 0xff
+		w.Write(frame)
+	})
+	recs, err := readArchive(t, bs)
+	assert.Len(t, recs, 0)
+	assertError(t, err, errors.CriticalHashError, "InvalidChecksum")
+}
+
+func TestReader_Truncated(t *testing.T) {
+	meta := &Meta{From: 0, To: 9}
+
+	// the stream ends without the end of the archive
+	bs := writeFrames(t, meta, func(w io.Writer) {
+		for h := meta.From; h <= meta.To; h++ {
+			assert.NoError(t, writeFrame(w, frameRecord,
+				codec.BC.MustMarshalToBytes(newRecord(h))))
+		}
+	})
+	recs, err := readArchive(t, bs)
+	assert.Len(t, recs, 10)
+	assertError(t, err, errors.CriticalFormatError, "UnexpectedEndOfArchive")
+
+	// the stream is cut in the middle
+	bs = writeArchive(t, meta)
+	_, err = readArchive(t, bs[:len(bs)/2])
+	assertError(t, err, errors.CriticalFormatError, "UnexpectedEndOfArchive")
+
+	// the end of the archive is before the last record
+	bs = writeFrames(t, meta, func(w io.Writer) {
+		assert.NoError(t, writeFrame(w, frameRecord,
+			codec.BC.MustMarshalToBytes(newRecord(0))))
+		assert.NoError(t, writeFrame(w, frameEnd,
+			codec.BC.MustMarshalToBytes(&endOfArchive{Records: 1})))
+	})
+	recs, err = readArchive(t, bs)
+	assert.Len(t, recs, 1)
+	assertError(t, err, errors.CriticalFormatError, "InvalidRecords")
+}
+
+func TestReader_InvalidDigest(t *testing.T) {
+	meta := &Meta{From: 0, To: 1}
+	bs := writeFrames(t, meta, func(w io.Writer) {
+		for h := meta.From; h <= meta.To; h++ {
+			assert.NoError(t, writeFrame(w, frameRecord,
+				codec.BC.MustMarshalToBytes(newRecord(h))))
+		}
+		assert.NoError(t, writeFrame(w, frameEnd,
+			codec.BC.MustMarshalToBytes(&endOfArchive{
+				Records: 2,
+				Digest:  make([]byte, 32),
+			})))
+	})
+	recs, err := readArchive(t, bs)
+	assert.Len(t, recs, 2)
+	assertError(t, err, errors.CriticalHashError, "InvalidDigest")
+}
+
+func TestReader_InvalidRecordHeight(t *testing.T) {
+	meta := &Meta{From: 0, To: 2}
+	for name, heights := range map[string][]int64{
+		"Skipped":    {0, 2},
+		"Reordered":  {1, 0},
+		"Duplicated": {0, 0},
+		"OverRange":  {0, 1, 2, 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			bs := writeFrames(t, meta, func(w io.Writer) {
+				for _, h := range heights {
+					assert.NoError(t, writeFrame(w, frameRecord,
+						codec.BC.MustMarshalToBytes(newRecord(h))))
+				}
+			})
+			_, err := readArchive(t, bs)
+			assertError(t, err, errors.CriticalFormatError, "InvalidRecordHeight")
+		})
+	}
+}
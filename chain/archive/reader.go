@@ -0,0 +1,165 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+type Reader struct {
+	zr     *gzip.Reader
+	meta   Meta
+	next   int64
+	digest hash.Hash
+	done   bool
+}
+
+func readFrame(r io.Reader) (byte, []byte, error) {
+	var head [5]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return 0, nil, unexpectedEOF(err)
+	}
+	size := binary.BigEndian.Uint32(head[1:])
+	if size > MaxFrameSize {
+		return 0, nil, errors.CriticalFormatError.Errorf(
+			"TooLargeFrame(size=%d)", size)
+	}
+	payload := make([]byte, size+4)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, unexpectedEOF(err)
+	}
+	crc := crc32.NewIEEE()
+	crc.Write(head[0:1])
+	crc.Write(payload[:size])
+	if sum := binary.BigEndian.Uint32(payload[size:]); sum != crc.Sum32() {
+		return 0, nil, errors.CriticalHashError.Errorf(
+			"InvalidChecksum(exp=%#x,real=%#x)", sum, crc.Sum32())
+	}
+	return head[0], payload[:size], nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.CriticalFormatError.New("UnexpectedEndOfArchive")
+	}
+	return err
+}
+
+func (r *Reader) Meta() *Meta {
+	return &r.meta
+}
+
+// ReadRecord returns the next record. It returns io.EOF after the last
+// record if the number of records and the digest are verified.
+func (r *Reader) ReadRecord() (*Record, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	ft, payload, err := readFrame(r.zr)
+	if err != nil {
+		return nil, err
+	}
+	switch ft {
+	case frameRecord:
+		rec := new(Record)
+		if _, err := codec.BC.UnmarshalFromBytes(payload, rec); err != nil {
+			return nil, errors.CriticalFormatError.Wrap(err, "InvalidRecord")
+		}
+		if rec.Height != r.next || rec.Height > r.meta.To {
+			return nil, errors.CriticalFormatError.Errorf(
+				"InvalidRecordHeight(exp=%d,real=%d)", r.next, rec.Height)
+		}
+		r.digest.Write(payload)
+		r.next += 1
+		return rec, nil
+	case frameEnd:
+		var end endOfArchive
+		if _, err := codec.BC.UnmarshalFromBytes(payload, &end); err != nil {
+			return nil, errors.CriticalFormatError.Wrap(err, "InvalidEnd")
+		}
+		if records := r.next - r.meta.From; end.Records != records ||
+			r.next != r.meta.To+1 {
+			return nil, errors.CriticalFormatError.Errorf(
+				"InvalidRecords(exp=%d,real=%d)", end.Records, records)
+		}
+		if digest := r.digest.Sum(nil); !bytes.Equal(end.Digest, digest) {
+			return nil, errors.CriticalHashError.Errorf(
+				"InvalidDigest(exp=%#x,real=%#x)", end.Digest, digest)
+		}
+		r.done = true
+		return nil, io.EOF
+	default:
+		return nil, errors.CriticalFormatError.Errorf(
+			"UnknownFrameType(type=%#x)", ft)
+	}
+}
+
+func (r *Reader) Close() error {
+	return r.zr.Close()
+}
+
+// NewReader reads the header and the meta of the archive from r.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	var head [len(Magic) + 2]byte
+	if _, err := io.ReadFull(br, head[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if string(head[:len(Magic)]) != Magic {
+		return nil, errors.CriticalFormatError.New("NotBlockArchive")
+	}
+	if v := binary.BigEndian.Uint16(head[len(Magic):]); v != Version {
+		return nil, errors.UnsupportedError.Errorf(
+			"UnsupportedVersion(version=%d)", v)
+	}
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidCompression")
+	}
+	ft, payload, err := readFrame(zr)
+	if err != nil {
+		return nil, err
+	}
+	if ft != frameMeta {
+		return nil, errors.CriticalFormatError.Errorf(
+			"NoMeta(type=%#x)", ft)
+	}
+	ar := &Reader{
+		zr:     zr,
+		digest: sha3.New256(),
+	}
+	if _, err := codec.BC.UnmarshalFromBytes(payload, &ar.meta); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidMeta")
+	}
+	if ar.meta.From < 0 || ar.meta.From > ar.meta.To {
+		return nil, errors.CriticalFormatError.Errorf(
+			"InvalidRange(from=%d,to=%d)", ar.meta.From, ar.meta.To)
+	}
+	ar.next = ar.meta.From
+	return ar, nil
+}
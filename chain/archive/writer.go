@@ -0,0 +1,127 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+type Writer struct {
+	zw     *gzip.Writer
+	meta   Meta
+	next   int64
+	digest hash.Hash
+}
+
+func writeFrame(w io.Writer, ft byte, payload []byte) error {
+	if len(payload) > MaxFrameSize {
+		return errors.IllegalArgumentError.Errorf(
+			"TooLargeFrame(size=%d)", len(payload))
+	}
+	var head [5]byte
+	head[0] = ft
+	binary.BigEndian.PutUint32(head[1:], uint32(len(payload)))
+	crc := crc32.NewIEEE()
+	crc.Write(head[0:1])
+	crc.Write(payload)
+	var tail [4]byte
+	binary.BigEndian.PutUint32(tail[:], crc.Sum32())
+
+	if _, err := w.Write(head[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	_, err := w.Write(tail[:])
+	return err
+}
+
+// WriteRecord writes the record. Records should be written in order of
+// height from Meta.From to Meta.To.
+func (w *Writer) WriteRecord(r *Record) error {
+	if r.Height != w.next || r.Height > w.meta.To {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidRecordHeight(exp=%d,real=%d)", w.next, r.Height)
+	}
+	payload, err := codec.BC.MarshalToBytes(r)
+	if err != nil {
+		return err
+	}
+	if err := writeFrame(w.zw, frameRecord, payload); err != nil {
+		return err
+	}
+	w.digest.Write(payload)
+	w.next += 1
+	return nil
+}
+
+// Close writes the end of the archive and flushes the compressed stream.
+// It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if w.next != w.meta.To+1 {
+		return errors.InvalidStateError.Errorf(
+			"MissingRecords(next=%d,to=%d)", w.next, w.meta.To)
+	}
+	payload, err := codec.BC.MarshalToBytes(&endOfArchive{
+		Records: w.meta.To - w.meta.From + 1,
+		Digest:  w.digest.Sum(nil),
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeFrame(w.zw, frameEnd, payload); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+func NewWriter(w io.Writer, meta *Meta) (*Writer, error) {
+	if meta.From < 0 || meta.From > meta.To {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidRange(from=%d,to=%d)", meta.From, meta.To)
+	}
+	var head [len(Magic) + 2]byte
+	copy(head[:], Magic)
+	binary.BigEndian.PutUint16(head[len(Magic):], Version)
+	if _, err := w.Write(head[:]); err != nil {
+		return nil, err
+	}
+	zw := gzip.NewWriter(w)
+	payload, err := codec.BC.MarshalToBytes(meta)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFrame(zw, frameMeta, payload); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		zw:     zw,
+		meta:   *meta,
+		next:   meta.From,
+		digest: sha3.New256(),
+	}, nil
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/chain/archive"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// archiveExport writes the blocks in the range into a block archive. It runs
+// as a live task of the running chain, or in taskExportArchive if the chain
+// is stopped. Commit votes of a block are in the next block, so the last
+// finalized block can't be exported.
+type archiveExport struct {
+	chain *singleChain
+	bm    module.BlockManager
+	sm    module.ServiceManager
+	file  string
+	from  int64
+	to    int64
+
+	current int64
+	stop    int32
+	result  resultStore
+}
+
+func (e *archiveExport) String() string {
+	return fmt.Sprintf("ArchiveExport(file=%s,from=%d,to=%d)",
+		path.Base(e.file), e.from, e.to)
+}
+
+func (e *archiveExport) Detail() string {
+	return fmt.Sprintf("export %d/%d", atomic.LoadInt64(&e.current), e.to)
+}
+
+func (e *archiveExport) _interrupted() error {
+	if atomic.LoadInt32(&e.stop) != 0 {
+		return errors.ErrInterrupted
+	}
+	return nil
+}
+
+func (e *archiveExport) Start() error {
+	e.bm = e.chain.bm
+	e.sm = e.chain.sm
+	blk, err := e.bm.GetLastBlock()
+	if err != nil {
+		return err
+	}
+	last := blk.Height()
+	if e.to <= 0 {
+		e.to = last - 1
+	}
+	if e.from < 0 || e.from > e.to || e.to >= last {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidRange(from=%d,to=%d,last=%d)", e.from, e.to, last)
+	}
+	gblk, _, err := e.bm.GetGenesisData()
+	if err != nil {
+		return err
+	}
+	first := e.from
+	if gblk != nil && gblk.Height() == first {
+		first += 1
+	} else if gblk != nil && gblk.Height() > first {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidRange(from=%d,genesis=%d)", e.from, gblk.Height())
+	}
+	if base := block.GetBodyBaseOf(e.chain.Database()); base > 0 && base > first {
+		return errors.InvalidStateError.Errorf(
+			"PrunedBlocks(from=%d,base=%d)", e.from, base)
+	}
+	atomic.StoreInt64(&e.current, e.from)
+
+	go func() {
+		e.result.SetValue(e._export())
+	}()
+	return nil
+}
+
+func (e *archiveExport) _export() (rerr error) {
+	e.chain.logger.Infof("Export archive file=%s from=%d to=%d",
+		e.file, e.from, e.to)
+	tmp, err := ioutil.TempFile(path.Dir(e.file), TemporalBackupFile)
+	if err != nil {
+		return errors.Wrap(err, "Fail to make temporal file")
+	}
+	defer func() {
+		if rerr != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+	if err := e._write(tmp); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), e.file)
+}
+
+func (e *archiveExport) _write(fd *os.File) error {
+	defer fd.Close()
+	if err := fd.Chmod(0644); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(fd)
+	aw, err := archive.NewWriter(bw, &archive.Meta{
+		NID:  e.chain.NID(),
+		CID:  e.chain.CID(),
+		From: e.from,
+		To:   e.to,
+	})
+	if err != nil {
+		return err
+	}
+	for h := e.from; h <= e.to; h++ {
+		if err := e._interrupted(); err != nil {
+			return err
+		}
+		rec, err := e._recordOf(h)
+		if err != nil {
+			return err
+		}
+		if err := aw.WriteRecord(rec); err != nil {
+			return err
+		}
+		atomic.StoreInt64(&e.current, h)
+	}
+	if err := aw.Close(); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return fd.Sync()
+}
+
+func (e *archiveExport) _recordOf(height int64) (*archive.Record, error) {
+	blk, err := e.bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	nblk, err := e.bm.GetBlockByHeight(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	rec := &archive.Record{
+		Height: height,
+		Votes:  nblk.Votes().Bytes(),
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := blk.MarshalHeader(buf); err != nil {
+		return nil, err
+	}
+	rec.Header = buf.Bytes()
+	buf = bytes.NewBuffer(nil)
+	if err := blk.MarshalBody(buf); err != nil {
+		return nil, err
+	}
+	rec.Body = buf.Bytes()
+	if rec.PatchReceipts, err = receiptsOf(e.sm, blk.Result(),
+		module.TransactionGroupPatch); err != nil {
+		return nil, err
+	}
+	if rec.NormalReceipts, err = receiptsOf(e.sm, blk.Result(),
+		module.TransactionGroupNormal); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// receiptsOf returns the bytes of the receipts of the group in the result.
+// The result of the genesis block is empty.
+func receiptsOf(sm module.ServiceManager, result []byte, g module.TransactionGroup) ([][]byte, error) {
+	if len(result) == 0 {
+		return nil, nil
+	}
+	rl, err := sm.ReceiptListFromResult(result, g)
+	if err != nil {
+		return nil, err
+	}
+	var rcts [][]byte
+	for itr := rl.Iterator(); itr.Has(); itr.Next() {
+		rct, err := itr.Get()
+		if err != nil {
+			return nil, err
+		}
+		rcts = append(rcts, rct.Bytes())
+	}
+	return rcts, nil
+}
+
+func (e *archiveExport) Stop() {
+	atomic.StoreInt32(&e.stop, 1)
+}
+
+func (e *archiveExport) Wait() error {
+	return e.result.Wait()
+}
+
+func newArchiveExport(chain *singleChain, file string, from, to int64) *archiveExport {
+	return &archiveExport{
+		chain: chain,
+		file:  file,
+		from:  from,
+		to:    to,
+	}
+}
+
+var exportArchiveStates = map[State]string{
+	Starting: "export starting",
+	Stopping: "export stopping",
+	Failed:   "export failed",
+	Finished: "export done",
+}
+
+// taskExportArchive exports the blocks into a block archive while the chain
+// is stopped.
+type taskExportArchive struct {
+	chain  *singleChain
+	export *archiveExport
+	result resultStore
+}
+
+func (t *taskExportArchive) String() string {
+	return fmt.Sprintf("ExportArchive(file=%s,from=%d,to=%d)",
+		path.Base(t.export.file), t.export.from, t.export.to)
+}
+
+func (t *taskExportArchive) DetailOf(s State) string {
+	switch s {
+	case Started:
+		return t.export.Detail()
+	default:
+		if st, ok := exportArchiveStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskExportArchive) Start() error {
+	if err := t.chain.prepareManagers(); err != nil {
+		return err
+	}
+	if err := t.export.Start(); err != nil {
+		t.chain.releaseManagers()
+		return err
+	}
+	go func() {
+		err := t.export.Wait()
+		t.chain.releaseManagers()
+		t.result.SetValue(err)
+	}()
+	return nil
+}
+
+func (t *taskExportArchive) Stop() {
+	t.export.Stop()
+}
+
+func (t *taskExportArchive) Wait() error {
+	return t.result.Wait()
+}
+
+func newTaskExportArchive(chain *singleChain, file string, from, to int64) chainTask {
+	return &taskExportArchive{
+		chain:  chain,
+		export: newArchiveExport(chain, file, from, to),
+	}
+}
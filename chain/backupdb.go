@@ -0,0 +1,197 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+const mergeBatchSize = 1024
+
+// backupBuckets is the list of buckets written by exporting blocks.
+var backupBuckets = []db.BucketID{
+	db.MerkleTrie,
+	db.BytesByHash,
+	db.TransactionLocatorByHash,
+	db.BlockHeaderHashByHeight,
+	db.BlockV1ByHash,
+	db.ReceiptV1ByHash,
+	db.ChainProperty,
+}
+
+// incrementBucket returns the values in the base bucket as if they are in
+// the bucket, but it writes only to the bucket. So the values written on
+// exporting blocks are skipped if they are already in the base.
+type incrementBucket struct {
+	base db.Bucket
+	real db.Bucket
+}
+
+func (bk *incrementBucket) Get(key []byte) ([]byte, error) {
+	if value, err := bk.real.Get(key); err != nil || value != nil {
+		return value, err
+	}
+	return bk.base.Get(key)
+}
+
+func (bk *incrementBucket) Has(key []byte) bool {
+	return bk.real.Has(key) || bk.base.Has(key)
+}
+
+func (bk *incrementBucket) Set(key []byte, value []byte) error {
+	return bk.real.Set(key, value)
+}
+
+func (bk *incrementBucket) Delete(key []byte) error {
+	return bk.real.Delete(key)
+}
+
+// NewIterator returns an iterator over the entries written to the bucket.
+func (bk *incrementBucket) NewIterator(r *db.Range) db.Iterator {
+	return bk.real.NewIterator(r)
+}
+
+type incrementDB struct {
+	base db.Database
+	real db.Database
+}
+
+func (idb *incrementDB) GetBucket(id db.BucketID) (db.Bucket, error) {
+	base, err := idb.base.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	real, err := idb.real.GetBucket(id)
+	if err != nil {
+		return nil, err
+	}
+	return &incrementBucket{base: base, real: real}, nil
+}
+
+func (idb *incrementDB) NewBatch() db.Batch {
+	return idb.real.NewBatch()
+}
+
+func (idb *incrementDB) Close() error {
+	return nil
+}
+
+func newIncrementDB(base, real db.Database) db.Database {
+	return &incrementDB{base: base, real: real}
+}
+
+func openBackupDB(chainDir, dbType string, nid int) (db.Database, error) {
+	dbDir := path.Join(chainDir, DefaultDBDir)
+	if _, err := os.Stat(dbDir); err != nil {
+		return nil, errors.NotFoundError.Wrapf(err, "NoDatabase(dir=%s)", dbDir)
+	}
+	name := strconv.FormatInt(int64(nid), 16)
+	return db.Open(dbDir, dbType, name)
+}
+
+func checkBlockHash(dbase db.Database, height int64, hash []byte) error {
+	if h := block.GetBlockHashOf(dbase, height); !bytes.Equal(h, hash) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidBlockHash(height=%d,exp=%#x,real=%#x)", height, hash, h)
+	}
+	return nil
+}
+
+// MergeBackup merges the chain data extracted from an incremental backup in
+// src into the chain data in dst, which should be at the base of the
+// increment. It verifies the hashes of the blocks at the base and at the
+// last height of the increment.
+func MergeBackup(dst, src string, info *BackupInfo, dbType string) error {
+	if !info.IsIncremental() {
+		return errors.IllegalArgumentError.New("NotIncrementalBackup")
+	}
+	nid := int(info.NID.Value)
+	if dbType == "" {
+		dbType = string(db.GoLevelDBBackend)
+	}
+
+	dstDB, err := openBackupDB(dst, dbType, nid)
+	if err != nil {
+		return err
+	}
+	defer dstDB.Close()
+
+	srcDB, err := openBackupDB(src, dbType, nid)
+	if err != nil {
+		return err
+	}
+	defer srcDB.Close()
+
+	return mergeBackupDB(dstDB, srcDB, info)
+}
+
+// mergeBackupDB merges the chain data of an incremental backup in srcDB into
+// the chain data in dstDB.
+func mergeBackupDB(dstDB, srcDB db.Database, info *BackupInfo) error {
+	if last := block.GetLastHeightOf(dstDB); last != info.Base {
+		return errors.InvalidStateError.Errorf(
+			"InvalidBaseHeight(exp=%d,real=%d)", info.Base, last)
+	}
+	if err := checkBlockHash(dstDB, info.Base, info.BaseHash); err != nil {
+		return err
+	}
+	if err := checkBlockHash(srcDB, info.Base, info.BaseHash); err != nil {
+		return err
+	}
+	if err := checkBlockHash(srcDB, info.Height, info.Hash); err != nil {
+		return err
+	}
+
+	for _, id := range backupBuckets {
+		if err := copyBucket(dstDB, srcDB, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyBucket(dst, src db.Database, id db.BucketID) error {
+	sbk, err := src.GetBucket(id)
+	if err != nil {
+		return err
+	}
+	batch := dst.NewBatch()
+	itr := sbk.NewIterator(nil)
+	defer itr.Release()
+	for itr.Next() {
+		if err := batch.Set(id, itr.Key(), itr.Value()); err != nil {
+			return err
+		}
+		if batch.Len() >= mergeBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
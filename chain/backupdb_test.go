@@ -0,0 +1,225 @@
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// keyLastBlockHeight is the key of the last height in db.ChainProperty
+// used by the block manager.
+const keyLastBlockHeight = "block.lastHeight"
+
+// tBackupSource has a world state for each height, and the states of
+// consecutive heights share most of the nodes.
+type tBackupSource struct {
+	db     db.Database
+	ws     state.WorldState
+	states [][]byte
+	hashes [][]byte
+}
+
+func newBackupSource(t *testing.T, n int) *tBackupSource {
+	return newBackupSourceOn(t, db.NewMapDB(), n)
+}
+
+func newBackupSourceOn(t *testing.T, dbase db.Database, n int) *tBackupSource {
+	src := &tBackupSource{
+		db: dbase,
+		ws: state.NewWorldState(dbase, nil, nil),
+	}
+	for h := 0; h < n; h++ {
+		src.grow(t)
+	}
+	return src
+}
+
+// applyStateOf changes the world state for the height.
+func applyStateOf(t *testing.T, ws state.WorldState, h int) {
+	addr := common.NewAddressFromString(fmt.Sprintf("hx%040x", h%5+1))
+	as := ws.GetAccountState(addr.ID())
+	as.SetBalance(big.NewInt(int64(h)))
+	_, err := as.SetValue([]byte(fmt.Sprintf("key%d", h)), []byte{byte(h)})
+	assert.NoError(t, err)
+}
+
+// grow adds the world state and the block of the next height.
+func (src *tBackupSource) grow(t *testing.T) {
+	h := len(src.states)
+	hbk, err := src.db.GetBucket(db.BytesByHash)
+	assert.NoError(t, err)
+	ibk, err := src.db.GetBucket(db.BlockHeaderHashByHeight)
+	assert.NoError(t, err)
+
+	applyStateOf(t, src.ws, h)
+	wss := src.ws.GetSnapshot()
+	assert.NoError(t, wss.Flush())
+
+	header := append([]byte(fmt.Sprintf("header%d", h)), wss.StateHash()...)
+	hash := crypto.SHA3Sum256(header)
+	assert.NoError(t, hbk.Set(hash, header))
+	assert.NoError(t, ibk.Set(codec.BC.MustMarshalToBytes(int64(h)), hash))
+	src.states = append(src.states, wss.StateHash())
+	src.hashes = append(src.hashes, hash)
+}
+
+// export exports the blocks in the range into dst like ExportBlocks, which
+// exports the block before the first one too.
+func (src *tBackupSource) export(t *testing.T, from, to int64, dst db.Database) {
+	ctx := merkle.NewCopyContext(src.db, dst)
+	if from > 0 {
+		from -= 1
+	}
+	for h := from; h <= to; h++ {
+		_, err := state.NewWorldSnapshotWithBuilder(ctx.Builder(), src.states[h], nil)
+		assert.NoError(t, err)
+		assert.NoError(t, ctx.Run())
+		assert.NoError(t, ctx.Copy(db.BytesByHash, src.hashes[h]))
+		hb := codec.BC.MustMarshalToBytes(h)
+		assert.NoError(t, ctx.Copy(db.BlockHeaderHashByHeight, hb))
+		assert.NoError(t, ctx.Set(db.ChainProperty, []byte(keyLastBlockHeight), hb))
+	}
+}
+
+func (src *tBackupSource) info(base, height int64) *BackupInfo {
+	return &BackupInfo{
+		NID:      common.HexInt32{Value: 1},
+		Height:   height,
+		Hash:     src.hashes[height],
+		Base:     base,
+		BaseHash: src.hashes[base],
+	}
+}
+
+func entriesOf(t *testing.T, dbase db.Database, id db.BucketID) map[string][]byte {
+	bk, err := dbase.GetBucket(id)
+	assert.NoError(t, err)
+	entries := make(map[string][]byte)
+	itr := bk.NewIterator(nil)
+	defer itr.Release()
+	for itr.Next() {
+		entries[string(itr.Key())] = append([]byte(nil), itr.Value()...)
+	}
+	assert.NoError(t, itr.Error())
+	return entries
+}
+
+func countOf(t *testing.T, dbase db.Database) int {
+	cnt := 0
+	for _, id := range backupBuckets {
+		cnt += len(entriesOf(t, dbase, id))
+	}
+	return cnt
+}
+
+// exportIncrement exports the blocks after base up to height like the live
+// backup does for an incremental backup.
+func (src *tBackupSource) exportIncrement(t *testing.T, base, height int64) db.Database {
+	based := db.NewMapDB()
+	src.export(t, base+1, base, based)
+	inc := db.NewMapDB()
+	src.export(t, base+1, height, newIncrementDB(based, inc))
+	return inc
+}
+
+func TestIncrementDB_Bucket(t *testing.T) {
+	base := db.NewMapDB()
+	real := db.NewMapDB()
+	bbk, _ := base.GetBucket(db.BytesByHash)
+	assert.NoError(t, bbk.Set([]byte("k1"), []byte("v1")))
+
+	idb := newIncrementDB(base, real)
+	bk, err := idb.GetBucket(db.BytesByHash)
+	assert.NoError(t, err)
+
+	// values in the base are visible, but not written
+	assert.True(t, bk.Has([]byte("k1")))
+	v, err := bk.Get([]byte("k1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+
+	assert.NoError(t, bk.Set([]byte("k2"), []byte("v2")))
+	assert.True(t, bk.Has([]byte("k2")))
+	assert.Equal(t, map[string][]byte{"k2": []byte("v2")},
+		entriesOf(t, real, db.BytesByHash))
+	assert.Equal(t, map[string][]byte{"k2": []byte("v2")},
+		entriesOf(t, idb, db.BytesByHash))
+	assert.False(t, bbk.Has([]byte("k2")))
+
+	assert.NoError(t, bk.Delete([]byte("k2")))
+	assert.False(t, bk.Has([]byte("k2")))
+	assert.True(t, bk.Has([]byte("k1")))
+}
+
+func TestMergeBackup(t *testing.T) {
+	src := newBackupSource(t, 20)
+
+	full := db.NewMapDB()
+	src.export(t, 0, 19, full)
+
+	dst := db.NewMapDB()
+	src.export(t, 0, 9, dst)
+	inc1 := src.exportIncrement(t, 9, 14)
+	inc2 := src.exportIncrement(t, 14, 19)
+
+	// the increment has only the data not reachable from the base
+	plain := db.NewMapDB()
+	src.export(t, 10, 14, plain)
+	assert.True(t, countOf(t, inc1) < countOf(t, plain))
+
+	assert.NoError(t, mergeBackupDB(dst, inc1, src.info(9, 14)))
+	assert.NoError(t, mergeBackupDB(dst, inc2, src.info(14, 19)))
+
+	for _, id := range backupBuckets {
+		assert.Equal(t, entriesOf(t, full, id), entriesOf(t, dst, id),
+			"bucket=%s", id)
+	}
+
+	wss := state.NewWorldSnapshot(dst, src.states[19], nil)
+	for h := 0; h < 20; h++ {
+		addr := common.NewAddressFromString(fmt.Sprintf("hx%040x", h%5+1))
+		ass := wss.GetAccountSnapshot(addr.ID())
+		if assert.NotNil(t, ass) {
+			v, err := ass.GetValue([]byte(fmt.Sprintf("key%d", h)))
+			assert.NoError(t, err)
+			assert.True(t, bytes.Equal([]byte{byte(h)}, v))
+		}
+	}
+}
+
+func TestMergeBackup_Invalid(t *testing.T) {
+	src := newBackupSource(t, 10)
+
+	dst := db.NewMapDB()
+	src.export(t, 0, 4, dst)
+	inc := src.exportIncrement(t, 5, 9)
+
+	// base of the increment is not the last of dst
+	err := mergeBackupDB(dst, inc, src.info(5, 9))
+	assert.Error(t, err)
+
+	// different hash of the last block
+	info := src.info(4, 9)
+	info.Hash = src.hashes[8]
+	inc = src.exportIncrement(t, 4, 9)
+	err = mergeBackupDB(dst, inc, info)
+	assert.Error(t, err)
+
+	// different base hash
+	info = src.info(4, 9)
+	info.BaseHash = src.hashes[3]
+	err = mergeBackupDB(dst, inc, info)
+	assert.Error(t, err)
+
+	assert.NoError(t, mergeBackupDB(dst, inc, src.info(4, 9)))
+}
@@ -0,0 +1,400 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"time"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	keyBlockPruneRefHeight = "blockprune.refHeight"
+
+	// blockPruneMinKeep is the minimum number of the last blocks whose
+	// transactions and receipts are kept regardless of the policy.
+	blockPruneMinKeep = 100
+
+	// blockPruneMinBlocks is the minimum number of blocks to start pruning.
+	blockPruneMinBlocks = 100
+
+	blockPruneInterval = time.Minute
+)
+
+// blockPruner removes transactions and receipts of the blocks out of the
+// retention policy while the chain is running. Headers, votes and locators of
+// the transactions are kept, so the queries for the removed data fail with
+// block.PrunedBlockError.
+//
+// Merkle trie nodes of receipts may be shared by the blocks having the same
+// receipts, so it maintains the last height of the blocks referring each node
+// of receipts in ReceiptNodeRefByHash bucket. A node is deleted with the last
+// block referring it.
+//
+// Pruning is paused while live tasks exporting blocks hold the database, so
+// they don't see blocks being pruned.
+//
+// A pruned node can't serve block sync for the blocks below the body base. It
+// responds as if it doesn't have them, so peers should sync those blocks from
+// other nodes.
+type blockPruner struct {
+	chain    *singleChain
+	database *gcDatabase
+	bm       module.BlockManager
+	sm       module.ServiceManager
+	keep     int64
+	days     int
+	log      log.Logger
+
+	stop   chan struct{}
+	result resultStore
+}
+
+func (p *blockPruner) Start() {
+	p.log.Infof("BlockPruner started keep=%d days=%d", p.keep, p.days)
+	go func() {
+		p.result.SetValue(p.run())
+	}()
+}
+
+func (p *blockPruner) run() error {
+	for {
+		if err := p.prune(); err != nil {
+			if errors.InterruptedError.Equals(err) {
+				return err
+			}
+			p.log.Warnf("BlockPruner failed err=%+v", err)
+		}
+		select {
+		case <-p.stop:
+			return errors.ErrInterrupted
+		case <-time.After(blockPruneInterval):
+		}
+	}
+}
+
+func (p *blockPruner) Stop() {
+	close(p.stop)
+}
+
+func (p *blockPruner) Wait() error {
+	return p.result.Wait()
+}
+
+func (p *blockPruner) _interrupted() error {
+	select {
+	case <-p.stop:
+		return errors.ErrInterrupted
+	default:
+		return nil
+	}
+}
+
+func (p *blockPruner) lastHeight() (int64, error) {
+	blk, err := p.bm.GetLastBlock()
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+// base returns the lowest height of the blocks which are not pruned.
+// Transactions of the genesis block are used on start, so they are kept.
+func (p *blockPruner) base() (int64, error) {
+	base := block.GetBodyBaseOf(p.database)
+	gblk, _, err := p.bm.GetGenesisData()
+	if err != nil {
+		return 0, err
+	}
+	var height int64
+	if gblk != nil {
+		height = gblk.Height()
+	}
+	if base <= height {
+		base = height + 1
+	}
+	return base, nil
+}
+
+// target returns the highest height of the blocks to be pruned.
+func (p *blockPruner) target(base, last int64) (int64, error) {
+	to := last - blockPruneMinKeep
+	if p.keep > 0 && last-p.keep < to {
+		to = last - p.keep
+	}
+	if p.days > 0 {
+		limit := time.Now().Add(-time.Duration(p.days) * 24 * time.Hour)
+		ts := limit.UnixNano() / int64(time.Microsecond)
+		// find the highest height of the block created before the limit
+		low, high := base-1, to
+		for low < high {
+			mid := (low + high + 1) / 2
+			blk, err := p.bm.GetBlockByHeight(mid)
+			if err != nil {
+				return 0, err
+			}
+			if blk.Timestamp() < ts {
+				low = mid
+			} else {
+				high = mid - 1
+			}
+		}
+		to = low
+	}
+	return to, nil
+}
+
+func (p *blockPruner) refHeight() (int64, error) {
+	bk, err := p.database.GetBucket(db.ChainProperty)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := bk.Get([]byte(keyBlockPruneRefHeight))
+	if err != nil || bs == nil {
+		return -1, err
+	}
+	var height int64
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+func (p *blockPruner) setRefHeight(height int64) error {
+	bk, err := p.database.GetBucket(db.ChainProperty)
+	if err != nil {
+		return err
+	}
+	return bk.Set([]byte(keyBlockPruneRefHeight), codec.BC.MustMarshalToBytes(height))
+}
+
+// exportNodes returns the database having the nodes of receipts of the
+// block. If withTxs is true, the nodes of transactions are also included.
+func (p *blockPruner) exportNodes(blk module.Block, withTxs bool) (db.Database, error) {
+	nodes := db.NewMapDB()
+	if err := p.sm.ExportReceipts(blk.Result(), nodes); err != nil {
+		return nil, err
+	}
+	if withTxs {
+		if err := p.sm.ExportTransactions(blk.PatchTransactions().Hash(), nodes); err != nil {
+			return nil, err
+		}
+		if err := p.sm.ExportTransactions(blk.NormalTransactions().Hash(), nodes); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// updateRefs records the blocks from the height to the height to as the last
+// blocks referring the nodes of their receipts.
+func (p *blockPruner) updateRefs(from, to int64) error {
+	refs, err := p.database.GetBucket(db.ReceiptNodeRefByHash)
+	if err != nil {
+		return err
+	}
+	for h := from; h <= to; h++ {
+		if err := p._interrupted(); err != nil {
+			return err
+		}
+		blk, err := p.bm.GetBlockByHeight(h)
+		if err != nil {
+			return err
+		}
+		nodes, err := p.exportNodes(blk, false)
+		if err != nil {
+			return errors.Wrapf(err, "fail to export receipts height=%d", h)
+		}
+		bk, err := nodes.GetBucket(db.MerkleTrie)
+		if err != nil {
+			return err
+		}
+		value := codec.BC.MustMarshalToBytes(h)
+		itr := bk.NewIterator(nil)
+		for itr.Next() {
+			if err := refs.Set(itr.Key(), value); err != nil {
+				itr.Release()
+				return err
+			}
+		}
+		itr.Release()
+		if err := p.setRefHeight(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastRefOf returns the last height of the blocks referring the node.
+// It returns -1 if it's not a node of receipts.
+func lastRefOf(refs db.Bucket, key []byte) (int64, error) {
+	bs, err := refs.Get(key)
+	if err != nil || bs == nil {
+		return -1, err
+	}
+	var height int64
+	if _, err := codec.BC.UnmarshalFromBytes(bs, &height); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+func (p *blockPruner) pruneBlock(rec *gcRecord, height int64) (int, error) {
+	blk, err := p.bm.GetBlockByHeight(height)
+	if err != nil {
+		return 0, err
+	}
+	nodes, err := p.exportNodes(blk, true)
+	if err != nil {
+		return 0, errors.Wrapf(err, "fail to export body height=%d", height)
+	}
+	if err := block.SetBodyBaseOf(p.database, height+1); err != nil {
+		return 0, err
+	}
+
+	bk, err := p.database.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return 0, err
+	}
+	refs, err := p.database.GetBucket(db.ReceiptNodeRefByHash)
+	if err != nil {
+		return 0, err
+	}
+	nbk, err := nodes.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int
+	itr := nbk.NewIterator(nil)
+	defer itr.Release()
+	for itr.Next() {
+		key := itr.Key()
+		ref, err := lastRefOf(refs, key)
+		if err != nil {
+			return deleted, err
+		}
+		if ref > height {
+			continue
+		}
+		if ok, err := p.database.deleteIfNotWritten(rec, bk, key); err != nil {
+			return deleted, err
+		} else if ok {
+			deleted += 1
+		}
+		if ref >= 0 {
+			if err := refs.Delete(key); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	return deleted, itr.Error()
+}
+
+func (p *blockPruner) waitForHeight(height int64) (int64, error) {
+	for {
+		last, err := p.lastHeight()
+		if err != nil {
+			return 0, err
+		}
+		if last >= height {
+			return last, nil
+		}
+		select {
+		case <-p.stop:
+			return 0, errors.ErrInterrupted
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (p *blockPruner) prune() error {
+	base, err := p.base()
+	if err != nil {
+		return err
+	}
+	last, err := p.lastHeight()
+	if err != nil {
+		return err
+	}
+	to, err := p.target(base, last)
+	if err != nil {
+		return err
+	}
+	if to-base+1 < blockPruneMinBlocks {
+		return nil
+	}
+
+	rec := p.database.startRecord()
+	defer p.database.stopRecord(rec)
+
+	// Receipts written before recording are for the blocks being finalized,
+	// so it waits for them and records their references.
+	current, err := p.waitForHeight(last + stateGCSafeBlocks)
+	if err != nil {
+		return err
+	}
+	ref, err := p.refHeight()
+	if err != nil {
+		return err
+	}
+	from := ref + 1
+	if from < base {
+		from = base
+	}
+	if from <= current {
+		p.log.Infof("BlockPruner update references from=%d to=%d", from, current)
+		if err := p.updateRefs(from, current); err != nil {
+			return err
+		}
+	}
+
+	p.log.Infof("BlockPruner prune blocks from=%d to=%d", base, to)
+	var deleted int
+	for h := base; h <= to; h++ {
+		if err := p._interrupted(); err != nil {
+			return err
+		}
+		if err := p.database.runWhenNotHeld(p.stop, func() error {
+			cnt, err := p.pruneBlock(rec, h)
+			deleted += cnt
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	p.log.Infof("BlockPruner pruned blocks from=%d to=%d deleted=%d",
+		base, to, deleted)
+	return nil
+}
+
+func newBlockPruner(chain *singleChain, database *gcDatabase, keep int64, days int) *blockPruner {
+	return &blockPruner{
+		chain:    chain,
+		database: database,
+		bm:       chain.bm,
+		sm:       chain.sm,
+		keep:     keep,
+		days:     days,
+		log:      chain.logger,
+		stop:     make(chan struct{}),
+	}
+}
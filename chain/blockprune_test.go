@@ -0,0 +1,221 @@
+package chain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/transaction"
+	"github.com/icon-project/goloop/service/txresult"
+	"github.com/icon-project/goloop/test"
+)
+
+type tPruneBlock struct {
+	tGCBlock
+	timestamp int64
+	txs       module.TransactionList
+}
+
+func (b *tPruneBlock) Timestamp() int64 {
+	return b.timestamp
+}
+
+func (b *tPruneBlock) PatchTransactions() module.TransactionList {
+	return b.txs
+}
+
+func (b *tPruneBlock) NormalTransactions() module.TransactionList {
+	return b.txs
+}
+
+type tPruneBlockManager struct {
+	test.BlockManagerBase
+	blocks []*tPruneBlock
+}
+
+func (bm *tPruneBlockManager) GetGenesisData() (module.Block, module.CommitVoteSet, error) {
+	return bm.blocks[0], nil, nil
+}
+
+func (bm *tPruneBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height < 0 || height >= int64(len(bm.blocks)) {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return bm.blocks[height], nil
+}
+
+func (bm *tPruneBlockManager) GetLastBlock() (module.Block, error) {
+	return bm.blocks[len(bm.blocks)-1], nil
+}
+
+// newPruneBlockManager returns the blocks of which timestamps are made by
+// tsOf, and receipts are made by rctsOf.
+func newPruneBlockManager(
+	dbase db.Database, n int,
+	tsOf func(h int) int64,
+	rctsOf func(h int) []txresult.Receipt,
+) *tPruneBlockManager {
+	bm := new(tPruneBlockManager)
+	txs := transaction.NewTransactionListFromSlice(dbase, nil)
+	for h := 0; h < n; h++ {
+		blk := &tPruneBlock{
+			tGCBlock:  tGCBlock{height: int64(h)},
+			timestamp: tsOf(h),
+			txs:       txs,
+		}
+		if rctsOf != nil && h > 0 {
+			rl := txresult.NewReceiptListFromSlice(dbase, rctsOf(h))
+			if err := rl.Flush(); err != nil {
+				panic(err)
+			}
+			blk.result = codec.BC.MustMarshalToBytes(&tResult{
+				NormalReceiptHash: rl.Hash(),
+			})
+		}
+		bm.blocks = append(bm.blocks, blk)
+	}
+	return bm
+}
+
+func (sm *tGCServiceManager) ExportReceipts(result []byte, d db.Database) error {
+	if len(result) == 0 {
+		return nil
+	}
+	var r tResult
+	if _, err := codec.BC.UnmarshalFromBytes(result, &r); err != nil {
+		return err
+	}
+	e := merkle.NewCopyContext(sm.db, d)
+	txresult.NewReceiptListWithBuilder(e.Builder(), r.NormalReceiptHash)
+	txresult.NewReceiptListWithBuilder(e.Builder(), r.PatchReceiptHash)
+	return e.Run()
+}
+
+func (sm *tGCServiceManager) ExportTransactions(hash []byte, d db.Database) error {
+	e := merkle.NewCopyContext(sm.db, d)
+	transaction.NewTransactionListWithBuilder(e.Builder(), hash)
+	return e.Run()
+}
+
+func TestBlockPruner_Target(t *testing.T) {
+	const last = 299
+	now := time.Now()
+	// a block for every 10 minutes, and the block at 154 is the last one
+	// created a day before.
+	bm := newPruneBlockManager(db.NewMapDB(), last+1, func(h int) int64 {
+		ts := now.Add(time.Duration(last-h)*-10*time.Minute + 5*time.Minute)
+		return ts.UnixNano() / int64(time.Microsecond)
+	}, nil)
+
+	cases := []struct {
+		name string
+		keep int64
+		days int
+		base int64
+		to   int64
+	}{
+		{"MinKeep", 0, 0, 1, last - blockPruneMinKeep},
+		{"Keep", 200, 0, 1, last - 200},
+		{"Days", 0, 1, 1, 154},
+		{"KeepAndDays", 200, 1, 1, last - 200},
+		{"DaysWithBase", 0, 1, 100, 154},
+		{"DaysOverBase", 0, 1, 180, 179},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := &blockPruner{bm: bm, keep: c.keep, days: c.days}
+			to, err := p.target(c.base, last)
+			assert.NoError(t, err)
+			assert.Equal(t, c.to, to)
+		})
+	}
+}
+
+func TestBlockPruner_SharedReceipts(t *testing.T) {
+	gcdb := newGCDatabase(db.NewMapDB())
+	score := common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	receiptsOf := func(v int) []txresult.Receipt {
+		var rcts []txresult.Receipt
+		for i := 0; i < 2; i++ {
+			rct := txresult.NewReceipt(gcdb, 0, score)
+			rct.AddLog(score, [][]byte{[]byte("Event(int,int)")},
+				[][]byte{{byte(v)}, {byte(i)}})
+			rct.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+			rcts = append(rcts, rct)
+		}
+		return rcts
+	}
+	// receipts of the block at 3 are same as the ones at 1.
+	bm := newPruneBlockManager(gcdb, 5, func(h int) int64 {
+		return int64(h)
+	}, func(h int) []txresult.Receipt {
+		if h == 3 {
+			return receiptsOf(1)
+		}
+		return receiptsOf(h)
+	})
+	sm := &tGCServiceManager{db: gcdb}
+	p := &blockPruner{
+		database: gcdb,
+		bm:       bm,
+		sm:       sm,
+		stop:     make(chan struct{}),
+	}
+	exportOf := func(h int) error {
+		return sm.ExportReceipts(bm.blocks[h].Result(), db.NewMapDB())
+	}
+	countOfNodes := func() int {
+		return len(entriesOf(t, gcdb, db.MerkleTrie))
+	}
+
+	assert.NoError(t, p.updateRefs(1, 4))
+	ref, err := p.refHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), ref)
+
+	rec := gcdb.startRecord()
+	defer gcdb.stopRecord(rec)
+
+	// all nodes of the block at 1 are referred by the block at 3
+	cnt := countOfNodes()
+	deleted, err := p.pruneBlock(rec, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	assert.Equal(t, cnt, countOfNodes())
+	assert.Equal(t, int64(2), block.GetBodyBaseOf(gcdb))
+
+	deleted, err = p.pruneBlock(rec, 2)
+	assert.NoError(t, err)
+	assert.True(t, deleted > 0)
+	assert.Error(t, exportOf(2))
+	assert.NoError(t, exportOf(1))
+	assert.NoError(t, exportOf(3))
+	assert.NoError(t, exportOf(4))
+
+	deleted, err = p.pruneBlock(rec, 3)
+	assert.NoError(t, err)
+	assert.True(t, deleted > 0)
+	assert.Error(t, exportOf(3))
+	assert.NoError(t, exportOf(4))
+	assert.Equal(t, int64(4), block.GetBodyBaseOf(gcdb))
+
+	// nodes written after recording are kept
+	rl := txresult.NewReceiptListFromSlice(gcdb, receiptsOf(4))
+	assert.NoError(t, rl.Flush())
+	assert.Equal(t, bm.blocks[4].result, codec.BC.MustMarshalToBytes(&tResult{
+		NormalReceiptHash: rl.Hash(),
+	}))
+	deleted, err = p.pruneBlock(rec, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	assert.NoError(t, exportOf(4))
+}
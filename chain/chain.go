@@ -72,10 +72,21 @@ type chainTask interface {
 	Wait() error
 }
 
+// liveTask is a task running in background while the consensus task is
+// running. Only one live task runs at a time.
+type liveTask interface {
+	String() string
+	Detail() string
+	Start() error
+	Stop()
+	Wait() error
+}
+
 type singleChain struct {
 	wallet module.Wallet
 
 	database db.Database
+	gcdb     *gcDatabase
 	vld      module.CommitVoteSetDecoder
 	pd       module.PatchDecoder
 	sm       module.ServiceManager
@@ -99,6 +110,12 @@ type singleChain struct {
 	task       chainTask
 	termWaiter *sync.Cond
 
+	live    liveTask
+	liveMtx sync.Mutex
+
+	gc     *stateGC
+	pruner *blockPruner
+
 	// monitor
 	metricCtx context.Context
 }
@@ -109,6 +126,7 @@ const (
 	DefaultContractDir = "contract"
 	DefaultCacheDir    = "cache"
 	DefaultTmpDBDir    = "tmp"
+	DefaultStateGCDir  = "gc"
 )
 
 func (c *singleChain) Database() db.Database {
@@ -197,6 +215,18 @@ func (c *singleChain) PatchTxPoolSize() int {
 	return ConfigDefaultPatchTxPoolSize
 }
 
+func (c *singleChain) SenderTxPoolSize() int {
+	return c.cfg.SenderTxPoolSize
+}
+
+func (c *singleChain) SenderTxBytes() int {
+	return c.cfg.SenderTxBytes
+}
+
+func (c *singleChain) PeerTxRate() int {
+	return c.cfg.PeerTxRate
+}
+
 func (c *singleChain) MaxBlockTxBytes() int {
 	if c.cfg.MaxBlockTxBytes > 0 {
 		return c.cfg.MaxBlockTxBytes
@@ -204,6 +234,17 @@ func (c *singleChain) MaxBlockTxBytes() int {
 	return ConfigDefaultMaxBlockTxBytes
 }
 
+func (c *singleChain) EventLogIndex() bool {
+	return c.cfg.EventLogIndex
+}
+
+func (c *singleChain) TxOrdering() string {
+	if len(c.cfg.TxOrdering) > 0 {
+		return c.cfg.TxOrdering
+	}
+	return service.TxOrderingDefault
+}
+
 func (c *singleChain) DefaultWaitTimeout() time.Duration {
 	if c.cfg.DefWaitTimeout > 0 {
 		return time.Duration(c.cfg.DefWaitTimeout) * time.Millisecond
@@ -233,7 +274,11 @@ func (c *singleChain) State() (string, int64, error) {
 				height = blk.Height()
 			}
 		}
-		return c.task.DetailOf(c.state), height, c.lastErr
+		detail := c.task.DetailOf(c.state)
+		if t := c.liveTask(); t != nil {
+			detail = fmt.Sprintf("%s (%s)", detail, t.Detail())
+		}
+		return detail, height, c.lastErr
 	default:
 		return c.state.String(), c.lastBlockHeight(), c.lastErr
 	}
@@ -370,6 +415,10 @@ func (c *singleChain) prepareDatabase(chainDir string) error {
 		cdb.Close()
 		return errors.Errorf("Unknown cache strategy:%s", c.cfg.NodeCache)
 	}
+	// It should be under the cache manager, which is used by the services
+	// with type assertion.
+	c.gcdb = newGCDatabase(cdb)
+	cdb = c.gcdb
 	if mLevel > 0 || fLevel > 0 {
 		cacheDir := path.Join(chainDir, DefaultCacheDir)
 		c.database = cache.AttachManager(cdb, cacheDir, mLevel, fLevel)
@@ -383,6 +432,7 @@ func (c *singleChain) releaseDatabase() {
 	if c.database != nil {
 		c.database.Close()
 		c.database = nil
+		c.gcdb = nil
 	}
 }
 
@@ -399,6 +449,9 @@ func (c *singleChain) _init() error {
 		}
 		c.cfg.GenesisStorage = gs.NewFromTx(c.cfg.Genesis)
 	}
+	if len(c.cfg.TxOrdering) > 0 && !IsTxOrderingOption(c.cfg.TxOrdering) {
+		return errors.IllegalArgumentError.Errorf("InvalidTxOrdering(%s)", c.cfg.TxOrdering)
+	}
 
 	chainDir := c.cfg.AbsBaseDir()
 	log.Println("ConfigFilepath", c.cfg.FilePath, "BaseDir", c.cfg.BaseDir, "ChainDir", chainDir)
@@ -548,6 +601,143 @@ func (c *singleChain) Backup(file string, extra []string) error {
 	return c._runTask(task, false)
 }
 
+// BackupLive starts to make a backup of the running chain without stopping
+// it. The backup contains the blocks up to the last finalized block when it
+// starts.
+func (c *singleChain) BackupLive(file string, extra []string) error {
+	return c._startLiveBackup(file, extra, 0, nil)
+}
+
+// BackupIncremental starts to make an incremental backup of the running
+// chain. It contains the blocks after the block at base, and the data which
+// are not reachable from the block. hash should be the hash of the block.
+func (c *singleChain) BackupIncremental(file string, base int64, hash []byte) error {
+	if len(hash) == 0 {
+		return errors.IllegalArgumentError.New("NoBaseHash")
+	}
+	return c._startLiveBackup(file, nil, base, hash)
+}
+
+// ExportArchive exports the blocks from the height from to the height to
+// into a block archive. If to is not positive, it exports up to the block
+// before the last. It runs in background if the chain is running.
+func (c *singleChain) ExportArchive(file string, from, to int64) error {
+	if c.IsStarted() {
+		return c._startLiveTask(func() liveTask {
+			return newArchiveExport(c, file, from, to)
+		})
+	}
+	task := newTaskExportArchive(c, file, from, to)
+	return c._runTask(task, false)
+}
+
+// ImportArchive imports the blocks in the block archive at src, which is a
+// file or a URL. The archive should have the next block of the last.
+func (c *singleChain) ImportArchive(src string) error {
+	task := newTaskImportArchive(c, src)
+	return c._runTask(task, false)
+}
+
+func (c *singleChain) _startLiveBackup(file string, extra []string, base int64, hash []byte) error {
+	return c._startLiveTask(func() liveTask {
+		return newLiveBackup(c, file, extra, base, hash)
+	})
+}
+
+func (c *singleChain) _startLiveTask(creator func() liveTask) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.task.(*taskConsensus); !ok || c.state != Started {
+		return errors.InvalidStateError.Errorf("InvalidState(state=%s)", c.state.String())
+	}
+
+	c.liveMtx.Lock()
+	defer c.liveMtx.Unlock()
+	if c.live != nil {
+		return errors.InvalidStateError.Errorf("AlreadyRunning(%s)", c.live.String())
+	}
+	// Deletions of the state GC and the block pruner are paused while the
+	// task exports the data.
+	gcdb := c.gcdb
+	if gcdb != nil {
+		gcdb.hold()
+	}
+	t := creator()
+	if err := t.Start(); err != nil {
+		if gcdb != nil {
+			gcdb.release()
+		}
+		return err
+	}
+	c.live = t
+	c.logger.Infof("STARTED %s", t.String())
+
+	go func() {
+		err := t.Wait()
+		if gcdb != nil {
+			gcdb.release()
+		}
+		c.logger.Infof("DONE %s err=%v", t.String(), err)
+
+		c.liveMtx.Lock()
+		defer c.liveMtx.Unlock()
+		if c.live == t {
+			c.live = nil
+		}
+	}()
+	return nil
+}
+
+func (c *singleChain) liveTask() liveTask {
+	c.liveMtx.Lock()
+	defer c.liveMtx.Unlock()
+	return c.live
+}
+
+// stopLiveTask interrupts the running live task and waits for it to be
+// finished. It should be called before the managers are released.
+func (c *singleChain) stopLiveTask() {
+	if t := c.liveTask(); t != nil {
+		t.Stop()
+		t.Wait()
+	}
+}
+
+func (c *singleChain) startStateGC() {
+	if c.cfg.StateGCKeep > 0 && c.gcdb != nil {
+		c.gc = newStateGC(c, c.gcdb, int64(c.cfg.StateGCKeep), c.cfg.StateGCRate)
+		c.gc.Start()
+	}
+}
+
+// stopStateGC interrupts the state garbage collector and waits for it to be
+// finished. It should be called before the managers are released.
+func (c *singleChain) stopStateGC() {
+	if c.gc != nil {
+		c.gc.Stop()
+		c.gc.Wait()
+		c.gc = nil
+	}
+}
+
+func (c *singleChain) startBlockPruner() {
+	if (c.cfg.BlockKeep > 0 || c.cfg.BlockKeepDays > 0) && c.gcdb != nil {
+		c.pruner = newBlockPruner(c, c.gcdb, int64(c.cfg.BlockKeep), c.cfg.BlockKeepDays)
+		c.pruner.Start()
+	}
+}
+
+// stopBlockPruner interrupts the block pruner and waits for it to be
+// finished. It should be called before the managers are released.
+func (c *singleChain) stopBlockPruner() {
+	if c.pruner != nil {
+		c.pruner.Stop()
+		c.pruner.Wait()
+		c.pruner = nil
+	}
+}
+
 func (c *singleChain) _handleTerminateInLock() {
 	if c.state != Terminating {
 		c.logger.Panicf("InvalidStateForTerminate(state=%s)", c.state.String())
@@ -592,6 +782,11 @@ func (c *singleChain) Reset() error {
 	return c._runTask(task, false)
 }
 
+func (c *singleChain) Reindex() error {
+	task := newTaskReindex(c)
+	return c._runTask(task, false)
+}
+
 func (c *singleChain) Logger() log.Logger {
 	return c.logger
 }
@@ -622,6 +817,10 @@ func NewChain(
 	return c
 }
 
+func IsTxOrderingOption(s string) bool {
+	return service.IsTxOrderingOption(s)
+}
+
 func IsNodeCacheOption(s string) bool {
 	for _, k := range NodeCacheOptions {
 		if k == s {
@@ -38,8 +38,17 @@ type Config struct {
 	ConcurrencyLevel int    `json:"concurrency_level,omitempty"`
 	NormalTxPoolSize int    `json:"normal_tx_pool,omitempty"`
 	PatchTxPoolSize  int    `json:"patch_tx_pool,omitempty"`
+	SenderTxPoolSize int    `json:"sender_tx_pool,omitempty"`
+	SenderTxBytes    int    `json:"sender_tx_bytes,omitempty"`
+	PeerTxRate       int    `json:"peer_tx_rate,omitempty"`
 	MaxBlockTxBytes  int    `json:"max_block_tx_bytes,omitempty"`
+	StateGCKeep      int    `json:"state_gc_keep,omitempty"`
+	StateGCRate      int    `json:"state_gc_rate,omitempty"`
+	BlockKeep        int    `json:"block_keep,omitempty"`
+	BlockKeepDays    int    `json:"block_keep_days,omitempty"`
 	NodeCache        string `json:"node_cache,omitempty"`
+	TxOrdering       string `json:"tx_ordering,omitempty"`
+	EventLogIndex    bool   `json:"event_log_index,omitempty"`
 	AutoStart        bool   `json:"auto_start,omitempty"`
 
 	// runtime
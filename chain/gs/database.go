@@ -36,6 +36,11 @@ func (d *writerDatabase) Delete(key []byte) error {
 	panic("unsupported")
 }
 
+func (d *writerDatabase) NewIterator(r *db.Range) db.Iterator {
+	return db.NewErrorIterator(
+		errors.UnsupportedError.New("GSWriterUnsupportIteration"))
+}
+
 func (d *writerDatabase) GetBucket(id db.BucketID) (db.Bucket, error) {
 	if id == db.BytesByHash || id == db.MerkleTrie {
 		return d, nil
@@ -43,6 +48,10 @@ func (d *writerDatabase) GetBucket(id db.BucketID) (db.Bucket, error) {
 	return nil, errors.UnsupportedError.Errorf("GSWriterUnsupport(id=%s)", id)
 }
 
+func (d *writerDatabase) NewBatch() db.Batch {
+	return db.NewBucketBatch(d)
+}
+
 func (d *writerDatabase) Close() error {
 	return nil
 }
@@ -77,6 +86,16 @@ func (d *readerDatabase) Delete(key []byte) error {
 	return errors.UnsupportedError.Errorf("GenesisStorageIsReadOnly")
 }
 
+func (d *readerDatabase) NewIterator(r *db.Range) db.Iterator {
+	return db.NewErrorIterator(
+		errors.UnsupportedError.New("GSReaderUnsupportIteration"))
+}
+
+func (d *readerDatabase) NewBatch() db.Batch {
+	return db.NewErrorBatch(
+		errors.UnsupportedError.New("GenesisStorageIsReadOnly"))
+}
+
 func (d *readerDatabase) GetBucket(id db.BucketID) (db.Bucket, error) {
 	if id == db.BytesByHash || id == db.MerkleTrie {
 		return d, nil
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// liveBackup makes a backup of the chain while it's running. Instead of
+// archiving the database directory, it exports the blocks up to the last
+// finalized block into a temporal database, then archives the database with
+// contracts and extra files in the same format as taskBackup.
+//
+// WAL is not included, so the restored node gets the votes for the last
+// block from its peers. Event log index isn't included either.
+//
+// If baseHash is set, it makes an incremental backup having only the blocks
+// after base and the data not reachable from the block at base. Contracts
+// and extra files are not included in an incremental backup. Contracts
+// are extracted again from the state if they are not in the directory.
+//
+// States collected by the state GC are not exported. The base of the state
+// GC is written to the backup, so the restored node knows which states are
+// collected. The state GC is paused while it's running.
+type liveBackup struct {
+	chain    *singleChain
+	bm       module.BlockManager
+	file     string
+	extra    []string
+	base     int64
+	baseHash []byte
+	from     int64
+	height   int64
+	hash     []byte
+	gcBase   int64
+
+	current int64
+	files   int32
+	written int32
+	stop    int32
+	result  resultStore
+}
+
+func (b *liveBackup) String() string {
+	if b.isIncremental() {
+		return fmt.Sprintf("LiveBackup(file=%s,base=%d,height=%d)",
+			path.Base(b.file), b.base, b.height)
+	}
+	return fmt.Sprintf("LiveBackup(file=%s,height=%d)", path.Base(b.file), b.height)
+}
+
+func (b *liveBackup) Detail() string {
+	if total := atomic.LoadInt32(&b.files); total > 0 {
+		return fmt.Sprintf("backup %d/%d",
+			atomic.LoadInt32(&b.written), total)
+	}
+	current := atomic.LoadInt64(&b.current)
+	return fmt.Sprintf("backup export %d/%d", current, b.height)
+}
+
+func (b *liveBackup) _isInterrupted() bool {
+	return atomic.LoadInt32(&b.stop) != 0
+}
+
+func (b *liveBackup) OnExport(height int64) error {
+	if b._isInterrupted() {
+		return errors.ErrInterrupted
+	}
+	atomic.StoreInt64(&b.current, height)
+	return nil
+}
+
+func (b *liveBackup) OnWrite(int64) error {
+	if b._isInterrupted() {
+		return errors.ErrInterrupted
+	}
+	atomic.AddInt32(&b.written, 1)
+	return nil
+}
+
+func (b *liveBackup) Start() error {
+	gblk, _, err := b.bm.GetGenesisData()
+	if err != nil {
+		return err
+	}
+	blk, err := b.bm.GetLastBlock()
+	if err != nil {
+		return err
+	}
+	b.height = blk.Height()
+	b.hash = blk.ID()
+	// ExportBlocks exports the block before the first one with validators,
+	// so it starts from the next of the pruned genesis.
+	if gblk != nil && gblk.Height() > 0 {
+		b.from = gblk.Height() + 1
+	}
+	if b.isIncremental() {
+		if b.base < b.from-1 || b.base > b.height {
+			return errors.IllegalArgumentError.Errorf(
+				"InvalidBaseHeight(base=%d,last=%d)", b.base, b.height)
+		}
+		bblk, err := b.bm.GetBlockByHeight(b.base)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bblk.ID(), b.baseHash) {
+			return errors.IllegalArgumentError.Errorf(
+				"InvalidBaseHash(height=%d,exp=%#x,real=%#x)",
+				b.base, b.baseHash, bblk.ID())
+		}
+		b.from = b.base + 1
+	}
+	// Blocks are exported with transactions and receipts including the one
+	// before the first.
+	if base := block.GetBodyBaseOf(b.chain.Database()); base > 0 && base >= b.from {
+		return errors.InvalidStateError.Errorf(
+			"PrunedBlocks(from=%d,base=%d)", b.from, base)
+	}
+	if b.gcBase, err = block.GetStateGCBaseOf(b.chain.Database()); err != nil {
+		return err
+	}
+
+	go func() {
+		b.result.SetValue(b._backup())
+	}()
+	return nil
+}
+
+func (b *liveBackup) _backup() (rerr error) {
+	c := b.chain
+	chainDir := c.cfg.AbsBaseDir()
+
+	tmpDir, err := ioutil.TempDir(chainDir, TemporalBackupFile)
+	if err != nil {
+		return errors.Wrap(err, "Fail to make temporal directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	c.logger.Infof("Export blocks for backup from=%d to=%d", b.from, b.height)
+	if err := b._exportDatabase(tmpDir); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(path.Dir(b.file), TemporalBackupFile)
+	if err != nil {
+		return errors.Wrap(err, "Fail to make temporal file")
+	}
+	defer func() {
+		if rerr != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+	if err := b._write(tmp, tmpDir, chainDir); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), b.file)
+}
+
+func (b *liveBackup) isIncremental() bool {
+	return len(b.baseHash) > 0
+}
+
+func (b *liveBackup) _exportDatabase(tmpDir string) error {
+	dbType := b.chain.cfg.DBType
+	dbase, err := b.chain.openDatabase(path.Join(tmpDir, DefaultDBDir), dbType)
+	if err != nil {
+		return err
+	}
+	defer dbase.Close()
+	if b.gcBase > b.from {
+		if err := block.SetStateGCBaseOf(dbase, b.gcBase); err != nil {
+			return err
+		}
+	}
+	if !b.isIncremental() {
+		return b.bm.ExportBlocks(b.from, b.height, dbase, b.OnExport)
+	}
+
+	// Export the block at base to know which data are in the base backup.
+	based, err := b.chain.openDatabase(path.Join(tmpDir, DefaultTmpDBDir), dbType)
+	if err != nil {
+		return err
+	}
+	defer based.Close()
+	if err := b.bm.ExportBlocks(b.base+1, b.base, based, nil); err != nil {
+		return err
+	}
+	return b.bm.ExportBlocks(b.from, b.height, newIncrementDB(based, dbase), b.OnExport)
+}
+
+func (b *liveBackup) _write(fd *os.File, tmpDir, chainDir string) error {
+	defer fd.Close()
+	if err := fd.Chmod(0644); err != nil {
+		return err
+	}
+	zw := zip.NewWriter(fd)
+	defer zw.Close()
+
+	if err := writeBackupInfo(zw, &BackupInfo{
+		NID:      common.HexInt32{Value: int32(b.chain.NID())},
+		CID:      common.HexInt32{Value: int32(b.chain.CID())},
+		Channel:  b.chain.Channel(),
+		Height:   b.height,
+		Hash:     b.hash,
+		Codec:    codec.BC.Name(),
+		Base:     b.base,
+		BaseHash: b.baseHash,
+	}); err != nil {
+		return err
+	}
+
+	total, err := countFiles(path.Join(tmpDir, DefaultDBDir))
+	if err != nil {
+		return err
+	}
+	var names []string
+	if !b.isIncremental() {
+		names = append([]string{DefaultContractDir}, b.extra...)
+	}
+	for _, name := range names {
+		if cnt, err := countFiles(path.Join(chainDir, name)); err != nil {
+			return err
+		} else {
+			total += cnt
+		}
+	}
+	atomic.StoreInt32(&b.files, int32(total))
+
+	if err := zipWrite(zw, tmpDir, DefaultDBDir, b.OnWrite); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := zipWrite(zw, chainDir, name, b.OnWrite); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (b *liveBackup) Stop() {
+	atomic.StoreInt32(&b.stop, 1)
+}
+
+func (b *liveBackup) Wait() error {
+	return b.result.Wait()
+}
+
+func newLiveBackup(chain *singleChain, file string, extra []string, base int64, baseHash []byte) *liveBackup {
+	return &liveBackup{
+		chain:    chain,
+		bm:       chain.bm,
+		file:     file,
+		extra:    extra,
+		base:     base,
+		baseHash: baseHash,
+	}
+}
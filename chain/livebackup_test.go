@@ -0,0 +1,180 @@
+package chain
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/test"
+)
+
+type tBackupBlock struct {
+	test.BlockBase
+	height int64
+	id     []byte
+}
+
+func (b *tBackupBlock) Height() int64 {
+	return b.height
+}
+
+func (b *tBackupBlock) ID() []byte {
+	return b.id
+}
+
+// tBackupBlockManager returns the blocks of the source, and exports them
+// like the block manager.
+type tBackupBlockManager struct {
+	test.BlockManagerBase
+	t   *testing.T
+	src *tBackupSource
+}
+
+func (bm *tBackupBlockManager) GetGenesisData() (module.Block, module.CommitVoteSet, error) {
+	blk, err := bm.GetBlockByHeight(0)
+	return blk, nil, err
+}
+
+func (bm *tBackupBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	if height < 0 || height >= int64(len(bm.src.hashes)) {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return &tBackupBlock{height: height, id: bm.src.hashes[height]}, nil
+}
+
+func (bm *tBackupBlockManager) GetLastBlock() (module.Block, error) {
+	return bm.GetBlockByHeight(int64(len(bm.src.hashes)) - 1)
+}
+
+func (bm *tBackupBlockManager) ExportBlocks(from, to int64, dst db.Database, on func(height int64) error) error {
+	for h := from; h <= to; h++ {
+		if on != nil {
+			if err := on(h); err != nil {
+				return err
+			}
+		}
+	}
+	bm.src.export(bm.t, from, to, dst)
+	return nil
+}
+
+func extractBackup(t *testing.T, file, dir string) *BackupInfo {
+	zr, err := zip.OpenReader(file)
+	assert.NoError(t, err)
+	defer zr.Close()
+	for _, f := range zr.File {
+		target := path.Join(dir, f.Name)
+		if f.Mode().IsDir() {
+			assert.NoError(t, os.MkdirAll(target, 0755))
+			continue
+		}
+		assert.NoError(t, os.MkdirAll(path.Dir(target), 0755))
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		fd, err := os.Create(target)
+		assert.NoError(t, err)
+		_, err = io.Copy(fd, rc)
+		assert.NoError(t, err)
+		fd.Close()
+		rc.Close()
+	}
+	info, err := ReadBackupInfo(&zr.Reader)
+	assert.NoError(t, err)
+	return info
+}
+
+func TestLiveBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "livebackup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	const dbType = "goleveldb"
+	src := newBackupSource(t, 10)
+	assert.NoError(t, block.SetStateGCBaseOf(src.db, 3))
+	bm := &tBackupBlockManager{t: t, src: src}
+	c := &singleChain{
+		cfg: Config{
+			NID:     1,
+			BaseDir: path.Join(dir, "chain"),
+			DBType:  dbType,
+		},
+		database: src.db,
+		bm:       bm,
+		logger:   log.New(),
+	}
+	assert.NoError(t, os.MkdirAll(path.Join(c.cfg.AbsBaseDir(), DefaultContractDir), 0755))
+
+	backup := func(file string, base int64, baseHash []byte) error {
+		b := newLiveBackup(c, path.Join(dir, file), nil, base, baseHash)
+		if err := b.Start(); err != nil {
+			return err
+		}
+		return b.Wait()
+	}
+
+	assert.NoError(t, backup("full.zip", 0, nil))
+	for h := 0; h < 5; h++ {
+		src.grow(t)
+	}
+
+	// the base should have the hash, and it should not be above the last
+	err = backup("invalid.zip", 9, src.hashes[8])
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	err = backup("invalid.zip", 15, src.hashes[14])
+	assert.True(t, errors.IllegalArgumentError.Equals(err))
+	assert.NoError(t, backup("inc.zip", 9, src.hashes[9]))
+
+	// restore the full backup, then merge the increment to it
+	restored := path.Join(dir, "restored")
+	info := extractBackup(t, path.Join(dir, "full.zip"), restored)
+	assert.False(t, info.IsIncremental())
+	assert.Equal(t, int64(9), info.Height)
+	assert.Equal(t, src.hashes[9], []byte(info.Hash))
+
+	dbase, err := openBackupDB(restored, dbType, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), block.GetLastHeightOf(dbase))
+	gcBase, err := block.GetStateGCBaseOf(dbase)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), gcBase)
+	assert.NoError(t, dbase.Close())
+
+	inc := path.Join(dir, "inc")
+	info = extractBackup(t, path.Join(dir, "inc.zip"), inc)
+	assert.True(t, info.IsIncremental())
+	assert.Equal(t, int64(9), info.Base)
+	assert.Equal(t, int64(14), info.Height)
+	_, err = os.Stat(path.Join(inc, DefaultContractDir))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, MergeBackup(restored, inc, info, dbType))
+
+	dbase, err = openBackupDB(restored, dbType, 1)
+	assert.NoError(t, err)
+	defer dbase.Close()
+	assert.Equal(t, int64(14), block.GetLastHeightOf(dbase))
+	assert.NoError(t, checkBlockHash(dbase, 14, src.hashes[14]))
+
+	wss := state.NewWorldSnapshot(dbase, src.states[14], nil)
+	for h := 0; h < 15; h++ {
+		addr := common.NewAddressFromString(fmt.Sprintf("hx%040x", h%5+1))
+		ass := wss.GetAccountSnapshot(addr.ID())
+		if assert.NotNil(t, ass) {
+			v, err := ass.GetValue([]byte(fmt.Sprintf("key%d", h)))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte{byte(h)}, v)
+		}
+	}
+}
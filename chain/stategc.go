@@ -0,0 +1,476 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service"
+)
+
+const (
+	// stateGCMinBlocks is the minimum number of blocks to start collection.
+	stateGCMinBlocks = 100
+
+	// stateGCSafeBlocks is the number of blocks to be finalized before
+	// deleting nodes, so that transitions which were in progress on start
+	// of the collection are finalized or dropped.
+	stateGCSafeBlocks = 3
+
+	stateGCInterval = time.Minute
+)
+
+// gcDatabase records the keys written to MerkleTrie bucket while the state
+// GC or the block pruner is deleting nodes. Nodes written during the
+// collection may be used by new blocks, so they are not deleted.
+//
+// Live tasks exporting the data hold it, then deletions are paused until
+// they are released.
+type gcDatabase struct {
+	db.Database
+
+	lock    sync.Mutex
+	records map[*gcRecord]struct{}
+
+	holdLock sync.Mutex
+	holds    int
+}
+
+// gcRecord is the set of keys written after startRecord.
+type gcRecord struct {
+	written map[string]struct{}
+}
+
+func (d *gcDatabase) GetBucket(id db.BucketID) (db.Bucket, error) {
+	bk, err := d.Database.GetBucket(id)
+	if err != nil || id != db.MerkleTrie {
+		return bk, err
+	}
+	return &gcBucket{Bucket: bk, database: d}, nil
+}
+
+func (d *gcDatabase) NewBatch() db.Batch {
+	return &gcBatch{Batch: d.Database.NewBatch(), database: d}
+}
+
+func (d *gcDatabase) recordInLock(key []byte) {
+	for r := range d.records {
+		r.written[string(key)] = struct{}{}
+	}
+}
+
+func (d *gcDatabase) startRecord() *gcRecord {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	r := &gcRecord{written: make(map[string]struct{})}
+	d.records[r] = struct{}{}
+	return r
+}
+
+func (d *gcDatabase) stopRecord(r *gcRecord) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.records, r)
+}
+
+// deleteIfNotWritten deletes the node if it's not written after
+// startRecord. It returns true if it's deleted.
+func (d *gcDatabase) deleteIfNotWritten(r *gcRecord, bk db.Bucket, key []byte) (bool, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, ok := r.written[string(key)]; ok {
+		return false, nil
+	}
+	if err := bk.Delete(key); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// hold pauses deletions until release is called. It waits for the running
+// deletion step to be finished.
+func (d *gcDatabase) hold() {
+	d.holdLock.Lock()
+	defer d.holdLock.Unlock()
+	d.holds += 1
+}
+
+func (d *gcDatabase) release() {
+	d.holdLock.Lock()
+	defer d.holdLock.Unlock()
+	d.holds -= 1
+}
+
+// runIfNotHeld runs the deletion step if it's not held. It returns true if
+// it's run.
+func (d *gcDatabase) runIfNotHeld(fn func() error) (bool, error) {
+	d.holdLock.Lock()
+	defer d.holdLock.Unlock()
+	if d.holds > 0 {
+		return false, nil
+	}
+	return true, fn()
+}
+
+// runWhenNotHeld runs the deletion step after it's released. It returns
+// ErrInterrupted if stop is closed while it's held.
+func (d *gcDatabase) runWhenNotHeld(stop <-chan struct{}, fn func() error) error {
+	for {
+		if ok, err := d.runIfNotHeld(fn); ok {
+			return err
+		}
+		select {
+		case <-stop:
+			return errors.ErrInterrupted
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+type gcBucket struct {
+	db.Bucket
+	database *gcDatabase
+}
+
+func (bk *gcBucket) Set(key []byte, value []byte) error {
+	bk.database.lock.Lock()
+	defer bk.database.lock.Unlock()
+	bk.database.recordInLock(key)
+	return bk.Bucket.Set(key, value)
+}
+
+type gcBatch struct {
+	db.Batch
+	database *gcDatabase
+	keys     [][]byte
+}
+
+func (b *gcBatch) Set(id db.BucketID, key []byte, value []byte) error {
+	if id == db.MerkleTrie {
+		b.keys = append(b.keys, key)
+	}
+	return b.Batch.Set(id, key, value)
+}
+
+func (b *gcBatch) Reset() {
+	b.keys = nil
+	b.Batch.Reset()
+}
+
+func (b *gcBatch) Write() error {
+	b.database.lock.Lock()
+	defer b.database.lock.Unlock()
+	for _, key := range b.keys {
+		b.database.recordInLock(key)
+	}
+	return b.Batch.Write()
+}
+
+func newGCDatabase(database db.Database) *gcDatabase {
+	return &gcDatabase{
+		Database: database,
+		records:  make(map[*gcRecord]struct{}),
+	}
+}
+
+// stateGC deletes MPT nodes of world states which are not reachable from
+// the last keep finalized blocks while the chain is running.
+//
+// It marks the nodes reachable from the kept states, then collects the
+// nodes of the older states since the last collection which are not
+// marked. States finalized during the collection are marked again before
+// deletion, and the nodes written during the collection are not deleted.
+// Roots of the states are deleted first, so queries for them fail with
+// PrunedStateError. Deletions are paused while live tasks hold the
+// database, so they export the states of which roots exist.
+type stateGC struct {
+	chain    *singleChain
+	database *gcDatabase
+	bm       module.BlockManager
+	sm       module.ServiceManager
+	keep     int64
+	rate     int
+	log      log.Logger
+
+	stop   chan struct{}
+	result resultStore
+}
+
+func (g *stateGC) Start() {
+	g.log.Infof("StateGC started keep=%d rate=%d", g.keep, g.rate)
+	go func() {
+		g.result.SetValue(g.run())
+	}()
+}
+
+func (g *stateGC) run() error {
+	for {
+		if err := g.collect(); err != nil {
+			if errors.InterruptedError.Equals(err) {
+				return err
+			}
+			g.log.Warnf("StateGC failed err=%+v", err)
+		}
+		select {
+		case <-g.stop:
+			return errors.ErrInterrupted
+		case <-time.After(stateGCInterval):
+		}
+	}
+}
+
+func (g *stateGC) Stop() {
+	close(g.stop)
+}
+
+func (g *stateGC) Wait() error {
+	return g.result.Wait()
+}
+
+func (g *stateGC) _interrupted() error {
+	select {
+	case <-g.stop:
+		return errors.ErrInterrupted
+	default:
+		return nil
+	}
+}
+
+func (g *stateGC) lastHeight() (int64, error) {
+	blk, err := g.bm.GetLastBlock()
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+// base returns the lowest height of the states which are not collected.
+func (g *stateGC) base() (int64, error) {
+	if height, err := block.GetStateGCBaseOf(g.database); err != nil || height > 0 {
+		return height, err
+	}
+	gblk, _, err := g.bm.GetGenesisData()
+	if err != nil {
+		return 0, err
+	}
+	if gblk == nil {
+		return 0, nil
+	}
+	return gblk.Height(), nil
+}
+
+func (g *stateGC) resultOf(height int64) ([]byte, error) {
+	blk, err := g.bm.GetBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return blk.Result(), nil
+}
+
+func (g *stateGC) exportStates(from, to int64, dst db.Database) error {
+	for h := from; h <= to; h++ {
+		if err := g._interrupted(); err != nil {
+			return err
+		}
+		result, err := g.resultOf(h)
+		if err != nil {
+			return err
+		}
+		if err := g.sm.ExportWorldState(result, dst); err != nil {
+			return errors.Wrapf(err, "fail to export state height=%d", h)
+		}
+	}
+	return nil
+}
+
+func (g *stateGC) waitForHeight(height int64) (int64, error) {
+	for {
+		last, err := g.lastHeight()
+		if err != nil {
+			return 0, err
+		}
+		if last >= height {
+			return last, nil
+		}
+		select {
+		case <-g.stop:
+			return 0, errors.ErrInterrupted
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (g *stateGC) collect() error {
+	base, err := g.base()
+	if err != nil {
+		return err
+	}
+	last, err := g.lastHeight()
+	if err != nil {
+		return err
+	}
+	to := last - g.keep
+	if to-base+1 < stateGCMinBlocks {
+		return nil
+	}
+
+	dir := path.Join(g.chain.cfg.AbsBaseDir(), DefaultStateGCDir)
+	os.RemoveAll(dir)
+	defer os.RemoveAll(dir)
+
+	rec := g.database.startRecord()
+	defer g.database.stopRecord(rec)
+
+	dbType := g.chain.cfg.DBType
+	mark, err := g.chain.openDatabase(path.Join(dir, "mark"), dbType)
+	if err != nil {
+		return err
+	}
+	defer mark.Close()
+	sweep, err := g.chain.openDatabase(path.Join(dir, "sweep"), dbType)
+	if err != nil {
+		return err
+	}
+	defer sweep.Close()
+
+	g.log.Infof("StateGC collect states from=%d to=%d", base, to)
+	if err := g.exportStates(to+1, last, mark); err != nil {
+		return err
+	}
+	if err := g.exportStates(base, to, newIncrementDB(mark, sweep)); err != nil {
+		return err
+	}
+
+	current, err := g.waitForHeight(last + stateGCSafeBlocks)
+	if err != nil {
+		return err
+	}
+	if err := g.exportStates(last+1, current, mark); err != nil {
+		return err
+	}
+
+	deleted, err := g.sweep(rec, base, to, mark, sweep)
+	g.log.Infof("StateGC collected states from=%d to=%d deleted=%d err=%v",
+		base, to, deleted, err)
+	return err
+}
+
+func (g *stateGC) sweep(rec *gcRecord, from, to int64, mark, sweep db.Database) (int, error) {
+	bk, err := g.database.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return 0, err
+	}
+	markBk, err := mark.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return 0, err
+	}
+	sweepBk, err := sweep.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int
+	var limitAt time.Time
+	deleteNode := func(key []byte) error {
+		if markBk.Has(key) {
+			return nil
+		}
+		if ok, err := g.database.deleteIfNotWritten(rec, bk, key); err != nil || !ok {
+			return err
+		}
+		deleted += 1
+		return nil
+	}
+	remove := func(key []byte) error {
+		cnt := deleted
+		if err := g.database.runWhenNotHeld(g.stop, func() error {
+			return deleteNode(key)
+		}); err != nil || deleted == cnt {
+			return err
+		}
+		if g.rate > 0 && deleted%g.rate == 0 {
+			if d := time.Until(limitAt); d > 0 {
+				select {
+				case <-g.stop:
+					return errors.ErrInterrupted
+				case <-time.After(d):
+				}
+			}
+			limitAt = time.Now().Add(time.Second)
+		}
+		return nil
+	}
+
+	var roots [][]byte
+	for h := from; h <= to; h++ {
+		result, err := g.resultOf(h)
+		if err != nil {
+			return deleted, err
+		}
+		sh, err := service.StateHashFromResult(result)
+		if err != nil {
+			return deleted, err
+		}
+		if len(sh) > 0 && sweepBk.Has(sh) {
+			roots = append(roots, sh)
+		}
+	}
+	// Live tasks see the roots and the base at once.
+	if err := g.database.runWhenNotHeld(g.stop, func() error {
+		for _, sh := range roots {
+			if err := deleteNode(sh); err != nil {
+				return err
+			}
+		}
+		return block.SetStateGCBaseOf(g.database, to+1)
+	}); err != nil {
+		return deleted, err
+	}
+
+	itr := sweepBk.NewIterator(nil)
+	defer itr.Release()
+	for itr.Next() {
+		if err := g._interrupted(); err != nil {
+			return deleted, err
+		}
+		if err := remove(itr.Key()); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, itr.Error()
+}
+
+func newStateGC(chain *singleChain, database *gcDatabase, keep int64, rate int) *stateGC {
+	return &stateGC{
+		chain:    chain,
+		database: database,
+		bm:       chain.bm,
+		sm:       chain.sm,
+		keep:     keep,
+		rate:     rate,
+		log:      chain.logger,
+		stop:     make(chan struct{}),
+	}
+}
@@ -0,0 +1,211 @@
+package chain
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/common/merkle"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/test"
+)
+
+// tResult has the same layout as the transition result of the service
+// manager.
+type tResult struct {
+	StateHash         []byte
+	PatchReceiptHash  []byte
+	NormalReceiptHash []byte
+}
+
+type tGCBlock struct {
+	test.BlockBase
+	height int64
+	result []byte
+}
+
+func (b *tGCBlock) Height() int64 {
+	return b.height
+}
+
+func (b *tGCBlock) Result() []byte {
+	return b.result
+}
+
+// tGCBlockManager returns the blocks of the states in the source. onLast is
+// called whenever the last block is requested.
+type tGCBlockManager struct {
+	test.BlockManagerBase
+	lock   sync.Mutex
+	src    *tBackupSource
+	onLast func()
+}
+
+func (bm *tGCBlockManager) GetGenesisData() (module.Block, module.CommitVoteSet, error) {
+	return nil, nil, nil
+}
+
+func (bm *tGCBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	bm.lock.Lock()
+	defer bm.lock.Unlock()
+	if height < 0 || height >= int64(len(bm.src.states)) {
+		return nil, errors.NotFoundError.Errorf("NoBlock(height=%d)", height)
+	}
+	return &tGCBlock{
+		height: height,
+		result: codec.BC.MustMarshalToBytes(&tResult{
+			StateHash: bm.src.states[height],
+		}),
+	}, nil
+}
+
+func (bm *tGCBlockManager) GetLastBlock() (module.Block, error) {
+	if bm.onLast != nil {
+		bm.onLast()
+	}
+	bm.lock.Lock()
+	last := int64(len(bm.src.states)) - 1
+	bm.lock.Unlock()
+	return bm.GetBlockByHeight(last)
+}
+
+type tGCServiceManager struct {
+	test.ServiceManagerBase
+	db db.Database
+}
+
+func (sm *tGCServiceManager) ExportWorldState(result []byte, d db.Database) error {
+	var r tResult
+	if _, err := codec.BC.UnmarshalFromBytes(result, &r); err != nil {
+		return err
+	}
+	e := merkle.NewCopyContext(sm.db, d)
+	if _, err := state.NewWorldSnapshotWithBuilder(e.Builder(), r.StateHash, nil); err != nil {
+		return err
+	}
+	return e.Run()
+}
+
+func TestGCDatabase_Hold(t *testing.T) {
+	gcdb := newGCDatabase(db.NewMapDB())
+	var run int
+	fn := func() error {
+		run += 1
+		return nil
+	}
+
+	gcdb.hold()
+	ok, err := gcdb.runIfNotHeld(fn)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, run)
+
+	stop := make(chan struct{})
+	close(stop)
+	err = gcdb.runWhenNotHeld(stop, fn)
+	assert.True(t, errors.InterruptedError.Equals(err))
+	assert.Equal(t, 0, run)
+
+	gcdb.release()
+	assert.NoError(t, gcdb.runWhenNotHeld(stop, fn))
+	assert.Equal(t, 1, run)
+}
+
+func TestStateGC_Collect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stategc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	const keep = 10
+	gcdb := newGCDatabase(db.NewMapDB())
+	src := newBackupSourceOn(t, gcdb, stateGCMinBlocks+keep+20)
+	bm := &tGCBlockManager{src: src}
+	sm := &tGCServiceManager{db: gcdb}
+	c := &singleChain{
+		cfg: Config{
+			BaseDir: dir,
+			DBType:  "mapdb",
+		},
+		logger: log.New(),
+	}
+	g := &stateGC{
+		chain:    c,
+		database: gcdb,
+		bm:       bm,
+		sm:       sm,
+		keep:     keep,
+		log:      c.logger,
+		stop:     make(chan struct{}),
+	}
+
+	// While it waits for blocks to be finalized, a transition in progress
+	// writes the same nodes as the state of an old height, and new blocks
+	// are finalized.
+	const rewritten = 5
+	var pending []byte
+	calls := 0
+	bm.onLast = func() {
+		calls += 1
+		if calls != 2 {
+			return
+		}
+		ws := state.NewWorldState(gcdb, nil, nil)
+		for h := 0; h <= rewritten; h++ {
+			applyStateOf(t, ws, h)
+		}
+		wss := ws.GetSnapshot()
+		assert.NoError(t, wss.Flush())
+		pending = wss.StateHash()
+
+		bm.lock.Lock()
+		defer bm.lock.Unlock()
+		for i := 0; i < stateGCSafeBlocks; i++ {
+			src.grow(t)
+		}
+	}
+
+	last := int64(len(src.states)) - 1
+	to := last - keep
+	assert.NoError(t, g.collect())
+	assert.Equal(t, src.states[rewritten], pending)
+
+	base, err := g.base()
+	assert.NoError(t, err)
+	assert.Equal(t, to+1, base)
+
+	exportOf := func(h int) error {
+		blk, err := bm.GetBlockByHeight(int64(h))
+		assert.NoError(t, err)
+		return sm.ExportWorldState(blk.Result(), db.NewMapDB())
+	}
+	for h := range src.states {
+		if int64(h) > to || h == rewritten {
+			assert.NoError(t, exportOf(h), "height=%d", h)
+		} else {
+			assert.Error(t, exportOf(h), "height=%d", h)
+		}
+	}
+
+	// all values are readable in the kept states, while the nodes are
+	// shared with the collected states.
+	wss := state.NewWorldSnapshot(gcdb, src.states[len(src.states)-1], nil)
+	for h := range src.states {
+		addr := common.NewAddressFromString(fmt.Sprintf("hx%040x", h%5+1))
+		ass := wss.GetAccountSnapshot(addr.ID())
+		if assert.NotNil(t, ass) {
+			v, err := ass.GetValue([]byte(fmt.Sprintf("key%d", h)))
+			assert.NoError(t, err)
+			assert.Equal(t, []byte{byte(h)}, v)
+		}
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"sort"
 	"sync/atomic"
 
+	"github.com/icon-project/goloop/block"
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/codec"
 	"github.com/icon-project/goloop/common/errors"
@@ -39,7 +40,19 @@ type BackupInfo struct {
 	CID     common.HexInt32 `json:"cid"`
 	Channel string          `json:"channel"`
 	Height  int64           `json:"height"`
+	Hash    common.HexBytes `json:"hash,omitempty"`
 	Codec   string          `json:"codec"`
+
+	// Base and BaseHash are the height and the hash of the last block of
+	// the previous backup. They are set only for incremental backups.
+	Base     int64           `json:"base,omitempty"`
+	BaseHash common.HexBytes `json:"baseHash,omitempty"`
+}
+
+// IsIncremental returns whether the backup has only the blocks after the
+// previous backup.
+func (info *BackupInfo) IsIncremental() bool {
+	return len(info.BaseHash) > 0
 }
 
 var backupStates = map[State]string{
@@ -102,11 +115,13 @@ func (t *taskBackup) Start() (ret error) {
 	t.fd = tmp
 	t.zw = zip.NewWriter(tmp)
 
+	height := t.chain.lastBlockHeight()
 	if err := writeBackupInfo(t.zw, &BackupInfo{
 		NID:     common.HexInt32{Value: int32(t.chain.NID())},
 		CID:     common.HexInt32{Value: int32(t.chain.CID())},
 		Channel: t.chain.Channel(),
-		Height:  t.chain.lastBlockHeight(),
+		Height:  height,
+		Hash:    block.GetBlockHashOf(t.chain.database, height),
 		Codec:   codec.BC.Name(),
 	}); err != nil {
 		return err
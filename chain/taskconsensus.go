@@ -66,11 +66,16 @@ func (t *taskConsensus) _start(c *singleChain) error {
 		return err
 	}
 	c.srv.SetChain(c.cfg.Channel, c)
+	c.startStateGC()
+	c.startBlockPruner()
 	return nil
 }
 
 func (t *taskConsensus) Stop() {
 	t.chain.srv.RemoveChain(t.chain.cfg.Channel)
+	t.chain.stopLiveTask()
+	t.chain.stopStateGC()
+	t.chain.stopBlockPruner()
 	t.chain.releaseManagers()
 	t.result.SetValue(errors.ErrInterrupted)
 }
@@ -0,0 +1,328 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/icon-project/goloop/chain/archive"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/trie/trie_manager"
+	"github.com/icon-project/goloop/module"
+)
+
+var importArchiveStates = map[State]string{
+	Starting: "import archive starting",
+	Stopping: "import archive stopping",
+	Failed:   "import archive failed",
+	Finished: "import archive done",
+}
+
+// taskImportArchive imports the blocks in a block archive. Each block is
+// executed and verified with the votes in the archive, and the receipts in
+// the archive are compared with the receipt lists in the result of the block
+// before finalization. Blocks the chain already has should be same as the
+// ones in the archive.
+type taskImportArchive struct {
+	chain *singleChain
+	src   string
+
+	rc      io.ReadCloser
+	ar      *archive.Reader
+	current int64
+	stop    chan struct{}
+	result  resultStore
+}
+
+func (t *taskImportArchive) String() string {
+	return fmt.Sprintf("ImportArchive(src=%s)", t.src)
+}
+
+func (t *taskImportArchive) DetailOf(s State) string {
+	switch s {
+	case Started:
+		return fmt.Sprintf("import archive %d/%d",
+			atomic.LoadInt64(&t.current), t.ar.Meta().To)
+	default:
+		if st, ok := importArchiveStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+const (
+	archiveConnectTimeout  = 30 * time.Second
+	archiveResponseTimeout = time.Minute
+)
+
+// archiveClient is the client to get the archive. The archive may be large,
+// so it limits the time to connect and to get the response header instead
+// of the time to read the whole body.
+var archiveClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   archiveConnectTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   archiveConnectTimeout,
+		ResponseHeaderTimeout: archiveResponseTimeout,
+	},
+}
+
+// openArchive opens the archive at the URL (http or https) or the file.
+func openArchive(src string) (io.ReadCloser, error) {
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") {
+		resp, err := archiveClient.Get(src)
+		if err != nil {
+			return nil, errors.InvalidNetworkError.Wrapf(err,
+				"FailToGetArchive(url=%s)", src)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, errors.NotFoundError.Errorf(
+				"FailToGetArchive(url=%s,status=%s)", src, resp.Status)
+		}
+		return resp.Body, nil
+	}
+	fd, err := os.Open(src)
+	if err != nil {
+		return nil, errors.NotFoundError.Wrapf(err,
+			"FailToOpenArchive(file=%s)", src)
+	}
+	return fd, nil
+}
+
+func (t *taskImportArchive) Start() error {
+	if err := t._open(); err != nil {
+		if t.rc != nil {
+			t.rc.Close()
+		}
+		return err
+	}
+	if err := t.chain.prepareManagers(); err != nil {
+		t.rc.Close()
+		return err
+	}
+	blk, err := t.chain.bm.GetLastBlock()
+	if err != nil {
+		t._release()
+		return err
+	}
+	meta := t.ar.Meta()
+	if meta.From > blk.Height()+1 || meta.To <= blk.Height() {
+		t._release()
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidRange(from=%d,to=%d,last=%d)",
+			meta.From, meta.To, blk.Height())
+	}
+	atomic.StoreInt64(&t.current, blk.Height())
+	go func() {
+		t.result.SetValue(t._import())
+	}()
+	return nil
+}
+
+func (t *taskImportArchive) _open() error {
+	var err error
+	if t.rc, err = openArchive(t.src); err != nil {
+		return err
+	}
+	if t.ar, err = archive.NewReader(t.rc); err != nil {
+		return err
+	}
+	meta := t.ar.Meta()
+	if meta.NID != t.chain.NID() || meta.CID != t.chain.CID() {
+		return errors.IllegalArgumentError.Errorf(
+			"DifferentChain(nid=%#x,cid=%#x)", meta.NID, meta.CID)
+	}
+	return nil
+}
+
+func (t *taskImportArchive) _release() {
+	t.chain.releaseManagers()
+	t.rc.Close()
+}
+
+func (t *taskImportArchive) _interrupted() error {
+	select {
+	case <-t.stop:
+		return errors.ErrInterrupted
+	default:
+		return nil
+	}
+}
+
+func (t *taskImportArchive) _import() error {
+	defer t._release()
+
+	meta := t.ar.Meta()
+	t.chain.logger.Infof("Import archive src=%s from=%d to=%d",
+		t.src, meta.From, meta.To)
+	for {
+		if err := t._interrupted(); err != nil {
+			return err
+		}
+		rec, err := t.ar.ReadRecord()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := t._importRecord(rec); err != nil {
+			return errors.Wrapf(err, "fail to import height=%d", rec.Height)
+		}
+	}
+}
+
+func (t *taskImportArchive) _importRecord(rec *archive.Record) error {
+	bm := t.chain.bm
+	bd, err := bm.NewBlockDataFromReader(io.MultiReader(
+		bytes.NewReader(rec.Header), bytes.NewReader(rec.Body)))
+	if err != nil {
+		return errors.CriticalFormatError.Wrap(err, "InvalidBlock")
+	}
+	if bd.Height() != rec.Height {
+		return errors.CriticalFormatError.Errorf(
+			"InvalidBlockHeight(exp=%d,real=%d)", rec.Height, bd.Height())
+	}
+
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return err
+	}
+	if rec.Height <= last.Height() {
+		blk, err := bm.GetBlockByHeight(rec.Height)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(blk.ID(), bd.ID()) {
+			return errors.InvalidStateError.Errorf(
+				"DifferentBlock(exp=%#x,real=%#x)", blk.ID(), bd.ID())
+		}
+		return nil
+	}
+
+	votes := t.chain.CommitVoteSetDecoder()(rec.Votes)
+	if err := votes.Verify(bd, last.NextValidators()); err != nil {
+		return errors.Wrap(err, "InvalidVotes")
+	}
+	blk, err := t._importBlock(bd)
+	if err != nil {
+		return err
+	}
+	defer blk.Dispose()
+
+	if err := t._verifyReceipts(blk.Result(), module.TransactionGroupPatch,
+		rec.PatchReceipts); err != nil {
+		return err
+	}
+	if err := t._verifyReceipts(blk.Result(), module.TransactionGroupNormal,
+		rec.NormalReceipts); err != nil {
+		return err
+	}
+	if err := bm.Finalize(blk); err != nil {
+		return err
+	}
+	atomic.StoreInt64(&t.current, rec.Height)
+	return nil
+}
+
+func (t *taskImportArchive) _importBlock(bd module.BlockData) (module.BlockCandidate, error) {
+	type importResult struct {
+		blk module.BlockCandidate
+		err error
+	}
+	ch := make(chan importResult, 1)
+	canceler, err := t.chain.bm.ImportBlock(bd, 0,
+		func(blk module.BlockCandidate, err error) {
+			ch <- importResult{blk, err}
+		})
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case r := <-ch:
+		return r.blk, r.err
+	case <-t.stop:
+		canceler.Cancel()
+		return nil, errors.ErrInterrupted
+	}
+}
+
+// _verifyReceipts compares the receipts with the receipt list of the group
+// in the result. Receipts of the result are written on finalization, so it
+// compares the hash of the list instead of the receipts.
+func (t *taskImportArchive) _verifyReceipts(result []byte, g module.TransactionGroup, rcts [][]byte) error {
+	var real []byte
+	if len(result) > 0 {
+		rl, err := t.chain.sm.ReceiptListFromResult(result, g)
+		if err != nil {
+			return err
+		}
+		real = rl.Hash()
+	}
+	exp, err := receiptListHashOf(rcts)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(exp, real) {
+		return errors.InvalidStateError.Errorf(
+			"InvalidReceipts(group=%d,exp=%#x,real=%#x)", g, exp, real)
+	}
+	return nil
+}
+
+// receiptListHashOf returns the hash of the receipt list having the receipts
+// in the order.
+func receiptListHashOf(rcts [][]byte) ([]byte, error) {
+	mt := trie_manager.NewMutable(db.NewMapDB(), nil)
+	for idx, rct := range rcts {
+		if _, err := mt.Set(codec.BC.MustMarshalToBytes(uint(idx)), rct); err != nil {
+			return nil, err
+		}
+	}
+	return mt.GetSnapshot().Hash(), nil
+}
+
+func (t *taskImportArchive) Stop() {
+	close(t.stop)
+}
+
+func (t *taskImportArchive) Wait() error {
+	return t.result.Wait()
+}
+
+func newTaskImportArchive(chain *singleChain, src string) chainTask {
+	return &taskImportArchive{
+		chain: chain,
+		src:   src,
+		stop:  make(chan struct{}),
+	}
+}
@@ -0,0 +1,38 @@
+package chain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+func TestReceiptListHashOf(t *testing.T) {
+	mdb := db.NewMapDB()
+	score := common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	var rcts []txresult.Receipt
+	var bss [][]byte
+	for i := 0; i < 20; i++ {
+		rct := txresult.NewReceipt(mdb, 0, score)
+		rct.AddLog(score, [][]byte{[]byte("Event(int)")}, [][]byte{{byte(i)}})
+		rct.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+		rcts = append(rcts, rct)
+		bss = append(bss, rct.Bytes())
+	}
+
+	for _, n := range []int{0, 1, 20} {
+		exp := txresult.NewReceiptListFromSlice(mdb, rcts[:n]).Hash()
+		hash, err := receiptListHashOf(bss[:n])
+		assert.NoError(t, err)
+		assert.Equal(t, exp, hash, "n=%d", n)
+	}
+
+	hash, err := receiptListHashOf([][]byte{bss[1], bss[0]})
+	assert.NoError(t, err)
+	assert.NotEqual(t, txresult.NewReceiptListFromSlice(mdb, rcts[:2]).Hash(), hash)
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2020 ICON Foundation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chain
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+var reindexStates = map[State]string{
+	Starting: "reindex starting",
+	Stopping: "reindex stopping",
+	Failed:   "reindex failed",
+	Finished: "reindex done",
+}
+
+type taskReindex struct {
+	chain   *singleChain
+	result  resultStore
+	blocks  int64
+	current int64
+}
+
+func (t *taskReindex) String() string {
+	return "Reindex"
+}
+
+func (t *taskReindex) DetailOf(s State) string {
+	switch s {
+	case Started:
+		i, a := t._progress()
+		return fmt.Sprintf("reindex %d/%d", i, a)
+	default:
+		if st, ok := reindexStates[s]; ok {
+			return st
+		} else {
+			return s.String()
+		}
+	}
+}
+
+func (t *taskReindex) Start() error {
+	if err := t.chain.prepareManagers(); err != nil {
+		return err
+	}
+	blk, err := t.chain.bm.GetLastBlock()
+	if err != nil {
+		t.chain.releaseManagers()
+		return err
+	}
+	// results of the transactions in the last block are not available yet.
+	t.blocks = blk.Height()
+	t.current = 0
+	go t.doReindex()
+	return nil
+}
+
+func (t *taskReindex) doReindex() {
+	err := t._reindex()
+	t.result.SetValue(err)
+}
+
+func (t *taskReindex) _progress() (int64, int64) {
+	blocks := atomic.LoadInt64(&t.blocks)
+	if blocks == 0 {
+		return 0, 0
+	}
+	current := atomic.LoadInt64(&t.current)
+	return current, blocks
+}
+
+func (t *taskReindex) _interrupted() bool {
+	return atomic.LoadInt64(&t.blocks) == 0
+}
+
+func (t *taskReindex) _reindex() error {
+	c := t.chain
+	defer c.releaseManagers()
+
+	eli, err := txresult.NewEventLogIndex(c.Database())
+	if err != nil {
+		return err
+	}
+	if err := eli.Reset(); err != nil {
+		return err
+	}
+	last := atomic.LoadInt64(&t.blocks)
+	for height := int64(0); height < last; height++ {
+		if t._interrupted() {
+			return errors.ErrInterrupted
+		}
+		// receipts of the transactions are in the result of the next block.
+		// blocks may not exist under the height where the chain is pruned.
+		rblk, err := c.bm.GetBlockByHeight(height + 1)
+		if errors.NotFoundError.Equals(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		rl, err := c.sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+		if err != nil {
+			return err
+		}
+		if err := eli.Add(height, rl); err != nil {
+			return err
+		}
+		atomic.StoreInt64(&t.current, height+1)
+	}
+	c.logger.Infof("Event log index rebuilt to height=%d", last-1)
+	return nil
+}
+
+func (t *taskReindex) Stop() {
+	atomic.StoreInt64(&t.blocks, 0)
+}
+
+func (t *taskReindex) Wait() error {
+	return t.result.Wait()
+}
+
+func newTaskReindex(chain *singleChain) chainTask {
+	return &taskReindex{
+		chain: chain,
+	}
+}
@@ -135,6 +135,16 @@ type Transaction struct {
 	TxIndex     jsonrpc.HexInt   `json:"txIndex" validate:"required,t_int"`
 }
 
+//refer server/v3/api_v3.go getLogs
+type Log struct {
+	EventLog
+	BlockHash   jsonrpc.HexBytes `json:"blockHash"`
+	BlockHeight jsonrpc.HexInt   `json:"blockHeight"`
+	TxIndex     jsonrpc.HexInt   `json:"txIndex"`
+	TxHash      jsonrpc.HexBytes `json:"txHash"`
+	LogIndex    jsonrpc.HexInt   `json:"logIndex"`
+}
+
 func (c *ClientV3) GetLastBlock() (*Block, error) {
 	blk := &Block{}
 	_, err := c.Do("icx_getLastBlock", nil, blk)
@@ -180,6 +190,15 @@ func (c *ClientV3) GetBalance(param *v3.AddressParam) (*jsonrpc.HexInt, error) {
 	return &result, nil
 }
 
+func (c *ClientV3) GetNonce(param *v3.AddressParam) (*jsonrpc.HexInt, error) {
+	var result jsonrpc.HexInt
+	_, err := c.Do("icx_getNonce", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 //refer servicce/scoreapi/info.go Info.ToJSON
 func (c *ClientV3) GetScoreApi(param *v3.ScoreAddressParam) ([]interface{}, error) {
 	var result []interface{}
@@ -190,9 +209,14 @@ func (c *ClientV3) GetScoreApi(param *v3.ScoreAddressParam) ([]interface{}, erro
 	return result, nil
 }
 
-func (c *ClientV3) GetTotalSupply() (*jsonrpc.HexInt, error) {
+func (c *ClientV3) GetTotalSupply(param *v3.TotalSupplyParam) (*jsonrpc.HexInt, error) {
 	var result jsonrpc.HexInt
-	_, err := c.Do("icx_getTotalSupply", nil, &result)
+	var err error
+	if param != nil {
+		_, err = c.Do("icx_getTotalSupply", param, &result)
+	} else {
+		_, err = c.Do("icx_getTotalSupply", nil, &result)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -300,6 +324,33 @@ func (c *ClientV3) GetProofForEvents(param *v3.ProofEventsParam) ([][][]byte, er
 	return result, nil
 }
 
+func (c *ClientV3) GetProofForAccount(param *v3.ProofAccountParam) ([][]byte, error) {
+	var result [][]byte
+	_, err := c.Do("icx_getProofForAccount", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetProofForStorage(param *v3.ProofStorageParam) ([][][]byte, error) {
+	var result [][][]byte
+	_, err := c.Do("icx_getProofForStorage", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetLogs(param *v3.LogsParam) ([]*Log, error) {
+	var result []*Log
+	_, err := c.Do("icx_getLogs", param, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *ClientV3) MonitorBlock(param *server.BlockRequest, cb func(v *server.BlockNotification), cancelCh <-chan bool) error {
 	resp := &server.BlockNotification{}
 	return c.Monitor("/block", param, resp, func(v interface{}) {
@@ -318,6 +369,15 @@ func (c *ClientV3) MonitorEvent(param *server.EventRequest, cb func(v *server.Ev
 	}, cancelCh)
 }
 
+func (c *ClientV3) MonitorTransaction(param *server.TransactionRequest, cb func(v *server.TransactionNotification), cancelCh <-chan bool) error {
+	resp := &server.TransactionNotification{}
+	return c.Monitor("/transaction", param, resp, func(v interface{}) {
+		if tn, ok := v.(*server.TransactionNotification); ok {
+			cb(tn)
+		}
+	}, cancelCh)
+}
+
 func (c *ClientV3) Monitor(reqUrl string, reqPtr, respPtr interface{},
 	cb func(v interface{}), cancelCh <-chan bool) error {
 	if cb == nil {
@@ -432,3 +492,58 @@ func (c *ClientV3) EstimateStep(param *v3.TransactionParamForEstimate) (*common.
 	}
 	return &result, nil
 }
+
+func (c *ClientV3) SimulateTransactions(param *v3.SimulateTransactionsParam) (map[string]interface{}, error) {
+	if c.Debug == nil {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	result := make(map[string]interface{})
+	if _, err := c.Debug.Do("debug_simulateTransactions", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetPoolTransactions(param *v3.PoolTransactionsParam) (map[string]interface{}, error) {
+	if c.Debug == nil {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	result := make(map[string]interface{})
+	if _, err := c.Debug.Do("debug_getPoolTransactions", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetPoolStatus() (map[string]interface{}, error) {
+	if c.Debug == nil {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	result := make(map[string]interface{})
+	if _, err := c.Debug.Do("debug_getPoolStatus", nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) GetDroppedTransaction(param *v3.TransactionHashParam) (map[string]interface{}, error) {
+	if c.Debug == nil {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	result := make(map[string]interface{})
+	if _, err := c.Debug.Do("debug_getDroppedTransaction", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *ClientV3) EvictTransactions(param *v3.EvictTransactionsParam) ([]jsonrpc.HexBytes, error) {
+	if c.Debug == nil {
+		return nil, errors.InvalidStateError.New("UnavailableDebugEndPoint")
+	}
+	var result []jsonrpc.HexBytes
+	if _, err := c.Debug.Do("debug_evictTransactions", param, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/node"
+	"github.com/icon-project/goloop/service"
 )
 
 func AdminPersistentPreRunE(vc *viper.Viper, adminClient *node.UnixDomainSockHttpClient) func(cmd *cobra.Command, args []string) error {
@@ -99,8 +100,17 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			param.ConcurrencyLevel, _ = fs.GetInt("concurrency")
 			param.NormalTxPoolSize, _ = fs.GetInt("normal_tx_pool")
 			param.PatchTxPoolSize, _ = fs.GetInt("patch_tx_pool")
+			param.SenderTxPoolSize, _ = fs.GetInt("sender_tx_pool")
+			param.SenderTxBytes, _ = fs.GetInt("sender_tx_bytes")
+			param.PeerTxRate, _ = fs.GetInt("peer_tx_rate")
 			param.MaxBlockTxBytes, _ = fs.GetInt("max_block_tx_bytes")
+			param.StateGCKeep, _ = fs.GetInt("state_gc_keep")
+			param.StateGCRate, _ = fs.GetInt("state_gc_rate")
+			param.BlockKeep, _ = fs.GetInt("block_keep")
+			param.BlockKeepDays, _ = fs.GetInt("block_keep_days")
 			param.NodeCache, _ = fs.GetString("node_cache")
+			param.EventLogIndex, _ = fs.GetBool("event_log_index")
+			param.TxOrdering, _ = fs.GetString("tx_ordering")
 			param.Channel, _ = fs.GetString("channel")
 			param.SecureSuites, _ = fs.GetString("secure_suites")
 			param.SecureAeads, _ = fs.GetString("secure_aeads")
@@ -156,8 +166,17 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 	joinFlags.Int("concurrency", 1, "Maximum number of executors to be used for concurrency")
 	joinFlags.Int("normal_tx_pool", 0, "Size of normal transaction pool")
 	joinFlags.Int("patch_tx_pool", 0, "Size of patch transaction pool")
+	joinFlags.Int("sender_tx_pool", 0, "Max number of transactions of a sender in normal transaction pool (0: unlimited)")
+	joinFlags.Int("sender_tx_bytes", 0, "Max size of transactions of a sender in normal transaction pool (0: unlimited)")
+	joinFlags.Int("peer_tx_rate", 0, "Max number of transactions per second received from a peer (0: unlimited)")
 	joinFlags.Int("max_block_tx_bytes", 0, "Max size of transactions in a block")
+	joinFlags.Int("state_gc_keep", 0, "Number of recent blocks whose states are kept by state garbage collection (0: disabled)")
+	joinFlags.Int("state_gc_rate", 0, "Max number of nodes deleted per second by state garbage collection (0: unlimited)")
+	joinFlags.Int("block_keep", 0, "Number of recent blocks whose transactions and receipts are kept (0: unlimited)")
+	joinFlags.Int("block_keep_days", 0, "Number of recent days of blocks whose transactions and receipts are kept (0: unlimited)")
 	joinFlags.String("node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
+	joinFlags.Bool("event_log_index", false, "Maintain index of event logs by SCORE address and signature")
+	joinFlags.String("tx_ordering", service.TxOrderingDefault, "Ordering of transactions for block candidates (arrival,step_limit,fairness,priority)")
 	joinFlags.String("channel", "", "Channel")
 	joinFlags.String("secure_suites", "none,tls,ecdhe",
 		"Supported Secure suites with order (none,tls,ecdhe) - Comma separated string")
@@ -258,6 +277,12 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			Short: "Chain data verify",
 			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
 			RunE:  opFunc("verify"),
+		},
+		&cobra.Command{
+			Use:   "reindex CID",
+			Short: "Rebuild index of event logs",
+			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+			RunE:  opFunc("reindex"),
 		})
 
 	importCmd := &cobra.Command{
@@ -317,9 +342,13 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 		Short: "Start to backup the channel",
 		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &node.ChainBackupParam{}
+			param.Live, _ = cmd.Flags().GetBool("live")
+			param.Base, _ = cmd.Flags().GetString("base")
+
 			var v string
 			reqUrl := node.UrlChain + "/" + args[0] + "/backup"
-			_, err := adminClient.PostWithJson(reqUrl, nil, &v)
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
 			if err != nil {
 				return err
 			}
@@ -328,6 +357,53 @@ func NewChainCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 		},
 	}
 	rootCmd.AddCommand(backupCmd)
+	backupFlags := backupCmd.Flags()
+	backupFlags.Bool("live", false, "Backup without stopping the chain")
+	backupFlags.String("base", "", "Name of the previous backup for incremental backup")
+
+	exportCmd := &cobra.Command{
+		Use:   "export CID",
+		Short: "Start to export blocks into a block archive",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			param := &node.ChainExportParam{}
+			param.From, _ = fs.GetInt64("from")
+			param.To, _ = fs.GetInt64("to")
+
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/export"
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(exportCmd)
+	exportFlags := exportCmd.Flags()
+	exportFlags.Int64("from", 0, "First block height")
+	exportFlags.Int64("to", 0, "Last block height(default:the block before the last)")
+
+	importArchiveCmd := &cobra.Command{
+		Use:   "import_archive CID SRC",
+		Short: "Start to import blocks from a block archive",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &node.ChainImportArchiveParam{Src: args[1]}
+
+			var v string
+			reqUrl := node.UrlChain + "/" + args[0] + "/import_archive"
+			_, err := adminClient.PostWithJson(reqUrl, param, &v)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+	rootCmd.AddCommand(importArchiveCmd)
 
 	genesisCmd := &cobra.Command{
 		Use:   "genesis CID FILE",
@@ -512,12 +588,13 @@ func NewRestoreCmd(parent *cobra.Command, client *node.UnixDomainSockHttpClient)
 	rootCmd.AddCommand(statusCmd)
 
 	startCmd := &cobra.Command{
-		Use:   "start [NAME]",
+		Use:   "start NAME [INCREMENT...]",
 		Short: "Start to restore the specified backup",
-		Args:  cobra.RangeArgs(1, 1),
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var params node.RestoreBackupParam
 			params.Name = args[0]
+			params.Increments = args[1:]
 			params.Overwrite, _ = cmd.PersistentFlags().GetBool("overwrite")
 			var v string
 			_, err := client.PostWithJson(node.UrlSystem+"/restore", &params, &v)
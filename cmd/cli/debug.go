@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/icon-project/goloop/client"
+	"github.com/icon-project/goloop/common/intconv"
 	"github.com/icon-project/goloop/server/jsonrpc"
 	v3 "github.com/icon-project/goloop/server/v3"
 )
@@ -59,6 +60,11 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			param := &v3.TransactionHashParam{
 				Hash: jsonrpc.HexBytes(args[0]),
 			}
+			if stateDiff, _ := cmd.Flags().GetBool("state_diff"); stateDiff {
+				opts := jsonrpc.IconOptions{}
+				opts.SetBool(jsonrpc.IconOptionsStateDiff, true)
+				debugClient.CustomHeader[jsonrpc.HeaderKeyIconOptions] = opts.ToHeaderValue()
+			}
 			trace, err := debugClient.Do("debug_getTrace", param, nil)
 			if err != nil {
 				return err
@@ -66,7 +72,85 @@ func NewDebugCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Comman
 			return JsonPrettyPrintln(os.Stdout, trace.Result)
 		},
 	}
+	traceCmd.Flags().Bool("state_diff", false, "Include changes of the state")
 	rootCmd.AddCommand(traceCmd)
 
+	poolCmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Get transactions in the transaction pool",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			param := &v3.PoolTransactionsParam{}
+			if group, _ := fs.GetString("group"); group != "" {
+				param.Group = group
+			}
+			if offset, _ := fs.GetInt64("offset"); offset > 0 {
+				param.Offset = jsonrpc.HexInt(intconv.FormatInt(offset))
+			}
+			if limit, _ := fs.GetInt64("limit"); limit > 0 {
+				param.Limit = jsonrpc.HexInt(intconv.FormatInt(limit))
+			}
+			txs, err := debugClient.Do("debug_getPoolTransactions", param, nil)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, txs.Result)
+		},
+	}
+	poolCmd.Flags().String("group", "normal", "Transaction group(normal,patch)")
+	poolCmd.Flags().Int64("offset", 0, "Offset of the first transaction")
+	poolCmd.Flags().Int64("limit", 0, "Maximum number of transactions")
+	rootCmd.AddCommand(poolCmd)
+
+	poolStatusCmd := &cobra.Command{
+		Use:   "poolstatus",
+		Short: "Get status of the transaction pools",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := debugClient.Do("debug_getPoolStatus", nil, nil)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, status.Result)
+		},
+	}
+	rootCmd.AddCommand(poolStatusCmd)
+
+	droppedCmd := &cobra.Command{
+		Use:   "dropped HASH",
+		Short: "Get the reason why the transaction was dropped from the pool",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.TransactionHashParam{
+				Hash: jsonrpc.HexBytes(args[0]),
+			}
+			drop, err := debugClient.Do("debug_getDroppedTransaction", param, nil)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, drop.Result)
+		},
+	}
+	rootCmd.AddCommand(droppedCmd)
+
+	evictCmd := &cobra.Command{
+		Use:   "evict HASH...",
+		Short: "Evict transactions from the transaction pool",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.EvictTransactionsParam{}
+			for _, arg := range args {
+				param.Hashes = append(param.Hashes, jsonrpc.HexBytes(arg))
+			}
+			evicted, err := debugClient.Do("debug_evictTransactions", param, nil)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, evicted.Result)
+		},
+	}
+	rootCmd.AddCommand(evictCmd)
+
 	return rootCmd, vc
 }
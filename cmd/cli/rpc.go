@@ -53,6 +53,16 @@ func AddRpcRequiredFlags(c *cobra.Command) {
 	MarkAnnotationCustom(pFlags, "uri")
 }
 
+// heightFlagValue returns the value of the height flag of the command.
+// It returns empty value if the flag isn't specified.
+func heightFlagValue(cmd *cobra.Command) jsonrpc.HexInt {
+	if !cmd.Flags().Changed("height") {
+		return ""
+	}
+	height, _ := cmd.Flags().GetInt64("height")
+	return jsonrpc.HexInt(intconv.FormatInt(height))
+}
+
 func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command, *viper.Viper) {
 	var rpcClient client.ClientV3
 	rootCmd, vc := NewCommand(parentCmd, parentVc, "rpc", "JSON-RPC API")
@@ -106,44 +116,6 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				return JsonPrettyPrintln(os.Stdout, blk)
 			},
 		},
-		&cobra.Command{
-			Use:   "balance ADDRESS",
-			Short: "GetBalance",
-			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
-			RunE: func(cmd *cobra.Command, args []string) error {
-				param := &v3.AddressParam{Address: jsonrpc.Address(args[0])}
-				balance, err := rpcClient.GetBalance(param)
-				if err != nil {
-					return err
-				}
-				return JsonPrettyPrintln(os.Stdout, balance)
-			},
-		},
-		&cobra.Command{
-			Use:   "scoreapi ADDRESS",
-			Short: "GetScoreApi",
-			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
-			RunE: func(cmd *cobra.Command, args []string) error {
-				param := &v3.ScoreAddressParam{Address: jsonrpc.Address(args[0])}
-				scoreApi, err := rpcClient.GetScoreApi(param)
-				if err != nil {
-					return err
-				}
-				return JsonPrettyPrintln(os.Stdout, scoreApi)
-			},
-		},
-		&cobra.Command{
-			Use:   "totalsupply",
-			Short: "GetTotalSupply",
-			Args:  cobra.NoArgs,
-			RunE: func(cmd *cobra.Command, args []string) error {
-				supply, err := rpcClient.GetTotalSupply()
-				if err != nil {
-					return err
-				}
-				return JsonPrettyPrintln(os.Stdout, supply)
-			},
-		},
 		&cobra.Command{
 			Use:   "txresult HASH",
 			Short: "GetTransactionResult",
@@ -170,6 +142,143 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				return JsonPrettyPrintln(os.Stdout, tx)
 			},
 		})
+	balanceCmd := &cobra.Command{
+		Use:   "balance ADDRESS",
+		Short: "GetBalance",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.AddressParam{Address: jsonrpc.Address(args[0])}
+			param.Height = heightFlagValue(cmd)
+			balance, err := rpcClient.GetBalance(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, balance)
+		},
+	}
+	rootCmd.AddCommand(balanceCmd)
+	balanceCmd.Flags().Int64("height", 0, "Height of the block (default: last block)")
+
+	nonceCmd := &cobra.Command{
+		Use:   "nonce ADDRESS",
+		Short: "GetNonce",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.AddressParam{Address: jsonrpc.Address(args[0])}
+			param.Height = heightFlagValue(cmd)
+			nonce, err := rpcClient.GetNonce(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, nonce)
+		},
+	}
+	rootCmd.AddCommand(nonceCmd)
+	nonceCmd.Flags().Int64("height", 0, "Height of the block (default: last block)")
+
+	scoreApiCmd := &cobra.Command{
+		Use:   "scoreapi ADDRESS",
+		Short: "GetScoreApi",
+		Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.ScoreAddressParam{Address: jsonrpc.Address(args[0])}
+			param.Height = heightFlagValue(cmd)
+			scoreApi, err := rpcClient.GetScoreApi(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, scoreApi)
+		},
+	}
+	rootCmd.AddCommand(scoreApiCmd)
+	scoreApiCmd.Flags().Int64("height", 0, "Height of the block (default: last block)")
+
+	totalSupplyCmd := &cobra.Command{
+		Use:   "totalsupply",
+		Short: "GetTotalSupply",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var param *v3.TotalSupplyParam
+			if height := heightFlagValue(cmd); len(height) > 0 {
+				param = &v3.TotalSupplyParam{Height: height}
+			}
+			supply, err := rpcClient.GetTotalSupply(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, supply)
+		},
+	}
+	rootCmd.AddCommand(totalSupplyCmd)
+	totalSupplyCmd.Flags().Int64("height", 0, "Height of the block (default: last block)")
+
+	logsCmd := &cobra.Command{
+		Use:   "logs FROM_HEIGHT [TO_HEIGHT]",
+		Short: "GetLogs",
+		Args:  ArgsWithDefaultErrorFunc(cobra.RangeArgs(1, 2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &v3.LogsParam{}
+			if rawJson := cmd.Flag("raw").Value.String(); rawJson != "" {
+				var dataBytes []byte
+				if strings.HasPrefix(strings.TrimSpace(rawJson), "{") {
+					dataBytes = []byte(rawJson)
+				} else {
+					var err error
+					if dataBytes, err = ioutil.ReadFile(rawJson); err != nil {
+						return err
+					}
+				}
+				if err := json.Unmarshal(dataBytes, &param.EventFilter); err != nil {
+					return err
+				}
+			} else if err := ValidateFlags(cmd.Flags(), "event"); err != nil {
+				return err
+			}
+			from, err := intconv.ParseInt(args[0], 64)
+			if err != nil {
+				return err
+			}
+			param.FromHeight = jsonrpc.HexInt(intconv.FormatInt(from))
+			if len(args) > 1 {
+				to, err := intconv.ParseInt(args[1], 64)
+				if err != nil {
+					return err
+				}
+				param.ToHeight = jsonrpc.HexInt(intconv.FormatInt(to))
+			}
+			if sig := cmd.Flag("event").Value.String(); sig != "" {
+				param.Signature = sig
+			}
+			if addr := cmd.Flag("addr").Value.String(); addr != "" {
+				param.Addr = common.NewAddressFromString(addr)
+			}
+			if evtIndexed, err := cmd.Flags().GetStringSlice("indexed"); err == nil && len(evtIndexed) > 0 {
+				param.Indexed = make([]*string, len(evtIndexed))
+				for i := range evtIndexed {
+					param.Indexed[i] = &evtIndexed[i]
+				}
+			}
+			if evtData, err := cmd.Flags().GetStringSlice("data"); err == nil && len(evtData) > 0 {
+				param.Data = make([]*string, len(evtData))
+				for i := range evtData {
+					param.Data[i] = &evtData[i]
+				}
+			}
+			logs, err := rpcClient.GetLogs(param)
+			if err != nil {
+				return err
+			}
+			return JsonPrettyPrintln(os.Stdout, logs)
+		},
+	}
+	rootCmd.AddCommand(logsCmd)
+	logsFlags := logsCmd.Flags()
+	logsFlags.String("addr", "", "SCORE Address")
+	logsFlags.String("event", "", "Signature of Event")
+	logsFlags.StringSlice("indexed", nil, "Indexed Arguments of Event, comma-separated string")
+	logsFlags.StringSlice("data", nil, "Not indexed Arguments of Event, comma-separated string")
+	logsFlags.String("raw", "", "EventFilter raw json file or json-string")
+
 	callCmd := &cobra.Command{
 		Use:   "call",
 		Short: "Call",
@@ -180,6 +289,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				ToAddress:   jsonrpc.Address(cmd.Flag("to").Value.String()),
 				DataType:    "call", //refer server/v3/validation.go:27 isCall
 			}
+			param.Height = heightFlagValue(cmd)
 
 			dataM := make(map[string]interface{})
 			if dataJson := cmd.Flag("raw").Value.String(); dataJson != "" {
@@ -220,6 +330,7 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 	callFlags := callCmd.Flags()
 	callFlags.String("from", "", "FromAddress")
 	callFlags.String("to", "", "ToAddress")
+	callFlags.Int64("height", 0, "Height of the block (default: last block)")
 	callFlags.String("method", "",
 		"Name of the function to invoke in SCORE, if '--raw' used, will overwrite")
 	callFlags.StringToString("param", nil,
@@ -342,6 +453,39 @@ func NewRpcCmd(parentCmd *cobra.Command, parentVc *viper.Viper) (*cobra.Command,
 				}
 				return JsonPrettyPrintln(os.Stdout, raw)
 			},
+		},
+		&cobra.Command{
+			Use:   "proofforaccount BLOCK_HASH ADDRESS",
+			Short: "GetProofForAccount",
+			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(2)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				param := &v3.ProofAccountParam{
+					BlockHash: jsonrpc.HexBytes(args[0]),
+					Address:   jsonrpc.Address(args[1]),
+				}
+				raw, err := rpcClient.GetProofForAccount(param)
+				if err != nil {
+					return err
+				}
+				return JsonPrettyPrintln(os.Stdout, raw)
+			},
+		},
+		&cobra.Command{
+			Use:   "proofforstorage BLOCK_HASH ADDRESS KEY",
+			Short: "GetProofForStorage",
+			Args:  ArgsWithDefaultErrorFunc(cobra.ExactArgs(3)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				param := &v3.ProofStorageParam{
+					BlockHash: jsonrpc.HexBytes(args[0]),
+					Address:   jsonrpc.Address(args[1]),
+					Key:       jsonrpc.HexBytes(args[2]),
+				}
+				raw, err := rpcClient.GetProofForStorage(param)
+				if err != nil {
+					return err
+				}
+				return JsonPrettyPrintln(os.Stdout, raw)
+			},
 		})
 	return rootCmd, vc
 }
@@ -794,6 +938,26 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 		Args:  ArgsWithDefaultErrorFunc(cobra.MaximumNArgs(1)),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			param := &server.EventRequest{}
+			fs, err := cmd.Flags().GetStringArray("filter")
+			if err != nil {
+				return err
+			}
+			for _, f := range fs {
+				ef := &server.EventFilter{}
+				var efBytes []byte
+				if strings.HasPrefix(strings.TrimSpace(f), "{") {
+					efBytes = []byte(f)
+				} else {
+					var err error
+					if efBytes, err = ioutil.ReadFile(f); err != nil {
+						return err
+					}
+				}
+				if err := json.Unmarshal(efBytes, ef); err != nil {
+					return fmt.Errorf("fail to unmarshal from %s, err:%+v", f, err)
+				}
+				param.EventFilters = append(param.EventFilters, ef)
+			}
 			if rawJson := cmd.Flag("raw").Value.String(); rawJson != "" {
 				var dataBytes []byte
 				if strings.HasPrefix(strings.TrimSpace(rawJson), "{") {
@@ -811,8 +975,10 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 				if err := cobra.ExactArgs(1)(cmd, args); err != nil {
 					return err
 				}
-				if err := ValidateFlags(cmd.Flags(), "event"); err != nil {
-					return err
+				if len(param.EventFilters) == 0 {
+					if err := ValidateFlags(cmd.Flags(), "event"); err != nil {
+						return err
+					}
 				}
 			}
 			if len(args) > 0 {
@@ -841,8 +1007,15 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 					param.Data[i] = &v
 				}
 			}
+			if logs, _ := cmd.Flags().GetBool("logs"); logs {
+				param.Logs = true
+			}
+			if cmd.Flags().Changed("progress_interval") {
+				interval, _ := cmd.Flags().GetInt64("progress_interval")
+				param.ProgressInterval = common.HexInt64{Value: interval}
+			}
 			OnInterrupt(rpcClient.Cleanup)
-			err := rpcClient.MonitorEvent(param, func(v *server.EventNotification) {
+			err = rpcClient.MonitorEvent(param, func(v *server.EventNotification) {
 				JsonPrettyPrintln(os.Stdout, v)
 			}, nil)
 			if err != nil {
@@ -859,5 +1032,45 @@ func NewMonitorCmd(parentCmd *cobra.Command, parentVc *viper.Viper) *cobra.Comma
 	monitorEventFlags.StringSlice("indexed", nil, "Indexed Arguments of Event, comma-separated string")
 	monitorEventFlags.StringSlice("data", nil, "Not indexed Arguments of Event, comma-separated string")
 	monitorEventFlags.String("raw", "", "EventFilter raw json file or json-string")
+	monitorEventFlags.StringArray("filter", nil,
+		"EventFilter raw json file or json string, for multiple filters")
+	monitorEventFlags.Bool("logs", false, "Include event logs")
+	monitorEventFlags.Int64("progress_interval", 0, "Interval of progress notifications in blocks")
+
+	monitorTransactionCmd := &cobra.Command{
+		Use:   "transaction",
+		Short: "MonitorTransaction",
+		Args:  ArgsWithDefaultErrorFunc(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			param := &server.TransactionRequest{}
+			if from := cmd.Flag("from").Value.String(); from != "" {
+				param.From = common.NewAddressFromString(from)
+			}
+			if to := cmd.Flag("to").Value.String(); to != "" {
+				param.To = common.NewAddressFromString(to)
+			}
+			if cmd.Flags().Changed("data_type") {
+				dataType := cmd.Flag("data_type").Value.String()
+				param.DataType = &dataType
+			}
+			param.Full, _ = cmd.Flags().GetBool("full")
+			param.Drops, _ = cmd.Flags().GetBool("drops")
+			OnInterrupt(rpcClient.Cleanup)
+			err := rpcClient.MonitorTransaction(param, func(v *server.TransactionNotification) {
+				JsonPrettyPrintln(os.Stdout, v)
+			}, nil)
+			if err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+	rootCmd.AddCommand(monitorTransactionCmd)
+	monitorTransactionFlags := monitorTransactionCmd.Flags()
+	monitorTransactionFlags.String("from", "", "Address of the sender")
+	monitorTransactionFlags.String("to", "", "Address of the receiver")
+	monitorTransactionFlags.String("data_type", "", "Data type of the transaction")
+	monitorTransactionFlags.Bool("full", false, "Include the transaction")
+	monitorTransactionFlags.Bool("drops", false, "Include dropped transactions")
 	return rootCmd
 }
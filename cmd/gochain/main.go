@@ -24,6 +24,7 @@ import (
 	"github.com/icon-project/goloop/network"
 	"github.com/icon-project/goloop/server"
 	"github.com/icon-project/goloop/server/metric"
+	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/eeproxy"
 )
 
@@ -128,8 +129,17 @@ func main() {
 	flag.IntVar(&cfg.ConcurrencyLevel, "concurrency", 1, "Maximum number of executors to be used for concurrency")
 	flag.IntVar(&cfg.NormalTxPoolSize, "normal_tx_pool", 0, "Normal transaction pool size")
 	flag.IntVar(&cfg.PatchTxPoolSize, "patch_tx_pool", 0, "Patch transaction pool size")
+	flag.IntVar(&cfg.SenderTxPoolSize, "sender_tx_pool", 0, "Max number of transactions of a sender in normal transaction pool (0: unlimited)")
+	flag.IntVar(&cfg.SenderTxBytes, "sender_tx_bytes", 0, "Max size of transactions of a sender in normal transaction pool (0: unlimited)")
+	flag.IntVar(&cfg.PeerTxRate, "peer_tx_rate", 0, "Max number of transactions per second received from a peer (0: unlimited)")
 	flag.IntVar(&cfg.MaxBlockTxBytes, "max_block_tx_bytes", 0, "Maximum size of transactions in a block")
+	flag.IntVar(&cfg.StateGCKeep, "state_gc_keep", 0, "Number of recent blocks whose states are kept by state garbage collection (0: disabled)")
+	flag.IntVar(&cfg.StateGCRate, "state_gc_rate", 0, "Max number of nodes deleted per second by state garbage collection (0: unlimited)")
+	flag.IntVar(&cfg.BlockKeep, "block_keep", 0, "Number of recent blocks whose transactions and receipts are kept (0: unlimited)")
+	flag.IntVar(&cfg.BlockKeepDays, "block_keep_days", 0, "Number of recent days of blocks whose transactions and receipts are kept (0: unlimited)")
 	flag.StringVar(&cfg.NodeCache, "node_cache", chain.NodeCacheDefault, "Node cache (none,small,large)")
+	flag.BoolVar(&cfg.EventLogIndex, "event_log_index", false, "Maintain index of event logs by SCORE address and signature")
+	flag.StringVar(&cfg.TxOrdering, "tx_ordering", service.TxOrderingDefault, "Ordering of transactions for block candidates (arrival,step_limit,fairness,priority)")
 	flag.StringVar(&cfg.LogLevel, "log_level", "debug", "Main log level")
 	flag.StringVar(&cfg.ConsoleLevel, "console_level", "trace", "Console log level")
 	flag.StringToStringVar(&modLevels, "mod_level", nil, "Console log level for specific module (<mod>=<level>,...)")
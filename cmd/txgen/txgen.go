@@ -42,7 +42,8 @@ func (c *Client) SendTx(tx interface{}) (string, error) {
 		r, err := c.Do("icx_sendTransaction", tx, nil)
 		if err != nil {
 			if re, ok := err.(*jsonrpc.Error); ok {
-				if re.Code == jsonrpc.ErrorCodeTxPoolOverflow {
+				if re.Code == jsonrpc.ErrorCodeTxPoolOverflow ||
+					re.Code == jsonrpc.ErrorCodeSenderQuota {
 					continue
 				}
 				return "", errors.Errorf("RPC Server Error code=%d msg=%s", r.Error.Code, r.Error.Message)
@@ -188,7 +189,8 @@ func (ctx *Context) sendRequests(wg *sync.WaitGroup, client *Client) {
 			r, err := client.Do(method, tx, nil)
 			if err != nil {
 				if re, ok := err.(*jsonrpc.Error); ok {
-					if re.Code == jsonrpc.ErrorCodeTxPoolOverflow {
+					if re.Code == jsonrpc.ErrorCodeTxPoolOverflow ||
+						re.Code == jsonrpc.ErrorCodeSenderQuota {
 						time.Sleep(ctx.delay / 3)
 						continue
 					}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"bytes"
 	"path/filepath"
 
 	"github.com/dgraph-io/badger"
@@ -20,6 +21,10 @@ func NewBadgerDB(name string, dir string) (*BadgerDB, error) {
 	opts.Dir = dbPath
 	opts.ValueDir = dbPath
 
+	return openBadgerDB(opts)
+}
+
+func openBadgerDB(opts badger.Options) (*BadgerDB, error) {
 	// TODO : badger.openDatabase() use os.Mkdir(). parent dirs must be created
 	db, err := badger.Open(opts)
 
@@ -50,6 +55,10 @@ func (db *BadgerDB) GetBucket(id BucketID) (Bucket, error) {
 	}, nil
 }
 
+func (db *BadgerDB) NewBatch() Batch {
+	return &badgerBatch{db: db.db}
+}
+
 func (db *BadgerDB) Close() error {
 	err := db.db.Close()
 	return err
@@ -103,3 +112,166 @@ func (bucket *badgerBucket) Delete(key []byte) error {
 		return txn.Delete(ikey)
 	})
 }
+
+func (bucket *badgerBucket) NewIterator(r *Range) Iterator {
+	start, limit := r.internalRange(bucket.id)
+	txn := bucket.db.NewTransaction(false)
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = r.isReverse()
+	return &badgerIterator{
+		txn:    txn,
+		iter:   txn.NewIterator(opts),
+		opts:   opts,
+		id:     bucket.id,
+		prefix: len(bucket.id),
+		start:  start,
+		limit:  limit,
+	}
+}
+
+//----------------------------------------
+// Batch
+
+var _ Batch = (*badgerBatch)(nil)
+
+type badgerBatch struct {
+	batchOps
+	db *badger.DB
+}
+
+func (b *badgerBatch) apply(txn *badger.Txn, op *batchOp) error {
+	ikey := internalKey(op.id, op.key)
+	if op.isDelete() {
+		return txn.Delete(ikey)
+	}
+	return txn.Set(ikey, op.value)
+}
+
+// Write applies operations in a transaction. Badger limits the size of
+// a transaction, so a batch exceeding the limit is committed through
+// multiple transactions, and it's atomic only within the limit.
+func (b *badgerBatch) Write() error {
+	txn := b.db.NewTransaction(true)
+	defer func() {
+		txn.Discard()
+	}()
+	for i := range b.ops {
+		op := &b.ops[i]
+		err := b.apply(txn, op)
+		if err == badger.ErrTxnTooBig {
+			if err := txn.Commit(nil); err != nil {
+				return err
+			}
+			txn = b.db.NewTransaction(true)
+			err = b.apply(txn, op)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return txn.Commit(nil)
+}
+
+//----------------------------------------
+// Iterator
+
+type badgerIterator struct {
+	txn     *badger.Txn
+	iter    *badger.Iterator
+	opts    badger.IteratorOptions
+	id      BucketID
+	prefix  int
+	start   []byte
+	limit   []byte
+	started bool
+
+	ikey  []byte
+	key   []byte
+	value []byte
+	err   error
+}
+
+// Next moves to the next entry of the bucket skipping the entries of other
+// buckets. See isForeignEntry.
+func (it *badgerIterator) Next() bool {
+	for it.move() {
+		if !isForeignEntry(it.id, it.ikey, it.value) {
+			it.key = append([]byte{}, it.ikey[it.prefix:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (it *badgerIterator) move() bool {
+	if it.iter == nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		if it.opts.Reverse {
+			if it.limit != nil {
+				// seek moves to the largest key less than or equal to
+				// the limit, and the limit is exclusive.
+				it.iter.Seek(it.limit)
+				if it.iter.Valid() && bytes.Equal(it.iter.Item().Key(), it.limit) {
+					it.iter.Next()
+				}
+			} else {
+				it.iter.Rewind()
+			}
+		} else {
+			it.iter.Seek(it.start)
+		}
+	} else {
+		it.iter.Next()
+	}
+	if !it.iter.Valid() {
+		return it.done()
+	}
+	item := it.iter.Item()
+	key := item.Key()
+	if it.opts.Reverse {
+		if bytes.Compare(key, it.start) < 0 {
+			return it.done()
+		}
+	} else {
+		if it.limit != nil && bytes.Compare(key, it.limit) >= 0 {
+			return it.done()
+		}
+	}
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		it.err = err
+		return it.done()
+	}
+	it.ikey = append([]byte{}, key...)
+	it.value = value
+	return true
+}
+
+func (it *badgerIterator) done() bool {
+	it.ikey, it.key, it.value = nil, nil, nil
+	return false
+}
+
+func (it *badgerIterator) Key() []byte {
+	return it.key
+}
+
+func (it *badgerIterator) Value() []byte {
+	return it.value
+}
+
+func (it *badgerIterator) Error() error {
+	return it.err
+}
+
+func (it *badgerIterator) Release() {
+	if it.iter != nil {
+		it.iter.Close()
+		it.txn.Discard()
+		it.iter = nil
+	}
+	it.done()
+}
@@ -1,10 +1,12 @@
 package db
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/dgraph-io/badger"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,3 +34,36 @@ func TestBadgerDB_Database(t *testing.T) {
 	result, _ = bucket.Get(key)
 	assert.Nil(t, result, "empty")
 }
+
+func TestBadgerDB_BatchTooLarge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badgerdb")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	opts.MaxTableSize = 1 << 20
+	testDB, err := openBadgerDB(opts)
+	assert.NoError(t, err)
+	defer testDB.Close()
+
+	bk, _ := testDB.GetBucket("A")
+	assert.NoError(t, bk.Set([]byte("k0"), []byte("old")))
+
+	value := []byte("value")
+	batch := testDB.NewBatch()
+	assert.NoError(t, batch.Delete("A", []byte("k0")))
+	for i := 0; i < 20000; i++ {
+		assert.NoError(t, batch.Set("A", []byte(fmt.Sprintf("k%d", i+1)), value))
+	}
+	assert.NoError(t, batch.Write())
+
+	// all operations are applied through multiple transactions
+	assert.False(t, bk.Has([]byte("k0")))
+	for i := 0; i < 20000; i++ {
+		assert.True(t, bk.Has([]byte(fmt.Sprintf("k%d", i+1))))
+	}
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Batch collects puts and deletes over multiple buckets of a database,
+// then Write applies them atomically.
+// Batch is not safe for concurrent use.
+type Batch interface {
+	Set(id BucketID, key []byte, value []byte) error
+	Delete(id BucketID, key []byte) error
+
+	// Len returns number of collected operations.
+	Len() int
+
+	// Reset clears collected operations.
+	Reset()
+
+	// Write applies collected operations to the database. Operations are
+	// applied in the order they were added. It doesn't reset the batch.
+	Write() error
+}
+
+type batchOp struct {
+	id    BucketID
+	key   []byte
+	value []byte
+}
+
+func (op *batchOp) isDelete() bool {
+	return op.value == nil
+}
+
+// batchOps is list of operations for the backends without native batch
+// support for the bucket.
+type batchOps struct {
+	ops []batchOp
+}
+
+func (b *batchOps) Set(id BucketID, key []byte, value []byte) error {
+	if value == nil {
+		return errors.New("IllegalArgument")
+	}
+	b.ops = append(b.ops, batchOp{
+		id:    id,
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	return nil
+}
+
+func (b *batchOps) Delete(id BucketID, key []byte) error {
+	b.ops = append(b.ops, batchOp{
+		id:  id,
+		key: append([]byte{}, key...),
+	})
+	return nil
+}
+
+func (b *batchOps) Len() int {
+	return len(b.ops)
+}
+
+func (b *batchOps) Reset() {
+	b.ops = nil
+}
+
+// bucketBatch is emulated batch applying operations through buckets of
+// the database. It's not atomic, so it's used only for the databases which
+// are not persistent or which delegate atomicity to the underlying ones.
+type bucketBatch struct {
+	batchOps
+	database Database
+}
+
+func (b *bucketBatch) Write() error {
+	buckets := make(map[BucketID]Bucket)
+	for _, op := range b.ops {
+		bk, ok := buckets[op.id]
+		if !ok {
+			var err error
+			if bk, err = b.database.GetBucket(op.id); err != nil {
+				return err
+			}
+			buckets[op.id] = bk
+		}
+		if op.isDelete() {
+			if err := bk.Delete(op.key); err != nil {
+				return err
+			}
+		} else {
+			if err := bk.Set(op.key, op.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewBucketBatch returns a batch writing operations through buckets of
+// the database one by one.
+func NewBucketBatch(database Database) Batch {
+	return &bucketBatch{database: database}
+}
+
+// errorBatch is a batch failing on Write with the error.
+type errorBatch struct {
+	batchOps
+	err error
+}
+
+func (b *errorBatch) Write() error {
+	return b.err
+}
+
+// NewErrorBatch returns a batch returning the error on Write.
+// It's used by databases which can't support batch.
+func NewErrorBatch(err error) Batch {
+	return &errorBatch{err: err}
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDatabaseBatch(t *testing.T, dbase Database) {
+	bk1, _ := dbase.GetBucket("A")
+	bk2, _ := dbase.GetBucket("B")
+	assert.NoError(t, bk1.Set([]byte("k0"), []byte("old")))
+
+	batch := dbase.NewBatch()
+	assert.NoError(t, batch.Set("A", []byte("k1"), []byte("v1")))
+	assert.NoError(t, batch.Set("B", []byte("k2"), []byte("v2")))
+	assert.NoError(t, batch.Delete("A", []byte("k0")))
+	assert.Error(t, batch.Set("A", []byte("k3"), nil))
+	assert.Equal(t, 3, batch.Len())
+
+	assert.False(t, bk1.Has([]byte("k1")))
+	assert.False(t, bk2.Has([]byte("k2")))
+	assert.True(t, bk1.Has([]byte("k0")))
+
+	assert.NoError(t, batch.Write())
+
+	v, err := bk1.Get([]byte("k1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v1"), v)
+	v, err = bk2.Get([]byte("k2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v2"), v)
+	assert.False(t, bk1.Has([]byte("k0")))
+
+	batch.Reset()
+	assert.Equal(t, 0, batch.Len())
+
+	ldb := NewLayerDB(dbase)
+	lbk1, _ := ldb.GetBucket("A")
+	lbk3, _ := ldb.GetBucket("C")
+	assert.NoError(t, lbk1.Delete([]byte("k1")))
+	assert.NoError(t, lbk3.Set([]byte("k3"), []byte("v3")))
+	assert.True(t, bk1.Has([]byte("k1")))
+
+	assert.NoError(t, ldb.Flush(true))
+
+	bk3, _ := dbase.GetBucket("C")
+	assert.False(t, bk1.Has([]byte("k1")))
+	v, err = bk3.Get([]byte("k3"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v3"), v)
+}
+
+func TestDatabase_Batch(t *testing.T) {
+	for _, backend := range []BackendType{
+		BadgerDBBackend, GoLevelDBBackend, BoltDBBackend, MapDBBackend,
+	} {
+		t.Run(string(backend), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", string(backend))
+			if err != nil {
+				panic(err)
+			}
+			defer os.RemoveAll(dir)
+
+			testDB, err := openDatabase(backend, "test", dir)
+			assert.NoError(t, err)
+			defer testDB.Close()
+
+			testDatabaseBatch(t, testDB)
+		})
+	}
+}
+
+func TestProxyDB_Batch(t *testing.T) {
+	pdb := NewProxyDB()
+	batch := pdb.NewBatch()
+	assert.NoError(t, batch.Set("A", []byte("k"), []byte("v")))
+	assert.Error(t, batch.Write())
+
+	mdb := NewMapDB()
+	assert.NoError(t, pdb.SetReal(mdb))
+	assert.NoError(t, batch.Write())
+
+	bk, _ := mdb.GetBucket("A")
+	v, err := bk.Get([]byte("k"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v"), v)
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"bytes"
 	"path/filepath"
 
 	bolt "go.etcd.io/bbolt"
@@ -44,6 +45,10 @@ func (db *BoltDB) GetBucket(id BucketID) (Bucket, error) {
 	return &boltBucket{db: db.db, id: bid}, err
 }
 
+func (db *BoltDB) NewBatch() Batch {
+	return &boltBatch{db: db.db}
+}
+
 func (db *BoltDB) Close() error {
 	err := db.db.Close()
 	return err
@@ -96,3 +101,131 @@ func (bucket *boltBucket) Delete(key []byte) error {
 	})
 	return err
 }
+
+func (bucket *boltBucket) NewIterator(r *Range) Iterator {
+	tx, err := bucket.db.Begin(false)
+	if err != nil {
+		return NewErrorIterator(err)
+	}
+	it := &boltIterator{
+		tx:     tx,
+		cursor: tx.Bucket(bucket.id).Cursor(),
+	}
+	if r != nil {
+		it.start, it.limit, it.reverse = r.Start, r.Limit, r.Reverse
+	}
+	return it
+}
+
+//----------------------------------------
+// Batch
+
+var _ Batch = (*boltBatch)(nil)
+
+type boltBatch struct {
+	batchOps
+	db *bolt.DB
+}
+
+func (b *boltBatch) Write() error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		buckets := make(map[BucketID]*bolt.Bucket)
+		for _, op := range b.ops {
+			bk, ok := buckets[op.id]
+			if !ok {
+				var err error
+				bk, err = tx.CreateBucketIfNotExists([]byte("B" + op.id))
+				if err != nil {
+					return err
+				}
+				buckets[op.id] = bk
+			}
+			var err error
+			if op.isDelete() {
+				err = bk.Delete(op.key)
+			} else {
+				err = bk.Put(op.key, op.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+//----------------------------------------
+// Iterator
+
+type boltIterator struct {
+	tx      *bolt.Tx
+	cursor  *bolt.Cursor
+	start   []byte
+	limit   []byte
+	reverse bool
+	started bool
+
+	key   []byte
+	value []byte
+}
+
+func (it *boltIterator) first() ([]byte, []byte) {
+	if !it.reverse {
+		if it.start != nil {
+			return it.cursor.Seek(it.start)
+		}
+		return it.cursor.First()
+	}
+	if it.limit != nil {
+		// seek moves to the smallest key greater than or equal to
+		// the limit, and the limit is exclusive.
+		if k, _ := it.cursor.Seek(it.limit); k != nil {
+			return it.cursor.Prev()
+		}
+	}
+	return it.cursor.Last()
+}
+
+func (it *boltIterator) Next() bool {
+	if it.tx == nil {
+		return false
+	}
+	var k, v []byte
+	if !it.started {
+		it.started = true
+		k, v = it.first()
+	} else if it.reverse {
+		k, v = it.cursor.Prev()
+	} else {
+		k, v = it.cursor.Next()
+	}
+	if k == nil ||
+		(it.reverse && it.start != nil && bytes.Compare(k, it.start) < 0) ||
+		(!it.reverse && it.limit != nil && bytes.Compare(k, it.limit) >= 0) {
+		it.key, it.value = nil, nil
+		return false
+	}
+	it.key = append([]byte{}, k...)
+	it.value = append([]byte{}, v...)
+	return true
+}
+
+func (it *boltIterator) Key() []byte {
+	return it.key
+}
+
+func (it *boltIterator) Value() []byte {
+	return it.value
+}
+
+func (it *boltIterator) Error() error {
+	return nil
+}
+
+func (it *boltIterator) Release() {
+	if it.tx != nil {
+		it.tx.Rollback()
+		it.tx = nil
+	}
+	it.key, it.value = nil, nil
+}
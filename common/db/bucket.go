@@ -6,6 +6,15 @@ type Bucket interface {
 	Has(key []byte) bool
 	Set(key []byte, value []byte) error
 	Delete(key []byte) error
+
+	// NewIterator returns an iterator over the entries in the range.
+	// nil range means all entries of the bucket.
+	//
+	// Backends keeping all buckets in one keyspace (goleveldb and badgerdb)
+	// find the entries of MerkleTrie in the range of other buckets, because
+	// MerkleTrie has the empty prefix, and vice versa. The iterator skips
+	// them by checking whether the internal key is sha3(value).
+	NewIterator(r *Range) Iterator
 }
 
 type BucketID string
@@ -32,6 +41,18 @@ const (
 
 	// ChainProperty is general key value map for chain property.
 	ChainProperty BucketID = "C"
+
+	// EventLogIndex maps location of event log from SCORE address and
+	// sha3(event signature).
+	EventLogIndex BucketID = "E"
+
+	// TransactionPoolJournal maps transactions in the transaction pools
+	// from transaction hash.
+	TransactionPoolJournal BucketID = "P"
+
+	// ReceiptNodeRefByHash maps the last height of the blocks referring
+	// the merkle trie node of receipts from hash of the node.
+	ReceiptNodeRefByHash BucketID = "N"
 )
 
 // internalKey returns key prefixed with the bucket's id.
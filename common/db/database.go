@@ -6,6 +6,11 @@ import (
 
 type Database interface {
 	GetBucket(id BucketID) (Bucket, error)
+
+	// NewBatch returns a batch to write operations over multiple buckets
+	// atomically.
+	NewBatch() Batch
+
 	Close() error
 }
 
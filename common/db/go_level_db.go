@@ -3,8 +3,11 @@ package db
 import (
 	"path/filepath"
 
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 func init() {
@@ -46,6 +49,13 @@ func (db *GoLevelDB) GetBucket(id BucketID) (Bucket, error) {
 	}, nil
 }
 
+func (db *GoLevelDB) NewBatch() Batch {
+	return &goLevelBatch{
+		db:    db.db,
+		batch: new(leveldb.Batch),
+	}
+}
+
 func (db *GoLevelDB) Close() error {
 	return db.db.Close()
 }
@@ -84,3 +94,107 @@ func (bucket *goLevelBucket) Set(key []byte, value []byte) error {
 func (bucket *goLevelBucket) Delete(key []byte) error {
 	return bucket.db.Delete(internalKey(bucket.id, key), nil)
 }
+
+func (bucket *goLevelBucket) NewIterator(r *Range) Iterator {
+	start, limit := r.internalRange(bucket.id)
+	iter := bucket.db.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+	return &goLevelIterator{
+		iter:    iter,
+		id:      bucket.id,
+		prefix:  len(bucket.id),
+		reverse: r.isReverse(),
+	}
+}
+
+//----------------------------------------
+// Batch
+
+var _ Batch = (*goLevelBatch)(nil)
+
+type goLevelBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+func (b *goLevelBatch) Set(id BucketID, key []byte, value []byte) error {
+	if value == nil {
+		return errors.New("IllegalArgument")
+	}
+	b.batch.Put(internalKey(id, key), value)
+	return nil
+}
+
+func (b *goLevelBatch) Delete(id BucketID, key []byte) error {
+	b.batch.Delete(internalKey(id, key))
+	return nil
+}
+
+func (b *goLevelBatch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *goLevelBatch) Reset() {
+	b.batch.Reset()
+}
+
+func (b *goLevelBatch) Write() error {
+	return b.db.Write(b.batch, nil)
+}
+
+//----------------------------------------
+// Iterator
+
+type goLevelIterator struct {
+	iter    iterator.Iterator
+	id      BucketID
+	prefix  int
+	reverse bool
+	started bool
+}
+
+// Next moves to the next entry of the bucket skipping the entries of other
+// buckets. See isForeignEntry.
+func (it *goLevelIterator) Next() bool {
+	for it.move() {
+		if !isForeignEntry(it.id, it.iter.Key(), it.iter.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *goLevelIterator) move() bool {
+	if !it.started {
+		it.started = true
+		if it.reverse {
+			return it.iter.Last()
+		}
+		return it.iter.First()
+	}
+	if it.reverse {
+		return it.iter.Prev()
+	}
+	return it.iter.Next()
+}
+
+func (it *goLevelIterator) Key() []byte {
+	if key := it.iter.Key(); key != nil {
+		return append([]byte{}, key[it.prefix:]...)
+	}
+	return nil
+}
+
+func (it *goLevelIterator) Value() []byte {
+	if value := it.iter.Value(); value != nil {
+		return append([]byte{}, value...)
+	}
+	return nil
+}
+
+func (it *goLevelIterator) Error() error {
+	return it.iter.Error()
+}
+
+func (it *goLevelIterator) Release() {
+	it.iter.Release()
+}
@@ -0,0 +1,285 @@
+package db
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+// Range specifies the keys of a bucket to be iterated. Start is inclusive
+// and Limit is exclusive. nil Start or Limit means that the range is not
+// bounded on that side. If Reverse is true, keys are returned in
+// descending order.
+type Range struct {
+	Start   []byte
+	Limit   []byte
+	Reverse bool
+}
+
+// BytesPrefix returns a range covering all keys with the given prefix.
+func BytesPrefix(prefix []byte) *Range {
+	return &Range{
+		Start: prefix,
+		Limit: prefixLimit(prefix),
+	}
+}
+
+// Contains returns whether the key is inside of the range.
+func (r *Range) Contains(key []byte) bool {
+	if r == nil {
+		return true
+	}
+	if r.Start != nil && bytes.Compare(key, r.Start) < 0 {
+		return false
+	}
+	if r.Limit != nil && bytes.Compare(key, r.Limit) >= 0 {
+		return false
+	}
+	return true
+}
+
+func (r *Range) isReverse() bool {
+	return r != nil && r.Reverse
+}
+
+// internalRange returns start and limit of the range in the keyspace
+// of the backend, where keys are prefixed with the bucket's id.
+// Returned limit is nil if the range is not bounded.
+func (r *Range) internalRange(id BucketID) ([]byte, []byte) {
+	var start, limit []byte
+	if r != nil && r.Start != nil {
+		start = internalKey(id, r.Start)
+	} else {
+		start = []byte(id)
+	}
+	if r != nil && r.Limit != nil {
+		limit = internalKey(id, r.Limit)
+	} else {
+		limit = prefixLimit([]byte(id))
+	}
+	return start, limit
+}
+
+// prefixLimit returns the smallest key which is greater than all keys
+// with the prefix. It returns nil if there is no such key.
+func prefixLimit(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit := make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			return limit
+		}
+	}
+	return nil
+}
+
+// merkleKeyLen is the length of sha3-256 hash, which is the key of
+// MerkleTrie.
+const merkleKeyLen = 32
+
+// isForeignEntry returns whether the entry of the internal key belongs to
+// another bucket. Backends keeping all buckets in one keyspace return the
+// entries of other buckets for a range of a bucket, because MerkleTrie has
+// the empty prefix. Keys of MerkleTrie are sha3(value), so the entry is a
+// node of MerkleTrie if it's true for the internal key.
+func isForeignEntry(id BucketID, ikey, value []byte) bool {
+	isNode := len(ikey) == merkleKeyLen &&
+		bytes.Equal(ikey, crypto.SHA3Sum256(value))
+	if id == MerkleTrie {
+		return !isNode
+	}
+	return isNode
+}
+
+// Iterator iterates over key value pairs of a bucket in the order of keys.
+// Next should be called before the first access to Key or Value.
+// Returned keys and values are owned by the caller.
+// Release should be called after use.
+type Iterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// sliceIterator iterates over pre-sorted entries.
+type sliceIterator struct {
+	keys   [][]byte
+	values [][]byte
+	index  int
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.index < len(it.keys) {
+		it.index++
+	}
+	return it.index < len(it.keys)
+}
+
+func (it *sliceIterator) valid() bool {
+	return it.index >= 0 && it.index < len(it.keys)
+}
+
+func (it *sliceIterator) Key() []byte {
+	if it.valid() {
+		return it.keys[it.index]
+	}
+	return nil
+}
+
+func (it *sliceIterator) Value() []byte {
+	if it.valid() {
+		return it.values[it.index]
+	}
+	return nil
+}
+
+func (it *sliceIterator) Error() error {
+	return nil
+}
+
+func (it *sliceIterator) Release() {
+	it.keys = nil
+	it.values = nil
+	it.index = 0
+}
+
+// newSliceIterator returns an iterator of entries in m contained by the
+// range. Values in m may be nil.
+func newSliceIterator(m map[string][]byte, r *Range) *sliceIterator {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if r.Contains([]byte(k)) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if r.isReverse() {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+	it := &sliceIterator{
+		keys:   make([][]byte, len(keys)),
+		values: make([][]byte, len(keys)),
+		index:  -1,
+	}
+	for i, k := range keys {
+		it.keys[i] = []byte(k)
+		if v := m[k]; v != nil {
+			it.values[i] = append([]byte{}, v...)
+		}
+	}
+	return it
+}
+
+// errorIterator is an empty iterator returning the error.
+type errorIterator struct {
+	err error
+}
+
+func (it *errorIterator) Next() bool {
+	return false
+}
+
+func (it *errorIterator) Key() []byte {
+	return nil
+}
+
+func (it *errorIterator) Value() []byte {
+	return nil
+}
+
+func (it *errorIterator) Error() error {
+	return it.err
+}
+
+func (it *errorIterator) Release() {
+}
+
+// NewErrorIterator returns an empty iterator returning the error.
+// It's used by buckets which can't support iteration.
+func NewErrorIterator(err error) Iterator {
+	return &errorIterator{err: err}
+}
+
+// mergedIterator iterates over the entries of upper overriding the entries
+// of lower. Entries of upper with nil value are regarded as deleted.
+type mergedIterator struct {
+	upper   *sliceIterator
+	lower   Iterator
+	reverse bool
+
+	upperValid bool
+	lowerValid bool
+	started    bool
+
+	key   []byte
+	value []byte
+}
+
+func (it *mergedIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		it.upperValid = it.upper.Next()
+		it.lowerValid = it.lower.Next()
+	}
+	for it.upperValid || it.lowerValid {
+		var c int
+		if !it.lowerValid {
+			c = -1
+		} else if !it.upperValid {
+			c = 1
+		} else {
+			c = bytes.Compare(it.upper.Key(), it.lower.Key())
+			if it.reverse {
+				c = -c
+			}
+		}
+		if c > 0 {
+			it.key, it.value = it.lower.Key(), it.lower.Value()
+			it.lowerValid = it.lower.Next()
+			return true
+		}
+		key, value := it.upper.Key(), it.upper.Value()
+		it.upperValid = it.upper.Next()
+		if c == 0 {
+			it.lowerValid = it.lower.Next()
+		}
+		if value != nil {
+			it.key, it.value = key, value
+			return true
+		}
+	}
+	it.key, it.value = nil, nil
+	return false
+}
+
+func (it *mergedIterator) Key() []byte {
+	return it.key
+}
+
+func (it *mergedIterator) Value() []byte {
+	return it.value
+}
+
+func (it *mergedIterator) Error() error {
+	return it.lower.Error()
+}
+
+func (it *mergedIterator) Release() {
+	it.upper.Release()
+	it.lower.Release()
+	it.key, it.value = nil, nil
+}
+
+func newMergedIterator(upper *sliceIterator, lower Iterator, r *Range) Iterator {
+	return &mergedIterator{
+		upper:   upper,
+		lower:   lower,
+		reverse: r.isReverse(),
+	}
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+)
+
+func collectEntries(t *testing.T, bk Bucket, r *Range) []string {
+	it := bk.NewIterator(r)
+	defer it.Release()
+
+	var entries []string
+	for it.Next() {
+		entries = append(entries, string(it.Key())+"="+string(it.Value()))
+	}
+	assert.NoError(t, it.Error())
+	return entries
+}
+
+func testBucketIterator(t *testing.T, dbase Database) {
+	bk, err := dbase.GetBucket("T")
+	assert.NoError(t, err)
+	other, err := dbase.GetBucket("U")
+	assert.NoError(t, err)
+
+	for _, k := range []string{"a1", "a2", "a3", "b1", "c1"} {
+		assert.NoError(t, bk.Set([]byte(k), []byte("v"+k)))
+	}
+	assert.NoError(t, other.Set([]byte("a0"), []byte("other")))
+
+	assert.Equal(t, []string{"a1=va1", "a2=va2", "a3=va3", "b1=vb1", "c1=vc1"},
+		collectEntries(t, bk, nil))
+	assert.Equal(t, []string{"a1=va1", "a2=va2", "a3=va3"},
+		collectEntries(t, bk, BytesPrefix([]byte("a"))))
+	assert.Equal(t, []string{"a2=va2", "a3=va3", "b1=vb1"},
+		collectEntries(t, bk, &Range{Start: []byte("a2"), Limit: []byte("c1")}))
+	assert.Equal(t, []string{"b1=vb1", "a3=va3", "a2=va2"},
+		collectEntries(t, bk, &Range{Start: []byte("a2"), Limit: []byte("c1"), Reverse: true}))
+	assert.Equal(t, []string{"c1=vc1", "b1=vb1", "a3=va3", "a2=va2", "a1=va1"},
+		collectEntries(t, bk, &Range{Reverse: true}))
+	assert.Equal(t, []string{"a0=other"},
+		collectEntries(t, other, nil))
+	assert.Empty(t, collectEntries(t, bk, BytesPrefix([]byte("d"))))
+
+	ldb := NewLayerDB(dbase)
+	lbk, err := ldb.GetBucket("T")
+	assert.NoError(t, err)
+	assert.NoError(t, lbk.Set([]byte("a0"), []byte("new")))
+	assert.NoError(t, lbk.Set([]byte("a2"), []byte("updated")))
+	assert.NoError(t, lbk.Delete([]byte("a3")))
+	assert.NoError(t, lbk.Set([]byte("d1"), []byte("new")))
+
+	assert.Equal(t, []string{"a0=new", "a1=va1", "a2=updated", "b1=vb1", "c1=vc1", "d1=new"},
+		collectEntries(t, lbk, nil))
+	assert.Equal(t, []string{"d1=new", "c1=vc1", "b1=vb1", "a2=updated", "a1=va1", "a0=new"},
+		collectEntries(t, lbk, &Range{Reverse: true}))
+	assert.Equal(t, []string{"a2=updated", "a1=va1", "a0=new"},
+		collectEntries(t, lbk, &Range{Limit: []byte("a3"), Reverse: true}))
+	assert.Equal(t, []string{"a1=va1", "a2=va2", "a3=va3", "b1=vb1", "c1=vc1"},
+		collectEntries(t, bk, nil))
+
+	assert.NoError(t, ldb.Flush(true))
+	assert.Equal(t, []string{"a0=new", "a1=va1", "a2=updated", "b1=vb1", "c1=vc1", "d1=new"},
+		collectEntries(t, bk, nil))
+}
+
+func TestBucket_Iterator(t *testing.T) {
+	for _, backend := range []BackendType{
+		BadgerDBBackend, GoLevelDBBackend, BoltDBBackend, MapDBBackend,
+	} {
+		t.Run(string(backend), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", string(backend))
+			if err != nil {
+				panic(err)
+			}
+			defer os.RemoveAll(dir)
+
+			testDB, err := openDatabase(backend, "test", dir)
+			assert.NoError(t, err)
+			defer testDB.Close()
+
+			testBucketIterator(t, testDB)
+		})
+	}
+}
+
+func TestProxyDB_Iterator(t *testing.T) {
+	pdb := NewProxyDB()
+	bk, _ := pdb.GetBucket("T")
+	it := bk.NewIterator(nil)
+	assert.False(t, it.Next())
+	assert.Error(t, it.Error())
+	it.Release()
+
+	mdb := NewMapDB()
+	real, _ := mdb.GetBucket("T")
+	real.Set([]byte("a"), []byte("b"))
+	assert.NoError(t, pdb.SetReal(mdb))
+	assert.Equal(t, []string{"a=b"}, collectEntries(t, bk, nil))
+}
+
+// nodesWithPrefix returns merkle trie nodes whose keys start with the id of
+// the bucket, so the keys are in the range of the bucket on the backends
+// keeping all buckets in one keyspace.
+func nodesWithPrefix(id BucketID, n int) [][]byte {
+	var nodes [][]byte
+	for i := 0; len(nodes) < n; i++ {
+		node := []byte(fmt.Sprintf("node%d", i))
+		if crypto.SHA3Sum256(node)[0] == id[0] {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func TestBucket_IteratorOfMerkleTrie(t *testing.T) {
+	for _, backend := range []BackendType{
+		BadgerDBBackend, GoLevelDBBackend, BoltDBBackend, MapDBBackend,
+	} {
+		t.Run(string(backend), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", string(backend))
+			if err != nil {
+				panic(err)
+			}
+			defer os.RemoveAll(dir)
+
+			testDB, err := openDatabase(backend, "test", dir)
+			assert.NoError(t, err)
+			defer testDB.Close()
+
+			mbk, err := testDB.GetBucket(MerkleTrie)
+			assert.NoError(t, err)
+			var nodes []string
+			for _, node := range nodesWithPrefix("T", 3) {
+				key := crypto.SHA3Sum256(node)
+				assert.NoError(t, mbk.Set(key, node))
+				nodes = append(nodes, string(key))
+			}
+			sort.Strings(nodes)
+
+			bk, err := testDB.GetBucket("T")
+			assert.NoError(t, err)
+			assert.NoError(t, bk.Set([]byte("a1"), []byte("va1")))
+			// an entry of the bucket having the same length as the keys of
+			// nodes is not regarded as a node.
+			key31 := bytes.Repeat([]byte{'k'}, 31)
+			assert.NoError(t, bk.Set(key31, []byte("v31")))
+
+			// only nodes are in MerkleTrie
+			var keys []string
+			it := mbk.NewIterator(nil)
+			for it.Next() {
+				keys = append(keys, string(it.Key()))
+			}
+			assert.NoError(t, it.Error())
+			it.Release()
+			assert.Equal(t, nodes, keys)
+
+			// nodes are not in other buckets, and reverse iteration skips
+			// them too.
+			assert.Equal(t, []string{"a1=va1", string(key31) + "=v31"},
+				collectEntries(t, bk, nil))
+			assert.Equal(t, []string{string(key31) + "=v31", "a1=va1"},
+				collectEntries(t, bk, &Range{Reverse: true}))
+
+			ldb := NewLayerDB(testDB)
+			lbk, err := ldb.GetBucket("T")
+			assert.NoError(t, err)
+			assert.NoError(t, lbk.Set([]byte("b1"), []byte("vb1")))
+			assert.Equal(t, []string{"a1=va1", "b1=vb1", string(key31) + "=v31"},
+				collectEntries(t, lbk, nil))
+		})
+	}
+}
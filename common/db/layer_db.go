@@ -7,6 +7,7 @@ import (
 
 type layerBucket struct {
 	lock sync.Mutex
+	id   BucketID
 	data map[string][]byte
 	real Bucket
 }
@@ -65,27 +66,44 @@ func (bk *layerBucket) Delete(key []byte) error {
 	}
 }
 
-func (bk *layerBucket) Flush(write bool) error {
+// NewIterator returns an iterator over the entries of the real bucket
+// merged with the pending writes.
+func (bk *layerBucket) NewIterator(r *Range) Iterator {
 	bk.lock.Lock()
 	defer bk.lock.Unlock()
 
-	if write && bk.data != nil {
-		for k, v := range bk.data {
-			if v == nil {
-				if err := bk.real.Delete([]byte(k)); err != nil {
-					return err
-				}
-			} else {
-				if err := bk.real.Set([]byte(k), v); err != nil {
-					return err
-				}
+	if bk.data == nil {
+		return bk.real.NewIterator(r)
+	}
+	return newMergedIterator(newSliceIterator(bk.data, r), bk.real.NewIterator(r), r)
+}
+
+// writeTo adds pending writes of the bucket to the batch.
+func (bk *layerBucket) writeTo(batch Batch) error {
+	bk.lock.Lock()
+	defer bk.lock.Unlock()
+
+	for k, v := range bk.data {
+		if v == nil {
+			if err := batch.Delete(bk.id, []byte(k)); err != nil {
+				return err
+			}
+		} else {
+			if err := batch.Set(bk.id, []byte(k), v); err != nil {
+				return err
 			}
 		}
 	}
-	bk.data = nil
 	return nil
 }
 
+func (bk *layerBucket) clear() {
+	bk.lock.Lock()
+	defer bk.lock.Unlock()
+
+	bk.data = nil
+}
+
 type layerDB struct {
 	lock sync.Mutex
 
@@ -110,6 +128,7 @@ func (ldb *layerDB) GetBucket(id BucketID) (Bucket, error) {
 		return realbk, nil
 	}
 	bk := &layerBucket{
+		id:   id,
 		data: make(map[string][]byte),
 		real: realbk,
 	}
@@ -117,15 +136,31 @@ func (ldb *layerDB) GetBucket(id BucketID) (Bucket, error) {
 	return bk, nil
 }
 
+// NewBatch returns a batch applying operations to the layer.
+func (ldb *layerDB) NewBatch() Batch {
+	return NewBucketBatch(ldb)
+}
+
+// Flush writes pending writes of all buckets to the real database
+// through a batch if write is true, then the layer is released.
 func (ldb *layerDB) Flush(write bool) error {
 	ldb.lock.Lock()
 	defer ldb.lock.Unlock()
 
-	for _, bk := range ldb.buckets {
-		if err := bk.Flush(write); err != nil {
+	if write {
+		batch := ldb.real.NewBatch()
+		for _, bk := range ldb.buckets {
+			if err := bk.writeTo(batch); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
 			return err
 		}
 	}
+	for _, bk := range ldb.buckets {
+		bk.clear()
+	}
 	ldb.flushed = true
 	return nil
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/icon-project/goloop/common/log"
@@ -45,21 +46,79 @@ func (t *mapDatabase) GetBucket(id BucketID) (Bucket, error) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
+	return t.getBucketInLock(id), nil
+}
+
+func (t *mapDatabase) getBucketInLock(id BucketID) *mapBucket {
 	if bk, ok := t.bks[id]; ok {
-		return bk, nil
+		return bk
 	}
 	bk := &mapBucket{
 		id:   fmt.Sprintf("%s:%s", t.name, id),
 		real: make(map[string]string),
 	}
 	t.bks[id] = bk
-	return bk, nil
+	return bk
+}
+
+func (t *mapDatabase) NewBatch() Batch {
+	return &mapBatch{database: t}
 }
 
 func (t *mapDatabase) Close() error {
 	return nil
 }
 
+//----------------------------------------
+// Batch
+
+var _ Batch = (*mapBatch)(nil)
+
+// mapBatch emulates atomic write by holding locks of all related buckets
+// while it applies the operations.
+type mapBatch struct {
+	batchOps
+	database *mapDatabase
+}
+
+func (b *mapBatch) Write() error {
+	for _, op := range b.ops {
+		if !op.isDelete() && len(op.key) == 0 {
+			return errors.Errorf("Illegal Key:%x", op.key)
+		}
+	}
+
+	b.database.lock.Lock()
+	buckets := make(map[BucketID]*mapBucket)
+	for _, op := range b.ops {
+		if _, ok := buckets[op.id]; !ok {
+			buckets[op.id] = b.database.getBucketInLock(op.id)
+		}
+	}
+	b.database.lock.Unlock()
+
+	ids := make([]string, 0, len(buckets))
+	for id := range buckets {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		bk := buckets[BucketID(id)]
+		bk.mutex.Lock()
+		defer bk.mutex.Unlock()
+	}
+
+	for _, op := range b.ops {
+		bk := buckets[op.id]
+		if op.isDelete() {
+			delete(bk.real, string(op.key))
+		} else {
+			bk.real[string(op.key)] = string(op.value)
+		}
+	}
+	return nil
+}
+
 //----------------------------------------
 // Bucket
 
@@ -120,3 +179,15 @@ func (t *mapBucket) Delete(k []byte) error {
 	delete(t.real, string(k))
 	return nil
 }
+
+func (t *mapBucket) NewIterator(r *Range) Iterator {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	entries := make(map[string][]byte)
+	for k, v := range t.real {
+		if r.Contains([]byte(k)) {
+			entries[k] = []byte(v)
+		}
+	}
+	return newSliceIterator(entries, r)
+}
@@ -1,5 +1,7 @@
 package db
 
+import "github.com/pkg/errors"
+
 type nullDB struct {
 }
 
@@ -7,6 +9,10 @@ func (*nullDB) GetBucket(id BucketID) (Bucket, error) {
 	return &nullBucket{}, nil
 }
 
+func (*nullDB) NewBatch() Batch {
+	return NewErrorBatch(errors.New("NullDB.NewBatch() Unsupported"))
+}
+
 func (*nullDB) Close() error {
 	return nil
 }
@@ -30,6 +36,10 @@ func (*nullBucket) Delete(key []byte) error {
 	panic("NullBucket.Delete() Unsupported")
 }
 
+func (*nullBucket) NewIterator(r *Range) Iterator {
+	return newSliceIterator(nil, r)
+}
+
 func NewNullDB() *nullDB {
 	return &nullDB{}
 }
@@ -36,6 +36,39 @@ func (bk *proxyBucket) Delete(key []byte) error {
 	return errors.New("ProxyIsNotRealized")
 }
 
+func (bk *proxyBucket) NewIterator(r *Range) Iterator {
+	if bk.real != nil {
+		return bk.real.NewIterator(r)
+	}
+	return NewErrorIterator(errors.New("ProxyIsNotRealized"))
+}
+
+// proxyBatch collects operations until Write, then it writes them
+// through a batch of the real database.
+type proxyBatch struct {
+	batchOps
+	database *proxyDB
+}
+
+func (b *proxyBatch) Write() error {
+	if b.database.real == nil {
+		return errors.New("ProxyIsNotRealized")
+	}
+	batch := b.database.real.NewBatch()
+	for _, op := range b.ops {
+		var err error
+		if op.isDelete() {
+			err = batch.Delete(op.id, op.key)
+		} else {
+			err = batch.Set(op.id, op.key, op.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
 type proxyDB struct {
 	real    Database
 	buckets map[string]*proxyBucket
@@ -57,6 +90,10 @@ func (pdb *proxyDB) GetBucket(id BucketID) (Bucket, error) {
 	return bk, nil
 }
 
+func (pdb *proxyDB) NewBatch() Batch {
+	return &proxyBatch{database: pdb}
+}
+
 func (pdb *proxyDB) Close() error {
 	return nil
 }
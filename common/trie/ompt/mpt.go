@@ -515,6 +515,8 @@ func NewMPT(d db.Database, h []byte, t reflect.Type) *mpt {
 
 func MPTFromImmutable(immutable trie.ImmutableForObject) *mpt {
 	if m, ok := immutable.(*mpt); ok {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
 		return &mpt{
 			mptBase: m.mptBase,
 			root:    m.root,
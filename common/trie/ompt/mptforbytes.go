@@ -5,6 +5,7 @@ import (
 
 	"github.com/icon-project/goloop/common/merkle"
 
+	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/trie"
 )
@@ -61,6 +62,9 @@ func (m *mptForBytes) Prove(k []byte, proof [][]byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if obj == nil {
+		return nil, common.ErrNotFound
+	}
 	return obj.Bytes(), nil
 }
 
@@ -206,6 +206,14 @@ func (h *sconHandler) updateCurrentTask() {
 	h.requestID = ni.RequestID
 	blk, err := h.bm.GetBlockByHeight(ni.Height)
 	nblk, err2 := h.bm.GetBlockByHeight(ni.Height + 1)
+	var buf *bytes.Buffer
+	if err == nil && err2 == nil {
+		// The body is not available if the block is pruned.
+		buf = bytes.NewBuffer(nil)
+		if err = blk.MarshalHeader(buf); err == nil {
+			err = blk.MarshalBody(buf)
+		}
+	}
 	if err != nil || err2 != nil {
 		h.nextMsgPI = protoBlockMetadata
 		h.nextMsg = codec.MustMarshalToBytes(&BlockMetadata{
@@ -216,9 +224,7 @@ func (h *sconHandler) updateCurrentTask() {
 		h.buf = nil
 		return
 	}
-	h.buf = bytes.NewBuffer(nil)
-	blk.MarshalHeader(h.buf)
-	blk.MarshalBody(h.buf)
+	h.buf = buf
 	h.nextMsgPI = protoBlockMetadata
 	h.nextMsg = codec.MustMarshalToBytes(&BlockMetadata{
 		RequestID:   ni.RequestID,
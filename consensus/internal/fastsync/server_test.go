@@ -2,6 +2,7 @@ package fastsync
 
 import (
 	"crypto/rand"
+	"io"
 	"testing"
 
 	"github.com/icon-project/goloop/common/log"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
 )
 
@@ -83,7 +85,27 @@ func TestServer_Success(t *testing.T) {
 	assert.Equal(t, data, s.rawBlocks[0])
 }
 
+type tPrunedBlock struct {
+	*tBlock
+}
+
+func (b *tPrunedBlock) MarshalBody(w io.Writer) error {
+	return errors.NotFoundError.New("PrunedBlock")
+}
+
 func TestServer_Fail(t *testing.T) {
+	s := newServerTestSetUp(t)
+	s.sendBlockRequest(s.ph2, 0, tNumBlocks)
+	ev := <-s.r2.ch
+	md := &BlockMetadata{0, -1, nil}
+	s.assertEqualReceiveEvent(protoBlockMetadata, md, s.nm.ID, ev)
+
+	// the body of a pruned block is not served
+	s.bm.SetBlock(2, &tPrunedBlock{s.blks[2].(*tBlock)})
+	s.sendBlockRequest(s.ph2, 1, 2)
+	ev = <-s.r2.ch
+	md = &BlockMetadata{1, -1, nil}
+	s.assertEqualReceiveEvent(protoBlockMetadata, md, s.nm.ID, ev)
 }
 
 func TestServer_Queue(t *testing.T) {
@@ -24,7 +24,12 @@ type Chain interface {
 	ConcurrencyLevel() int
 	NormalTxPoolSize() int
 	PatchTxPoolSize() int
+	SenderTxPoolSize() int
+	SenderTxBytes() int
+	PeerTxRate() int
 	MaxBlockTxBytes() int
+	EventLogIndex() bool
+	TxOrdering() string
 	DefaultWaitTimeout() time.Duration
 	MaxWaitTimeout() time.Duration
 	Genesis() []byte
@@ -44,6 +49,10 @@ type Chain interface {
 	Import(src string, height int64) error
 	Prune(gs string, dbt string, height int64) error
 	Backup(file string, extra []string) error
+	BackupLive(file string, extra []string) error
+	BackupIncremental(file string, base int64, hash []byte) error
+	ExportArchive(file string, from, to int64) error
+	ImportArchive(src string) error
 	Term() error
 	State() (string, int64, error)
 	IsStarted() bool
@@ -51,6 +60,7 @@ type Chain interface {
 
 	Reset() error
 	Verify() error
+	Reindex() error
 
 	MetricContext() context.Context
 	Logger() log.Logger
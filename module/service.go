@@ -3,6 +3,7 @@ package module
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/icon-project/goloop/common/db"
 )
@@ -104,13 +105,14 @@ const (
 	Revision6
 	Revision7
 	Revision8
+	Revision9
 	RevisionReserved
 )
 
 const (
 	DefaultRevision = Revision4
 	MaxRevision     = RevisionReserved - 1
-	LatestRevision  = Revision8
+	LatestRevision  = Revision9
 )
 
 func (s Status) String() string {
@@ -290,6 +292,29 @@ type TransitionManager interface {
 	WaitForTransaction(parent Transition, bi BlockInfo, cb func()) bool
 }
 
+// TransactionMonitor is notified when a transaction is added to the
+// transaction pool or dropped from the pool without being included in a
+// block. It's called with the pool locked, so it shouldn't block.
+type TransactionMonitor interface {
+	OnTransactionAdded(tx Transaction)
+	OnTransactionDropped(tx Transaction, reason error)
+}
+
+// TransactionPoolStatus is a summary of the transaction pool.
+type TransactionPoolStatus struct {
+	Size            int
+	Used            int
+	OldestTimestamp int64
+	Senders         map[string]int
+}
+
+// TransactionDrop describes why and when a transaction was dropped from
+// the transaction pool.
+type TransactionDrop struct {
+	Reason error
+	Time   time.Time
+}
+
 type ServiceManager interface {
 	TransitionManager
 
@@ -331,6 +356,10 @@ type ServiceManager interface {
 	// GetBalance returns balance of the account
 	GetBalance(result []byte, addr Address) (*big.Int, error)
 
+	// GetNonce returns the nonce expected for the next transaction of
+	// the account
+	GetNonce(result []byte, addr Address) (*big.Int, error)
+
 	// GetTotalSupply returns total supplied coin
 	GetTotalSupply(result []byte) (*big.Int, error)
 
@@ -343,6 +372,13 @@ type ServiceManager interface {
 	// GetAPIInfo returns API info of the contract
 	GetAPIInfo(result []byte, addr Address) (APIInfo, error)
 
+	// GetProofForAccount returns merkle proof of the account in the state
+	GetProofForAccount(result []byte, addr Address) ([][]byte, error)
+
+	// GetProofForStorage returns merkle proof of the value for the key
+	// in the storage of the account
+	GetProofForStorage(result []byte, addr Address, key []byte) ([][]byte, error)
+
 	// GetMembers returns network member list
 	GetMembers(result []byte) (MemberList, error)
 
@@ -361,10 +397,45 @@ type ServiceManager interface {
 	// WaitTransactionResult return channel for result.
 	WaitTransactionResult(id []byte) (<-chan interface{}, error)
 
+	// AddTransactionMonitor registers the monitor of transactions
+	// entering or dropped from the transaction pools
+	AddTransactionMonitor(tm TransactionMonitor)
+
+	// RemoveTransactionMonitor unregisters the monitor
+	RemoveTransactionMonitor(tm TransactionMonitor)
+
+	// GetPoolTransactions returns transactions in the pool of the group
+	// starting at offset. It returns at most limit transactions and the
+	// number of all transactions in the pool.
+	GetPoolTransactions(g TransactionGroup, offset, limit int) ([]Transaction, int)
+
+	// GetPoolStatus returns the status of the pool of the group.
+	GetPoolStatus(g TransactionGroup) *TransactionPoolStatus
+
+	// GetTransactionDrop returns the reason of the transaction recently
+	// dropped from the pool.
+	GetTransactionDrop(id []byte) (*TransactionDrop, error)
+
+	// EvictTransactions removes the transactions from the pools.
+	// It returns the ids of the removed transactions.
+	EvictTransactions(ids [][]byte) [][]byte
+
 	// ExportResult exports all related entries related with the result
 	// should be exported to the database
 	ExportResult(result []byte, vh []byte, dst db.Database) error
 
+	// ExportWorldState exports entries of the world state of the result
+	// to the database. Receipts and validators are not exported.
+	ExportWorldState(result []byte, dst db.Database) error
+
+	// ExportReceipts exports entries of the receipts of the result
+	// to the database.
+	ExportReceipts(result []byte, dst db.Database) error
+
+	// ExportTransactions exports entries of the transaction list of the hash
+	// to the database.
+	ExportTransactions(hash []byte, dst db.Database) error
+
 	// ImportResult imports all related entries related with the result
 	// should be imported from the database
 	ImportResult(result []byte, vh []byte, src db.Database) error
@@ -373,6 +444,26 @@ type ServiceManager interface {
 	// Then it returns the expected result of the transaction.
 	// It ignores supplied step limit.
 	ExecuteTransaction(result []byte, vh []byte, js []byte, bi BlockInfo) (Receipt, error)
+
+	// ExecuteTransactionWithDiff is same as ExecuteTransaction, but it also
+	// returns changes of the state made by the transaction.
+	ExecuteTransactionWithDiff(result []byte, vh []byte, js []byte, bi BlockInfo) (Receipt, StateDiff, error)
+
+	// SimulateTransactions executes the transactions in the order on the
+	// specified state after applying overrides. Changes of the state are
+	// discarded. It returns receipts of the transactions.
+	SimulateTransactions(result []byte, vh []byte, txs [][]byte, overrides []*AccountOverride, bi BlockInfo) ([]Receipt, error)
+}
+
+// AccountOverride is a change of the account applied to the state before
+// simulation. Nil fields are not changed. Nil value in Storage deletes the
+// key. Code replaces the code of the contract keeping its storage.
+type AccountOverride struct {
+	Address     Address
+	Balance     *big.Int
+	Storage     map[string][]byte
+	ContentType string
+	Code        []byte
 }
 
 type TraceInfo struct {
@@ -393,3 +484,27 @@ type TraceCallback interface {
 	OnLog(level TraceLevel, msg string)
 	OnEnd(e error)
 }
+
+// TraceFrameCallback may be implemented by TraceCallback to receive
+// structured notifications of the execution in addition to the logs.
+// Each OnFrameEnter is paired with OnFrameExit, and frames of inter-SCORE
+// calls are nested between them. Params and result are values which can
+// be marshaled into JSON.
+type TraceFrameCallback interface {
+	OnFrameEnter(from, to Address, value, limit *big.Int, method string, params interface{})
+	OnFrameExit(status error, stepUsed *big.Int, result interface{})
+	OnEvent(addr Address, indexed, data [][]byte)
+	OnTransfer(from, to Address, value *big.Int)
+}
+
+// StateDiff is changes of the state made by a transaction.
+type StateDiff interface {
+	ToJSON(version JSONVersion) (interface{}, error)
+}
+
+// TraceStateCallback may be implemented by TraceCallback to receive
+// changes of the state made by the traced transaction. OnStateDiff is
+// called before OnEnd if the transaction is executed.
+type TraceStateCallback interface {
+	OnStateDiff(diff StateDiff)
+}
@@ -419,8 +419,17 @@ func (n *Node) JoinChain(
 		ConcurrencyLevel: p.ConcurrencyLevel,
 		NormalTxPoolSize: p.NormalTxPoolSize,
 		PatchTxPoolSize:  p.PatchTxPoolSize,
+		SenderTxPoolSize: p.SenderTxPoolSize,
+		SenderTxBytes:    p.SenderTxBytes,
+		PeerTxRate:       p.PeerTxRate,
 		MaxBlockTxBytes:  p.MaxBlockTxBytes,
+		StateGCKeep:      p.StateGCKeep,
+		StateGCRate:      p.StateGCRate,
+		BlockKeep:        p.BlockKeep,
+		BlockKeepDays:    p.BlockKeepDays,
 		NodeCache:        p.NodeCache,
+		EventLogIndex:    p.EventLogIndex,
+		TxOrdering:       p.TxOrdering,
 		DefWaitTimeout:   p.DefWaitTimeout,
 		MaxWaitTimeout:   p.MaxWaitTimeout,
 		AutoStart:        p.AutoStart,
@@ -505,6 +514,17 @@ func (n *Node) ResetChain(cid int) error {
 	return c.Reset()
 }
 
+func (n *Node) ReindexChain(cid int) error {
+	defer n.mtx.RUnlock()
+	n.mtx.RLock()
+
+	c, err := n._get(cid)
+	if err != nil {
+		return err
+	}
+	return c.Reindex()
+}
+
 func (n *Node) VerifyChain(cid int) error {
 	defer n.mtx.RUnlock()
 	n.mtx.RLock()
@@ -540,7 +560,7 @@ func (n *Node) PruneChain(cid int, dbt string, height int64) error {
 	return c.Prune(gs, dbt, height)
 }
 
-func (n *Node) BackupChain(cid int) (string, error) {
+func (n *Node) BackupChain(cid int, live bool, base string) (string, error) {
 	defer n.mtx.RUnlock()
 	n.mtx.RLock()
 
@@ -558,7 +578,28 @@ func (n *Node) BackupChain(cid int) (string, error) {
 	name := fmt.Sprintf("%#x_%#x_%s_%s.zip", c.CID(), c.NID(), c.Channel(),
 		now.Format("20060102-150405"))
 	file := path.Join(backupDir, name)
-	return name, c.Backup(file, []string{ChainGenesisZipFileName, ChainConfigFileName})
+	if base != "" {
+		info, err := chain.GetBackupInfoOf(path.Join(backupDir, base))
+		if err != nil {
+			return "", errors.IllegalArgumentError.Wrapf(err,
+				"InvalidBaseBackup(name=%s)", base)
+		}
+		if int(info.CID.Value) != c.CID() || int(info.NID.Value) != c.NID() {
+			return "", errors.IllegalArgumentError.Errorf(
+				"DifferentChain(name=%s,cid=%#x,nid=%#x)",
+				base, info.CID.Value, info.NID.Value)
+		}
+		if len(info.Hash) == 0 {
+			return "", errors.IllegalArgumentError.Errorf(
+				"NoBlockHash(name=%s)", base)
+		}
+		return name, c.BackupIncremental(file, info.Height, info.Hash)
+	}
+	extra := []string{ChainGenesisZipFileName, ChainConfigFileName}
+	if live {
+		return name, c.BackupLive(file, extra)
+	}
+	return name, c.Backup(file, extra)
 }
 
 type BackupInfo struct {
@@ -567,6 +608,46 @@ type BackupInfo struct {
 	chain.BackupInfo
 }
 
+// ExportChain exports the blocks of the chain into a block archive in the
+// backup directory, and returns the name of the archive.
+func (n *Node) ExportChain(cid int, from, to int64) (string, error) {
+	defer n.mtx.RUnlock()
+	n.mtx.RLock()
+
+	c, err := n._get(cid)
+	if err != nil {
+		return "", err
+	}
+
+	backupDir := n.cfg.ResolveAbsolute(n.cfg.BackupDir)
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		return "", errors.InvalidStateError.Wrapf(err,
+			"Fail to make backup directory=%s", backupDir)
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%#x_%#x_%s_%s.blocks", c.CID(), c.NID(), c.Channel(),
+		now.Format("20060102-150405"))
+	file := path.Join(backupDir, name)
+	return name, c.ExportArchive(file, from, to)
+}
+
+// ImportChainArchive imports the blocks in the block archive. src is a URL
+// (http or https), an absolute path or a name in the backup directory.
+func (n *Node) ImportChainArchive(cid int, src string) error {
+	defer n.mtx.RUnlock()
+	n.mtx.RLock()
+
+	c, err := n._get(cid)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(src, "http://") &&
+		!strings.HasPrefix(src, "https://") && !path.IsAbs(src) {
+		src = path.Join(n.cfg.ResolveAbsolute(n.cfg.BackupDir), src)
+	}
+	return c.ImportArchive(src)
+}
+
 func (n *Node) GetBackups() ([]BackupInfo, error) {
 	n.mtx.Lock()
 	defer n.mtx.Unlock()
@@ -597,14 +678,16 @@ func (n *Node) GetBackups() ([]BackupInfo, error) {
 }
 
 type RestoreView struct {
-	State     string `json:"state"`
-	Name      string `json:"name,omitempty"`
-	Overwrite bool   `json:"overwrite,omitempty"`
-	Error     string `json:"error,omitempty"`
+	State      string   `json:"state"`
+	Name       string   `json:"name,omitempty"`
+	Increments []string `json:"increments,omitempty"`
+	Overwrite  bool     `json:"overwrite,omitempty"`
+	Error      string   `json:"error,omitempty"`
 }
 
-// StartRestore start to restore chain.
-func (n *Node) StartRestore(name string, overwrite bool) (ret error) {
+// StartRestore start to restore chain. increments are the names of
+// incremental backups to be applied after the backup in order.
+func (n *Node) StartRestore(name string, increments []string, overwrite bool) (ret error) {
 	baseDir, backupDir := func() (string, string) {
 		n.mtx.Lock()
 		defer n.mtx.Unlock()
@@ -614,8 +697,12 @@ func (n *Node) StartRestore(name string, overwrite bool) (ret error) {
 	}()
 
 	backupFile := path.Join(backupDir, name)
+	incFiles := make([]string, len(increments))
+	for i, inc := range increments {
+		incFiles[i] = path.Join(backupDir, inc)
+	}
 
-	return n.rsm.Start(n, backupFile, baseDir, overwrite)
+	return n.rsm.Start(n, backupFile, incFiles, baseDir, overwrite)
 }
 
 // GetRestore returns state of latest restore operations.
@@ -626,11 +713,16 @@ func (n *Node) GetRestore() *RestoreView {
 			State: "stopped",
 		}
 	}
+	var increments []string
+	for _, inc := range status.Increments {
+		increments = append(increments, path.Base(inc))
+	}
 	return &RestoreView{
-		State:     status.State,
-		Name:      path.Base(status.File),
-		Overwrite: status.Overwrite,
-		Error:     errors.ToString(status.Error),
+		State:      status.State,
+		Name:       path.Base(status.File),
+		Increments: increments,
+		Overwrite:  status.Overwrite,
+		Error:      errors.ToString(status.Error),
 	}
 }
 
@@ -695,6 +787,48 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 			} else {
 				c.cfg.PatchTxPoolSize = intVal
 			}
+		case "senderTxPool":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.SenderTxPoolSize = intVal
+			}
+		case "senderTxBytes":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.SenderTxBytes = intVal
+			}
+		case "peerTxRate":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.PeerTxRate = intVal
+			}
+		case "stateGCKeep":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.StateGCKeep = intVal
+			}
+		case "stateGCRate":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.StateGCRate = intVal
+			}
+		case "blockKeep":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.BlockKeep = intVal
+			}
+		case "blockKeepDays":
+			if intVal, err := strconv.Atoi(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.BlockKeepDays = intVal
+			}
 		case "maxBlockTxBytes":
 			if intVal, err := strconv.Atoi(value); err != nil {
 				return errors.Wrapf(err, "invalid value type")
@@ -706,6 +840,17 @@ func (n *Node) ConfigureChain(cid int, key string, value string) error {
 				return errors.Errorf("InvalidNodeCacheOption(%s)", value)
 			}
 			c.cfg.NodeCache = value
+		case "eventLogIndex":
+			if b, err := strconv.ParseBool(value); err != nil {
+				return errors.Wrapf(err, "invalid value type")
+			} else {
+				c.cfg.EventLogIndex = b
+			}
+		case "txOrdering":
+			if !chain.IsTxOrderingOption(value) {
+				return errors.Errorf("InvalidTxOrderingOption(%s)", value)
+			}
+			c.cfg.TxOrdering = value
 		case "defaultWaitTimeout":
 			if intVal, err := strconv.ParseInt(value, 0, 64); err != nil {
 				return errors.Wrapf(err, "invalid value type")
@@ -844,6 +989,15 @@ func (n *Node) Configure(key string, value string) error {
 			n.rcfg.RPCIncludeDebug = boolVal
 		}
 		n.srv.SetIncludeDebug(n.rcfg.RPCIncludeDebug)
+	case "rpcBatchLimit":
+		if intVal, err := strconv.Atoi(value); err != nil {
+			return errors.Wrapf(err, "invalid value type")
+		} else if intVal < 0 {
+			return errors.Errorf("negative value")
+		} else {
+			n.rcfg.RPCBatchLimit = intVal
+		}
+		n.srv.SetBatchLimit(n.rcfg.RPCBatchLimit)
 	default:
 		return errors.Errorf("not found key")
 	}
@@ -876,6 +1030,7 @@ func NewNode(
 		_ = nt.SetListenAddress(cfg.P2PListenAddr)
 	}
 	srv := server.NewManager(cfg.RPCAddr, cfg.RPCDump, rcfg.RPCIncludeDebug, rcfg.RPCDefaultChannel, w, l)
+	srv.SetBatchLimit(rcfg.RPCBatchLimit)
 
 	ee, err := eeproxy.AllocEngines(l, strings.Split(cfg.Engines, ",")...)
 	if err != nil {
@@ -83,8 +83,17 @@ type ChainConfig struct {
 	ConcurrencyLevel int    `json:"concurrencyLevel,omitempty"`
 	NormalTxPoolSize int    `json:"normalTxPool,omitempty"`
 	PatchTxPoolSize  int    `json:"patchTxPool,omitempty"`
+	SenderTxPoolSize int    `json:"senderTxPool,omitempty"`
+	SenderTxBytes    int    `json:"senderTxBytes,omitempty"`
+	PeerTxRate       int    `json:"peerTxRate,omitempty"`
 	MaxBlockTxBytes  int    `json:"maxBlockTxBytes,omitempty"`
+	StateGCKeep      int    `json:"stateGCKeep,omitempty"`
+	StateGCRate      int    `json:"stateGCRate,omitempty"`
+	BlockKeep        int    `json:"blockKeep,omitempty"`
+	BlockKeepDays    int    `json:"blockKeepDays,omitempty"`
 	NodeCache        string `json:"nodeCache,omitempty"`
+	EventLogIndex    bool   `json:"eventLogIndex,omitempty"`
+	TxOrdering       string `json:"txOrdering,omitempty"`
 	Channel          string `json:"channel"`
 	SecureSuites     string `json:"secureSuites"`
 	SecureAeads      string `json:"secureAeads"`
@@ -103,14 +112,29 @@ type ChainPruneParam struct {
 	Height int64  `json:"height"`
 }
 
+type ChainBackupParam struct {
+	Live bool   `json:"live,omitempty"`
+	Base string `json:"base,omitempty"`
+}
+
+type ChainExportParam struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to,omitempty"`
+}
+
+type ChainImportArchiveParam struct {
+	Src string `json:"src"`
+}
+
 type ConfigureParam struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
 
 type RestoreBackupParam struct {
-	Name      string `json:"name"`
-	Overwrite bool   `json:"overwrite"`
+	Name       string   `json:"name"`
+	Increments []string `json:"increments,omitempty"`
+	Overwrite  bool     `json:"overwrite"`
 }
 
 func NewChainView(c *Chain) *ChainView {
@@ -151,8 +175,17 @@ func NewChainConfig(cfg *chain.Config) *ChainConfig {
 		ConcurrencyLevel: cfg.ConcurrencyLevel,
 		NormalTxPoolSize: cfg.NormalTxPoolSize,
 		PatchTxPoolSize:  cfg.PatchTxPoolSize,
+		SenderTxPoolSize: cfg.SenderTxPoolSize,
+		SenderTxBytes:    cfg.SenderTxBytes,
+		PeerTxRate:       cfg.PeerTxRate,
 		MaxBlockTxBytes:  cfg.MaxBlockTxBytes,
+		StateGCKeep:      cfg.StateGCKeep,
+		StateGCRate:      cfg.StateGCRate,
+		BlockKeep:        cfg.BlockKeep,
+		BlockKeepDays:    cfg.BlockKeepDays,
 		NodeCache:        cfg.NodeCache,
+		EventLogIndex:    cfg.EventLogIndex,
+		TxOrdering:       cfg.TxOrdering,
 		Channel:          cfg.Channel,
 		SecureSuites:     cfg.SecureSuites,
 		SecureAeads:      cfg.SecureAeads,
@@ -204,6 +237,9 @@ func (r *Rest) RegisterChainHandlers(g *echo.Group) {
 	g.POST(UrlChainRes+"/import", r.ImportChain, r.ChainInjector)
 	g.POST(UrlChainRes+"/prune", r.PruneChain, r.ChainInjector)
 	g.POST(UrlChainRes+"/backup", r.BackupChain, r.ChainInjector)
+	g.POST(UrlChainRes+"/export", r.ExportChain, r.ChainInjector)
+	g.POST(UrlChainRes+"/import_archive", r.ImportChainArchive, r.ChainInjector)
+	g.POST(UrlChainRes+"/reindex", r.ReindexChain, r.ChainInjector)
 	route := g.GET(UrlChainRes+"/genesis", r.GetChainGenesis, r.ChainInjector)
 	if r.a != nil {
 		r.a.SetSkip(route, false)
@@ -376,13 +412,58 @@ func (r *Rest) PruneChain(ctx echo.Context) error {
 
 func (r *Rest) BackupChain(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
-	if name, err := r.n.BackupChain(c.CID()); err != nil {
+	param := &ChainBackupParam{}
+	if ctx.Request().ContentLength != 0 {
+		if err := ctx.Bind(param); err != nil {
+			return echo.ErrBadRequest
+		}
+	}
+	if name, err := r.n.BackupChain(c.CID(), param.Live, param.Base); err != nil {
 		return err
 	} else {
 		return ctx.String(http.StatusOK, name)
 	}
 }
 
+func (r *Rest) ExportChain(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	param := &ChainExportParam{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if param.From < 0 || (param.To > 0 && param.To < param.From) {
+		return echo.ErrBadRequest
+	}
+	if name, err := r.n.ExportChain(c.CID(), param.From, param.To); err != nil {
+		return err
+	} else {
+		return ctx.String(http.StatusOK, name)
+	}
+}
+
+func (r *Rest) ImportChainArchive(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	param := &ChainImportArchiveParam{}
+	if err := ctx.Bind(param); err != nil {
+		return echo.ErrBadRequest
+	}
+	if param.Src == "" {
+		return echo.ErrBadRequest
+	}
+	if err := r.n.ImportChainArchive(c.CID(), param.Src); err != nil {
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
+func (r *Rest) ReindexChain(ctx echo.Context) error {
+	c := ctx.Get("chain").(*Chain)
+	if err := r.n.ReindexChain(c.CID()); err != nil {
+		return err
+	}
+	return ctx.String(http.StatusOK, "OK")
+}
+
 func (r *Rest) GetChainGenesis(ctx echo.Context) error {
 	c := ctx.Get("chain").(*Chain)
 	gsFile := path.Join(c.cfg.AbsBaseDir(), ChainGenesisZipFileName)
@@ -477,7 +558,7 @@ func (r *Rest) RestoreBackup(ctx echo.Context) error {
 	if err := ctx.Bind(param); err != nil {
 		return err
 	}
-	if err := r.n.StartRestore(param.Name, param.Overwrite); err != nil {
+	if err := r.n.StartRestore(param.Name, param.Increments, param.Overwrite); err != nil {
 		return err
 	}
 	return ctx.String(http.StatusOK, "OK")
@@ -18,6 +18,7 @@ package node
 
 import (
 	"archive/zip"
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -62,17 +63,19 @@ func (s RestoreState) String() string {
 }
 
 type RestoreStatus struct {
-	File      string
-	Overwrite bool
-	State     string
-	Error     error
+	File       string
+	Increments []string
+	Overwrite  bool
+	State      string
+	Error      error
 }
 
 type RestoreManager struct {
-	lock      sync.Mutex
-	file      string
-	channel   string
-	overwrite bool
+	lock       sync.Mutex
+	file       string
+	increments []string
+	channel    string
+	overwrite  bool
 
 	state   RestoreState
 	current int
@@ -80,7 +83,51 @@ type RestoreManager struct {
 	lastErr error
 }
 
-func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrite bool) (ret error) {
+// restoreSource is an incremental backup to be applied after the base.
+type restoreSource struct {
+	zr   *zip.ReadCloser
+	info *chain.BackupInfo
+}
+
+func openBackup(file string) (*zip.ReadCloser, *chain.BackupInfo, error) {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, nil, errors.IllegalArgumentError.Wrapf(err,
+			"ZipOpenFailure(backup=%s)", file)
+	}
+	info, err := chain.ReadBackupInfo(&zr.Reader)
+	if err != nil {
+		zr.Close()
+		return nil, nil, errors.IllegalArgumentError.Wrap(err,
+			"InvalidBackupInfo")
+	}
+	return zr, info, nil
+}
+
+// checkIncrement checks whether the increment can be applied to the backup
+// at prev.
+func checkIncrement(prev, info *chain.BackupInfo, file string) error {
+	if !info.IsIncremental() {
+		return errors.IllegalArgumentError.Errorf(
+			"NotIncrementalBackup(backup=%s)", path.Base(file))
+	}
+	if info.NID != prev.NID || info.CID != prev.CID || info.Channel != prev.Channel {
+		return errors.IllegalArgumentError.Errorf(
+			"DifferentChain(backup=%s)", path.Base(file))
+	}
+	if info.Codec != prev.Codec {
+		return errors.IllegalArgumentError.Errorf(
+			"IncompatibleCodec(backup=%s,codec=%s)", path.Base(file), info.Codec)
+	}
+	if info.Base != prev.Height || !bytes.Equal(info.BaseHash, prev.Hash) {
+		return errors.IllegalArgumentError.Errorf(
+			"Discontinuous(backup=%s,base=%d,baseHash=%#x,height=%d,hash=%#x)",
+			path.Base(file), info.Base, info.BaseHash, prev.Height, prev.Hash)
+	}
+	return nil
+}
+
+func (m *RestoreManager) Start(node *Node, file string, increments []string, baseDir string, overwrite bool) (ret error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -103,10 +150,9 @@ func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrit
 		}
 	}()
 
-	zr, err := zip.OpenReader(file)
+	zr, info, err := openBackup(file)
 	if err != nil {
-		return errors.IllegalArgumentError.Wrapf(err,
-			"ZipOpenFailure(backup=%s)", file)
+		return err
 	}
 	defer func() {
 		if ret != nil {
@@ -114,10 +160,9 @@ func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrit
 		}
 	}()
 
-	info, err := chain.ReadBackupInfo(&zr.Reader)
-	if err != nil {
-		return errors.IllegalArgumentError.Wrap(err,
-			"InvalidBackupInfo")
+	if info.IsIncremental() {
+		return errors.IllegalArgumentError.Errorf(
+			"IncrementalBackupAsBase(backup=%s)", path.Base(file))
 	}
 
 	if info.Codec != codec.BC.Name() {
@@ -130,8 +175,31 @@ func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrit
 		return err
 	}
 
+	total := len(zr.File)
+	srcs := make([]restoreSource, 0, len(increments))
+	defer func() {
+		if ret != nil {
+			for _, src := range srcs {
+				src.zr.Close()
+			}
+		}
+	}()
+	prev := info
+	for _, inc := range increments {
+		izr, iinfo, err := openBackup(inc)
+		if err != nil {
+			return err
+		}
+		srcs = append(srcs, restoreSource{zr: izr, info: iinfo})
+		if err := checkIncrement(prev, iinfo, inc); err != nil {
+			return err
+		}
+		total += len(izr.File)
+		prev = iinfo
+	}
+
 	go func() {
-		if err := m._restore(node, zr, tmpDir, overwrite); err != nil {
+		if err := m._restore(node, zr, srcs, tmpDir, baseDir, overwrite); err != nil {
 			node.logger.Debugf("Restore failed err=%+v", err)
 			if errors.InterruptedError.Equals(err) {
 				m._setState(RestoreNone, nil)
@@ -144,21 +212,22 @@ func (m *RestoreManager) Start(node *Node, file string, baseDir string, overwrit
 	}()
 
 	m.file = file
+	m.increments = increments
 	m.overwrite = overwrite
 	m.state = RestoreStarted
 	m.current = 0
-	m.total = len(zr.File)
+	m.total = total
 	return nil
 }
 
-func (m *RestoreManager) _onRestored(idx int) error {
+func (m *RestoreManager) _onRestored() error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	if m.state != RestoreStarted {
 		return errors.ErrInterrupted
 	}
-	m.current = idx + 1
+	m.current += 1
 	return nil
 }
 
@@ -171,16 +240,18 @@ func (m *RestoreManager) GetStatus() *RestoreStatus {
 		return nil
 	case RestoreStarted:
 		return &RestoreStatus{
-			File:      m.file,
-			Overwrite: m.overwrite,
-			State:     fmt.Sprintf("started %d/%d", m.current, m.total),
+			File:       m.file,
+			Increments: m.increments,
+			Overwrite:  m.overwrite,
+			State:      fmt.Sprintf("started %d/%d", m.current, m.total),
 		}
 	default:
 		return &RestoreStatus{
-			File:      m.file,
-			Overwrite: m.overwrite,
-			State:     m.state.String(),
-			Error:     m.lastErr,
+			File:       m.file,
+			Increments: m.increments,
+			Overwrite:  m.overwrite,
+			State:      m.state.String(),
+			Error:      m.lastErr,
 		}
 	}
 }
@@ -197,6 +268,7 @@ func (m *RestoreManager) _setStateInLock(s RestoreState, e error) {
 	m.lastErr = e
 	if s == RestoreNone {
 		m.file = ""
+		m.increments = nil
 		m.total = 0
 		m.current = 0
 	}
@@ -235,19 +307,53 @@ func zipExtract(file *zip.File, tmpDir string) (ret error) {
 	return err
 }
 
-func (m *RestoreManager) _restore(node *Node, zr *zip.ReadCloser, tmpDir string, overwrite bool) (ret error) {
+func (m *RestoreManager) _extract(zr *zip.ReadCloser, tmpDir string) error {
+	for _, file := range zr.File {
+		if err := zipExtract(file, tmpDir); err != nil {
+			return err
+		}
+		if err := m._onRestored(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *RestoreManager) _merge(node *Node, src restoreSource, tmpDir, baseDir string) error {
+	incDir, err := ioutil.TempDir(baseDir, RestoreDirectoryPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(incDir)
+
+	if err := m._extract(src.zr, incDir); err != nil {
+		return err
+	}
+	cfg, err := node.loadChainConfig(tmpDir)
+	if err != nil {
+		return err
+	}
+	return chain.MergeBackup(tmpDir, incDir, src.info, cfg.DBType)
+}
+
+func (m *RestoreManager) _restore(node *Node, zr *zip.ReadCloser, srcs []restoreSource, tmpDir, baseDir string, overwrite bool) (ret error) {
 	defer func() {
 		if ret != nil {
 			os.RemoveAll(tmpDir)
 		}
 	}()
+	defer func() {
+		for _, src := range srcs {
+			src.zr.Close()
+		}
+	}()
 	defer zr.Close()
 
-	for idx, file := range zr.File {
-		if err := zipExtract(file, tmpDir); err != nil {
-			return err
-		}
-		if err := m._onRestored(idx); err != nil {
+	if err := m._extract(zr, tmpDir); err != nil {
+		return err
+	}
+	for _, src := range srcs {
+		if err := m._merge(node, src, tmpDir, baseDir); err != nil {
 			return err
 		}
 	}
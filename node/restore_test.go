@@ -0,0 +1,72 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/chain"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/errors"
+)
+
+func TestCheckIncrement(t *testing.T) {
+	base := &chain.BackupInfo{
+		NID:     common.HexInt32{Value: 1},
+		CID:     common.HexInt32{Value: 0x123},
+		Channel: "test",
+		Height:  100,
+		Hash:    []byte{0x01},
+		Codec:   codec.BC.Name(),
+	}
+	newIncrement := func() *chain.BackupInfo {
+		info := *base
+		info.Height = 200
+		info.Hash = []byte{0x02}
+		info.Base = 100
+		info.BaseHash = []byte{0x01}
+		return &info
+	}
+
+	inc := newIncrement()
+	assert.NoError(t, checkIncrement(base, inc, "inc1.zip"))
+
+	next := newIncrement()
+	next.Base, next.BaseHash = inc.Height, inc.Hash
+	next.Height, next.Hash = 300, []byte{0x03}
+	assert.NoError(t, checkIncrement(inc, next, "inc2.zip"))
+
+	cases := map[string]func(info *chain.BackupInfo){
+		"not incremental": func(info *chain.BackupInfo) {
+			info.Base, info.BaseHash = 0, nil
+		},
+		"gap": func(info *chain.BackupInfo) {
+			info.Base = 150
+		},
+		"wrong base hash": func(info *chain.BackupInfo) {
+			info.BaseHash = []byte{0x09}
+		},
+		"different nid": func(info *chain.BackupInfo) {
+			info.NID = common.HexInt32{Value: 2}
+		},
+		"different cid": func(info *chain.BackupInfo) {
+			info.CID = common.HexInt32{Value: 0x456}
+		},
+		"different channel": func(info *chain.BackupInfo) {
+			info.Channel = "other"
+		},
+		"different codec": func(info *chain.BackupInfo) {
+			info.Codec = "other"
+		},
+	}
+	for name, modify := range cases {
+		t.Run(name, func(t *testing.T) {
+			inc := newIncrement()
+			modify(inc)
+			err := checkIncrement(base, inc, "inc.zip")
+			assert.Error(t, err)
+			assert.True(t, errors.IllegalArgumentError.Equals(err))
+		})
+	}
+}
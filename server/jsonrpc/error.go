@@ -57,6 +57,8 @@ const (
 	ErrorLackOfResource     ErrorCode = -31005
 	ErrorCodeTimeout        ErrorCode = -31006
 	ErrorCodeSystemTimeout  ErrorCode = -31007
+	ErrorCodeSenderQuota    ErrorCode = -31008
+	ErrorCodePruned         ErrorCode = -31009
 )
 
 type Error struct {
@@ -170,12 +172,14 @@ func ErrorHandler(re *Error, c echo.Context) {
 		}
 		status = http.StatusBadRequest
 	} else {
-		req := c.Get("request").(*Request)
 		res = &ErrorResponse{
-			ID:      req.ID,
 			Version: Version,
 			Error:   re,
 		}
+		// requests in a batch are not available.
+		if req, ok := c.Get("request").(*Request); ok {
+			res.ID = req.ID
+		}
 		switch re.Code {
 		case ErrorCodeInvalidRequest, ErrorCodeInvalidParams:
 			status = http.StatusBadRequest
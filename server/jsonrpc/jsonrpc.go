@@ -42,6 +42,7 @@ const (
 	HeaderKeyIconOptions = "Icon-Options"
 	IconOptionsDebug     = "debug"
 	IconOptionsTimeout   = "timeout"
+	IconOptionsStateDiff = "stateDiff"
 )
 
 type IconOptions map[string]string
@@ -131,6 +132,11 @@ func (ctx *Context) IncludeDebug() bool {
 	return v && serverDebug
 }
 
+func (ctx *Context) IncludeStateDiff() bool {
+	v, _ := ctx.opts.GetBool(IconOptionsStateDiff)
+	return v
+}
+
 func (ctx *Context) GetTimeout(t time.Duration) time.Duration {
 	if v, err := ctx.opts.GetInt(IconOptionsTimeout); err != nil {
 		return t
@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 
@@ -12,11 +14,13 @@ type Handler func(ctx *Context, params *Params) (result interface{}, err error)
 type MethodRepository struct {
 	mtx     sync.RWMutex
 	methods map[string]Handler
+	serial  map[string]bool
 }
 
 func NewMethodRepository() *MethodRepository {
 	return &MethodRepository{
 		methods: make(map[string]Handler),
+		serial:  make(map[string]bool),
 	}
 }
 
@@ -30,6 +34,34 @@ func (mr *MethodRepository) RegisterMethod(method string, handler Handler) {
 	mr.methods[method] = handler
 }
 
+// RegisterSerialMethod registers the method changing the state of the node.
+// Requests for serial methods in a batch are handled in order of the requests
+// with the others, so that they are handled as they are sent one by one.
+func (mr *MethodRepository) RegisterSerialMethod(method string, handler Handler) {
+	mr.RegisterMethod(method, handler)
+
+	defer mr.mtx.Unlock()
+	mr.mtx.Lock()
+	if method != "" && handler != nil {
+		mr.serial[method] = true
+	}
+}
+
+// batchModeOf returns whether the request is for a serial method, and
+// whether it's a notification, which has no id, so it gets no response.
+func (mr *MethodRepository) batchModeOf(msg json.RawMessage) (serial, notification bool) {
+	var r struct {
+		Method string          `json:"method"`
+		ID     json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(msg, &r); err != nil {
+		return false, false
+	}
+	defer mr.mtx.RUnlock()
+	mr.mtx.RLock()
+	return mr.serial[r.Method], r.ID == nil
+}
+
 func (mr *MethodRepository) TakeMethod(r *Request) (Handler, error) {
 	defer mr.mtx.RUnlock()
 	mr.mtx.RLock()
@@ -58,6 +90,9 @@ func (mr *MethodRepository) InvokeMethod(c echo.Context, r *Request) (interface{
 }
 
 func (mr *MethodRepository) Handle(c echo.Context) (err error) {
+	if reqs, ok := c.Get("batch").([]json.RawMessage); ok {
+		return mr.handleBatch(c, reqs)
+	}
 	r := c.Get("request").(*Request)
 
 	result, err := mr.InvokeMethod(c, r)
@@ -73,3 +108,108 @@ func (mr *MethodRepository) Handle(c echo.Context) (err error) {
 
 	return c.JSON(http.StatusOK, res)
 }
+
+// BatchConcurrency is the maximum number of requests for the methods other
+// than serial methods in a batch handled at the same time.
+const BatchConcurrency = 8
+
+// IsBatch returns whether the message is a batch of requests.
+func IsBatch(msg []byte) bool {
+	for _, b := range msg {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// handleBatch handles requests for serial methods one by one in order of the
+// requests, and the other requests between them concurrently. A request for
+// a serial method starts after the requests before it are done, and the
+// requests after it start after it's done. Notifications are handled, but
+// their responses are not returned.
+func (mr *MethodRepository) handleBatch(c echo.Context, reqs []json.RawMessage) error {
+	responses := make([]interface{}, len(reqs))
+	notifications := make([]bool, len(reqs))
+	sem := make(chan struct{}, BatchConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		var serial bool
+		serial, notifications[i] = mr.batchModeOf(req)
+		if serial {
+			wg.Wait()
+			responses[i] = mr.handleBatchItem(c, req)
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, req json.RawMessage) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			responses[i] = mr.handleBatchItem(c, req)
+		}(i, req)
+	}
+	wg.Wait()
+
+	results := make([]interface{}, 0, len(responses))
+	for i, res := range responses {
+		if !notifications[i] {
+			results = append(results, res)
+		}
+	}
+	// nothing is returned for a batch of notifications.
+	if len(results) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return c.JSON(http.StatusOK, results)
+}
+
+func (mr *MethodRepository) handleBatchItem(c echo.Context, msg json.RawMessage) (res interface{}) {
+	r := new(Request)
+	defer func() {
+		if err := recover(); err != nil {
+			res = &ErrorResponse{
+				ID:      r.ID,
+				Version: Version,
+				Error:   ErrInternal(fmt.Sprint(err)),
+			}
+		}
+	}()
+	if err := json.Unmarshal(msg, r); err != nil {
+		return &ErrorResponse{Version: Version, Error: ErrInvalidRequest()}
+	}
+	if err := c.Validate(r); err != nil {
+		return &ErrorResponse{ID: r.ID, Version: Version, Error: ErrInvalidRequest()}
+	}
+	h, err := mr.TakeMethod(r)
+	if err == nil {
+		param := Params{
+			rawMessage: r.Params,
+			validator:  c.Echo().Validator,
+		}
+		var result interface{}
+		if result, err = h(NewContext(c), &param); err == nil {
+			return &Response{
+				ID:      r.ID,
+				Version: Version,
+				Result:  result,
+			}
+		}
+	}
+	je, ok := err.(*Error)
+	if !ok {
+		je = ErrorCodeServer.Wrap(err, false)
+	}
+	return &ErrorResponse{
+		ID:      r.ID,
+		Version: Version,
+		Error:   je,
+	}
+}
@@ -3,8 +3,14 @@ package jsonrpc
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,3 +60,171 @@ func hello(ctx *Context, params *Params) (result interface{}, err error) {
 	}
 	return "hello, " + param.Name, nil
 }
+
+func TestMethodRepository_Batch(t *testing.T) {
+	mr := NewMethodRepository()
+	mr.RegisterMethod("hello", hello)
+
+	e := echo.New()
+	e.Validator = NewValidator()
+
+	message := `[
+		{"id":1,"jsonrpc":"2.0","method":"hello","params":{"name":"icon"}},
+		{"id":2,"jsonrpc":"2.0","method":"unknown"},
+		{"id":3,"jsonrpc":"2.0","method":"hello","params":{"invalid":"icon"}},
+		{"id":4,"jsonrpc":"1.0","method":"hello"},
+		{"id":5,"jsonrpc":"2.0","method":"hello","params":{"name":"loop"}}
+	]`
+	assert.True(t, IsBatch([]byte(message)))
+	assert.False(t, IsBatch([]byte(`{"id":1}`)))
+
+	var reqs []json.RawMessage
+	assert.NoError(t, json.Unmarshal([]byte(message), &reqs))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("batch", reqs)
+	assert.NoError(t, mr.Handle(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res []struct {
+		ID     int         `json:"id"`
+		Result interface{} `json:"result"`
+		Error  *Error      `json:"error"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res, len(reqs))
+	for i, r := range res {
+		assert.Equal(t, i+1, r.ID)
+	}
+	assert.Equal(t, "hello, icon", res[0].Result)
+	assert.Equal(t, ErrorCodeMethodNotFound, res[1].Error.Code)
+	assert.Equal(t, ErrorCodeInvalidParams, res[2].Error.Code)
+	assert.Equal(t, ErrorCodeInvalidRequest, res[3].Error.Code)
+	assert.Equal(t, "hello, loop", res[4].Result)
+}
+
+func TestMethodRepository_BatchSerial(t *testing.T) {
+	mr := NewMethodRepository()
+
+	var lock sync.Mutex
+	var order []int
+	// it returns the number of sent ones, which depends on the order of the
+	// requests with the serial ones.
+	mr.RegisterMethod("count", func(ctx *Context, params *Params) (interface{}, error) {
+		time.Sleep(time.Millisecond)
+		lock.Lock()
+		defer lock.Unlock()
+		return len(order), nil
+	})
+	mr.RegisterSerialMethod("send", func(ctx *Context, params *Params) (interface{}, error) {
+		var param struct {
+			Seq int `json:"seq"`
+		}
+		if err := params.Convert(&param); err != nil {
+			return nil, ErrInvalidParams()
+		}
+		// later requests finish earlier if they run concurrently
+		time.Sleep(time.Duration(10-param.Seq) * time.Millisecond)
+		lock.Lock()
+		defer lock.Unlock()
+		order = append(order, param.Seq)
+		return param.Seq, nil
+	})
+
+	e := echo.New()
+	e.Validator = NewValidator()
+
+	var items []string
+	for i := 0; i < 10; i++ {
+		items = append(items,
+			fmt.Sprintf(`{"id":%d,"jsonrpc":"2.0","method":"send","params":{"seq":%d}}`, i*2, i),
+			fmt.Sprintf(`{"id":%d,"jsonrpc":"2.0","method":"count"}`, i*2+1))
+	}
+	var reqs []json.RawMessage
+	assert.NoError(t, json.Unmarshal([]byte("["+strings.Join(items, ",")+"]"), &reqs))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("batch", reqs)
+	assert.NoError(t, mr.Handle(c))
+
+	var res []struct {
+		ID     int         `json:"id"`
+		Result interface{} `json:"result"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res, len(reqs))
+	for i, r := range res {
+		assert.Equal(t, i, r.ID)
+		if i%2 == 0 {
+			assert.EqualValues(t, i/2, r.Result)
+		} else {
+			assert.EqualValues(t, i/2+1, r.Result)
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, order)
+}
+
+func TestMethodRepository_BatchNotification(t *testing.T) {
+	mr := NewMethodRepository()
+	var lock sync.Mutex
+	var names []string
+	mr.RegisterMethod("hello", func(ctx *Context, params *Params) (interface{}, error) {
+		var param struct {
+			Name string `json:"name"`
+		}
+		if err := params.Convert(&param); err != nil {
+			return nil, ErrInvalidParams()
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		names = append(names, param.Name)
+		return "hello, " + param.Name, nil
+	})
+
+	e := echo.New()
+	e.Validator = NewValidator()
+
+	handle := func(message string) *httptest.ResponseRecorder {
+		var reqs []json.RawMessage
+		assert.NoError(t, json.Unmarshal([]byte(message), &reqs))
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("batch", reqs)
+		assert.NoError(t, mr.Handle(c))
+		return rec
+	}
+
+	rec := handle(`[
+		{"jsonrpc":"2.0","method":"hello","params":{"name":"icon"}},
+		{"id":1,"jsonrpc":"2.0","method":"hello","params":{"name":"loop"}},
+		{"jsonrpc":"2.0","method":"unknown"},
+		{"id":null,"jsonrpc":"2.0","method":"hello","params":{"name":"null"}}
+	]`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res []struct {
+		ID     interface{} `json:"id"`
+		Result interface{} `json:"result"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	if assert.Len(t, res, 2) {
+		assert.EqualValues(t, 1, res[0].ID)
+		assert.Equal(t, "hello, loop", res[0].Result)
+		assert.Nil(t, res[1].ID)
+		assert.Equal(t, "hello, null", res[1].Result)
+	}
+	assert.ElementsMatch(t, []string{"icon", "loop", "null"}, names)
+
+	// nothing is returned for a batch of notifications
+	rec = handle(`[
+		{"jsonrpc":"2.0","method":"hello","params":{"name":"icon"}},
+		{"jsonrpc":"2.0","method":"hello","params":{"name":"loop"}}
+	]`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+	assert.Len(t, names, 5)
+}
@@ -24,8 +24,12 @@ var (
 	msDropUserTx    = stats.Int64("txpool_user_drop", "Drop User Transaction", stats.UnitBytes)
 	msFinLatency    = stats.Int64("txlatency_finalize", "Finalize Transaction Latency", stats.UnitMilliseconds)
 	msCommitLatency = stats.Int64("txlatency_commit", "Commit Transaction Latency", stats.UnitMilliseconds)
+	msExecTx        = stats.Int64("txexec_parallel", "Transactions Executed In Parallel", stats.UnitDimensionless)
+	msExecConflict  = stats.Int64("txexec_conflict", "Transactions Re-executed For Conflicts", stats.UnitDimensionless)
+	msExecConflictR = stats.Int64("txexec_conflict_rate", "Conflict Rate Of Parallel Execution", "%")
 	mkTxType        = NewMetricKey("tx_type")
 	txPoolMks       = []tag.Key{mkTxType}
+	txExecMks       = []tag.Key{}
 )
 
 func RegisterTransaction() {
@@ -43,6 +47,9 @@ func RegisterTransaction() {
 	RegisterMetricView(msDropUserTx, view.Sum(), txPoolMks)
 	RegisterMetricView(msFinLatency, view.LastValue(), txPoolMks)
 	RegisterMetricView(msCommitLatency, view.LastValue(), txPoolMks)
+	RegisterMetricView(msExecTx, view.Sum(), txExecMks)
+	RegisterMetricView(msExecConflict, view.Sum(), txExecMks)
+	RegisterMetricView(msExecConflictR, view.LastValue(), txExecMks)
 }
 
 type commitRecord struct {
@@ -108,3 +115,15 @@ func NewTransactionMetric(ctx context.Context, t string) *TxMetric {
 		commits: make(map[string]*commitRecord),
 	}
 }
+
+// OnParallelExecution records the number of transactions executed in
+// parallel and the number of transactions re-executed for conflicts.
+func OnParallelExecution(ctx context.Context, n int, conflicts int) {
+	if n == 0 {
+		return
+	}
+	stats.Record(ctx,
+		msExecTx.M(int64(n)),
+		msExecConflict.M(int64(conflicts)),
+		msExecConflictR.M(int64(conflicts*100/n)))
+}
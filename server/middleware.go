@@ -2,6 +2,7 @@ package server
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -20,6 +21,26 @@ func JsonRpc(mr *jsonrpc.MethodRepository) echo.MiddlewareFunc {
 			if strings.HasPrefix(ctype, echo.MIMETextPlain) {
 				c.Request().Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			}
+			body, err := ioutil.ReadAll(c.Request().Body)
+			if err != nil {
+				return jsonrpc.ErrParse()
+			}
+			if jsonrpc.IsBatch(body) {
+				var reqs []json.RawMessage
+				if err := json.Unmarshal(body, &reqs); err != nil {
+					return jsonrpc.ErrParse()
+				}
+				if len(reqs) == 0 {
+					return jsonrpc.ErrInvalidRequest()
+				}
+				if limit, _ := c.Get("batchLimit").(int); len(reqs) > limit {
+					return jsonrpc.ErrInvalidRequest(
+						fmt.Sprintf("TooManyRequests(limit=%d)", limit))
+				}
+				c.Set("batch", reqs)
+				return next(c)
+			}
+			c.Request().Body = ioutil.NopCloser(bytes.NewReader(body))
 			r := new(jsonrpc.Request)
 			if err := c.Bind(r); err != nil {
 				return jsonrpc.ErrParse()
@@ -21,6 +21,8 @@ const (
 	flagENABLE  int32 = 1
 	flagDISABLE int32 = 0
 	UrlAdmin          = "/admin"
+
+	DefaultBatchLimit = 100
 )
 
 type Manager struct {
@@ -33,6 +35,7 @@ type Manager struct {
 	jsonrpcDefaultChannel string
 	jsonrpcMessageDump    int32
 	jsonrpcIncludeDebug   int32
+	jsonrpcBatchLimit     int32
 	logger                log.Logger
 }
 
@@ -67,6 +70,7 @@ func NewManager(addr string,
 	}
 	m.SetMessageDump(jsonrpcDump)
 	m.SetIncludeDebug(jsonrpcIncludeDebug)
+	m.SetBatchLimit(DefaultBatchLimit)
 	return m
 }
 
@@ -147,6 +151,15 @@ func (srv *Manager) IncludeDebug() bool {
 	return atomicLoad(&srv.jsonrpcIncludeDebug)
 }
 
+// SetBatchLimit sets the maximum number of requests in a batch.
+func (srv *Manager) SetBatchLimit(limit int) {
+	atomic.StoreInt32(&srv.jsonrpcBatchLimit, int32(limit))
+}
+
+func (srv *Manager) BatchLimit() int {
+	return int(atomic.LoadInt32(&srv.jsonrpcBatchLimit))
+}
+
 func (srv *Manager) Start() error {
 	srv.logger.Infoln("starting the server")
 	// middleware
@@ -171,6 +184,7 @@ func (srv *Manager) Start() error {
 	g.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
 			ctx.Set("includeDebug", srv.IncludeDebug())
+			ctx.Set("batchLimit", srv.BatchLimit())
 			return next(ctx)
 		}
 	})
@@ -187,6 +201,7 @@ func (srv *Manager) Start() error {
 	// websocket
 	srv.e.GET("/api/v3/:channel/block", srv.wssm.RunBlockSession, ChainInjector(srv))
 	srv.e.GET("/api/v3/:channel/event", srv.wssm.RunEventSession, ChainInjector(srv))
+	srv.e.GET("/api/v3/:channel/transaction", srv.wssm.RunTransactionSession, ChainInjector(srv))
 
 	// metric
 	srv.e.GET("/metrics", echo.WrapHandler(metric.PrometheusExporter()))
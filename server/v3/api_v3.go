@@ -3,8 +3,11 @@ package v3
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 	"unsafe"
@@ -17,10 +20,18 @@ import (
 	"github.com/icon-project/goloop/server/jsonrpc"
 	"github.com/icon-project/goloop/service"
 	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/trace"
+	"github.com/icon-project/goloop/service/txresult"
 )
 
 const (
 	ConfigShowPatchTransaction = false
+	ConfigMaxBlockRangeForLogs = 1000
+	ConfigMaxIndexedLogs       = 10000
+	ConfigDefaultPoolTxLimit   = 100
+	ConfigMaxPoolTxLimit       = 1000
+
+	ConfigMaxSimulateTransactions = 100
 )
 
 func MethodRepository() *jsonrpc.MethodRepository {
@@ -31,12 +42,13 @@ func MethodRepository() *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getBlockByHash", getBlockByHash)
 	mr.RegisterMethod("icx_call", call)
 	mr.RegisterMethod("icx_getBalance", getBalance)
+	mr.RegisterMethod("icx_getNonce", getNonce)
 	mr.RegisterMethod("icx_getScoreApi", getScoreApi)
 	mr.RegisterMethod("icx_getTotalSupply", getTotalSupply)
 	mr.RegisterMethod("icx_getTransactionResult", getTransactionResult)
 	mr.RegisterMethod("icx_getTransactionByHash", getTransactionByHash)
-	mr.RegisterMethod("icx_sendTransaction", sendTransaction)
-	mr.RegisterMethod("icx_sendTransactionAndWait", sendTransactionAndWait)
+	mr.RegisterSerialMethod("icx_sendTransaction", sendTransaction)
+	mr.RegisterSerialMethod("icx_sendTransactionAndWait", sendTransactionAndWait)
 	mr.RegisterMethod("icx_waitTransactionResult", waitTransactionResult)
 
 	mr.RegisterMethod("icx_getDataByHash", getDataByHash)
@@ -44,6 +56,9 @@ func MethodRepository() *jsonrpc.MethodRepository {
 	mr.RegisterMethod("icx_getVotesByHeight", getVotesByHeight)
 	mr.RegisterMethod("icx_getProofForResult", getProofForResult)
 	mr.RegisterMethod("icx_getProofForEvents", getProofForEvents)
+	mr.RegisterMethod("icx_getProofForAccount", getProofForAccount)
+	mr.RegisterMethod("icx_getProofForStorage", getProofForStorage)
+	mr.RegisterMethod("icx_getLogs", getLogs)
 
 	return mr
 }
@@ -126,6 +141,9 @@ func getBlockByHeight(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
+	if err := checkPrunedBlock(chain, block.Height(), debug); err != nil {
+		return nil, err
+	}
 
 	blockJson, err := block.ToJSON(module.JSONVersion3)
 	if err != nil {
@@ -162,6 +180,9 @@ func getBlockByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
+	if err := checkPrunedBlock(chain, block.Height(), debug); err != nil {
+		return nil, err
+	}
 
 	blockJson, err := block.ToJSON(module.JSONVersion3)
 	if err != nil {
@@ -193,11 +214,16 @@ func call(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	block, err := bm.GetLastBlock()
+	block, err := blockForQuery(bm, param.Height, debug)
+	if err != nil {
+		return nil, err
+	}
 	result, err := sm.Call(block.Result(), block.NextValidators(), params.RawMessage(), block)
 	if err != nil {
 		if service.InvalidQueryError.Equals(err) {
 			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		} else if service.PrunedStateError.Equals(err) {
+			return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
 		} else if scoreresult.IsValid(err) {
 			return nil, jsonrpc.ErrScore(err, debug)
 		} else {
@@ -227,18 +253,53 @@ func getBalance(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, erro
 	}
 
 	var balance common.HexInt
-	block, err := bm.GetLastBlock()
+	block, err := blockForQuery(bm, param.Height, debug)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 	b, err := sm.GetBalance(block.Result(), param.Address.Address())
-	if err != nil {
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 	balance.Set(b)
 	return &balance, nil
 }
 
+func getNonce(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	var param AddressParam
+	debug := ctx.IncludeDebug()
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	var nonce common.HexInt
+	block, err := blockForQuery(bm, param.Height, debug)
+	if err != nil {
+		return nil, err
+	}
+	n, err := sm.GetNonce(block.Result(), param.Address.Address())
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	nonce.Set(n)
+	return &nonce, nil
+}
+
 func getScoreApi(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	var param ScoreAddressParam
 	debug := ctx.IncludeDebug()
@@ -254,11 +315,14 @@ func getScoreApi(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, err
 	if bm == nil || sm == nil {
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
-	b, err := bm.GetLastBlock()
+	b, err := blockForQuery(bm, param.Height, debug)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 	info, err := sm.GetAPIInfo(b.Result(), param.Address.Address())
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	}
 	if service.NoActiveContractError.Equals(err) {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
 	}
@@ -272,8 +336,16 @@ func getScoreApi(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, err
 	}
 }
 
-func getTotalSupply(ctx *jsonrpc.Context, _ *jsonrpc.Params) (interface{}, error) {
+func getTotalSupply(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
+
+	var param TotalSupplyParam
+	if !params.IsEmpty() {
+		if err := params.Convert(&param); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+	}
+
 	chain, err := ctx.Chain()
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
@@ -284,14 +356,16 @@ func getTotalSupply(ctx *jsonrpc.Context, _ *jsonrpc.Params) (interface{}, error
 		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
 
-	b, err := bm.GetLastBlock()
+	b, err := blockForQuery(bm, param.Height, debug)
 	if err != nil {
-		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		return nil, err
 	}
 
 	var tsValue common.HexInt
 	ts, err := sm.GetTotalSupply(b.Result())
-	if err != nil {
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 	tsValue.Set(ts)
@@ -299,6 +373,40 @@ func getTotalSupply(ctx *jsonrpc.Context, _ *jsonrpc.Params) (interface{}, error
 	return &tsValue, nil
 }
 
+// checkPrunedBlock returns an error if transactions and receipts of the block
+// at the height are removed by the retention policy of the chain.
+func checkPrunedBlock(chain module.Chain, height int64, debug bool) error {
+	if base := block.GetBodyBaseOf(chain.Database()); height < base {
+		err := block.PrunedBlockError.Errorf(
+			"PrunedBlock(height=%d,base=%d)", height, base)
+		return jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	}
+	return nil
+}
+
+// blockForQuery returns the block whose result is used for the query.
+// It returns the last block if the height is not specified.
+func blockForQuery(bm module.BlockManager, height jsonrpc.HexInt, debug bool) (module.Block, error) {
+	if len(height) == 0 {
+		block, err := bm.GetLastBlock()
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		return block, nil
+	}
+	h, err := height.ParseInt(64)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	block, err := bm.GetBlockByHeight(h)
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return block, nil
+}
+
 func getTransactionResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
 	debug := ctx.IncludeDebug()
 
@@ -324,6 +432,8 @@ func getTransactionResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 			return nil, jsonrpc.ErrorCodePending.New("Pending")
 		}
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if block.PrunedBlockError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
@@ -370,6 +480,8 @@ func getTransactionByHash(ctx *jsonrpc.Context, params *jsonrpc.Params) (interfa
 	txInfo, err := bm.GetTransactionInfo(param.Hash.Bytes())
 	if errors.NotFoundError.Equals(err) {
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if block.PrunedBlockError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
@@ -409,6 +521,9 @@ func sendTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{},
 		if service.TransactionPoolOverflowError.Equals(err) {
 			return nil, jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
 		}
+		if service.SenderQuotaExceededError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeSenderQuota.Wrap(err, debug)
+		}
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
@@ -546,6 +661,9 @@ func getProofForResult(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
+	if err := checkPrunedBlock(chain, block.Height(), debug); err != nil {
+		return nil, err
+	}
 	blockResult := block.Result()
 	receiptList, err := sm.ReceiptListFromResult(blockResult, module.TransactionGroupNormal)
 	if err != nil {
@@ -596,6 +714,9 @@ func getProofForEvents(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
+	if err := checkPrunedBlock(chain, block.Height(), debug); err != nil {
+		return nil, err
+	}
 	blockResult := block.Result()
 	receiptList, err := sm.ReceiptListFromResult(blockResult, module.TransactionGroupNormal)
 	if err != nil {
@@ -632,6 +753,302 @@ func getProofForEvents(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{
 	return proofs, nil
 }
 
+func getProofForAccount(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param ProofAccountParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	block, err := bm.GetBlock(param.BlockHash.Bytes())
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	proof, err := sm.GetProofForAccount(block.Result(), param.Address.Address())
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return proof, nil
+}
+
+func getProofForStorage(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param ProofStorageParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	if !strings.HasPrefix(string(param.Key), "0x") {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf("InvalidKey(%s)", param.Key)
+	}
+	key, err := hex.DecodeString(string(param.Key[2:]))
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	block, err := bm.GetBlock(param.BlockHash.Bytes())
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+
+	addr := param.Address.Address()
+	aProof, err := sm.GetProofForAccount(block.Result(), addr)
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	sProof, err := sm.GetProofForStorage(block.Result(), addr, key)
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return [][][]byte{aProof, sProof}, nil
+}
+
+func getLogs(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param LogsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	from, err := param.FromHeight.ParseInt(64)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	if err := param.EventFilter.Compile(); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	// results of the transactions in the last block are not available yet.
+	last, err := bm.GetLastBlock()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	to := last.Height() - 1
+	if len(param.ToHeight) > 0 {
+		if v, err := param.ToHeight.ParseInt(64); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		} else if v < to {
+			to = v
+		}
+	}
+	if from < 0 || from > to {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(from=%d,to=%d)", from, to)
+	}
+	if err := checkPrunedBlock(chain, from, debug); err != nil {
+		return nil, err
+	}
+
+	if param.Addr != nil {
+		eli, err := txresult.NewEventLogIndex(chain.Database())
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		if eli.Covers(from, to) {
+			return getLogsWithIndex(eli, bm, sm, &param, from, to, debug)
+		}
+	}
+
+	if to-from >= ConfigMaxBlockRangeForLogs {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"TooWideRange(from=%d,to=%d,max=%d)", from, to, ConfigMaxBlockRangeForLogs)
+	}
+
+	logs := []interface{}{}
+	for height := from; height <= to; height++ {
+		blk, err := bm.GetBlockByHeight(height)
+		if errors.NotFoundError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+		} else if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		// receipts of the transactions are in the result of the next block.
+		rblk, err := bm.GetBlockByHeight(height + 1)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		if !param.EventFilter.MatchBloom(rblk.LogsBloom()) {
+			continue
+		}
+		rl, err := sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		txIndex := 0
+		for rit := rl.Iterator(); rit.Has(); rit.Next() {
+			rct, err := rit.Get()
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			if indexes, ok := param.EventFilter.Match(rct); ok {
+				for _, idx := range indexes {
+					result, err := eventLogResult(blk, txIndex, rct, int(idx.Value))
+					if err != nil {
+						return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+					}
+					logs = append(logs, result)
+				}
+			}
+			txIndex++
+		}
+	}
+	return logs, nil
+}
+
+// getLogsWithIndex returns matching event logs located by the index
+// instead of scanning all receipts in the range.
+func getLogsWithIndex(eli *txresult.EventLogIndex, bm module.BlockManager,
+	sm module.ServiceManager, param *LogsParam, from, to int64, debug bool,
+) (interface{}, error) {
+	locs, err := eli.Find(param.Addr, []byte(param.Signature), from, to,
+		ConfigMaxIndexedLogs+1)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	if len(locs) > ConfigMaxIndexedLogs {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"TooManyLogs(from=%d,to=%d,max=%d)", from, to, ConfigMaxIndexedLogs)
+	}
+
+	logs := []interface{}{}
+	var blk module.Block
+	var rl module.ReceiptList
+	var rct module.Receipt
+	var indexes []common.HexInt32
+	for i, loc := range locs {
+		if blk == nil || blk.Height() != loc.Height {
+			if blk, err = bm.GetBlockByHeight(loc.Height); err != nil {
+				return nil, logsError(err, debug)
+			}
+			rblk, err := bm.GetBlockByHeight(loc.Height + 1)
+			if err != nil {
+				return nil, logsError(err, debug)
+			}
+			rl, err = sm.ReceiptListFromResult(rblk.Result(), module.TransactionGroupNormal)
+			if err != nil {
+				return nil, logsError(err, debug)
+			}
+			rct = nil
+		}
+		if rct == nil || locs[i-1].TxIndex != loc.TxIndex {
+			if rct, err = rl.Get(loc.TxIndex); err != nil {
+				return nil, logsError(err, debug)
+			}
+			indexes, _ = param.EventFilter.Match(rct)
+		}
+		for _, idx := range indexes {
+			if int(idx.Value) == loc.LogIndex {
+				result, err := eventLogResult(blk, loc.TxIndex, rct, loc.LogIndex)
+				if err != nil {
+					return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+				}
+				logs = append(logs, result)
+				break
+			}
+		}
+	}
+	return logs, nil
+}
+
+// logsError returns the error for the block or the receipts which the logs
+// are read from. Blocks may be missing or pruned after the index is built.
+func logsError(err error, debug bool) error {
+	if block.PrunedBlockError.Equals(err) {
+		return jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if errors.NotFoundError.Equals(err) {
+		return jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	}
+	return jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+}
+
+// eventLogResult returns JSON object of the event log at the index in the
+// receipt of the transaction at txIndex in the block.
+func eventLogResult(blk module.Block, txIndex int, rct module.Receipt, idx int) (map[string]interface{}, error) {
+	tx, err := blk.NormalTransactions().Get(txIndex)
+	if err != nil {
+		return nil, err
+	}
+	el, err := eventLogAt(rct, idx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := txresult.EventLogToJSON(el)
+	if err != nil {
+		return nil, err
+	}
+	result["blockHash"] = "0x" + hex.EncodeToString(blk.ID())
+	result["blockHeight"] = "0x" + strconv.FormatInt(blk.Height(), 16)
+	result["txIndex"] = "0x" + strconv.FormatInt(int64(txIndex), 16)
+	result["txHash"] = "0x" + hex.EncodeToString(tx.ID())
+	result["logIndex"] = "0x" + strconv.FormatInt(int64(idx), 16)
+	return result, nil
+}
+
+// eventLogAt returns the event log at the index in the receipt.
+func eventLogAt(rct module.Receipt, idx int) (module.EventLog, error) {
+	it := rct.EventLogIterator()
+	for i := 0; it.Has(); i++ {
+		if i == idx {
+			return it.Get()
+		}
+		if err := it.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.NotFoundError.Errorf("NoEventLog(idx=%d)", idx)
+}
+
 // convert TransactionList to []Transaction
 func convertTransactionList(txs module.TransactionList, version module.JSONVersion) ([]interface{}, error) {
 	list := []interface{}{}
@@ -691,6 +1108,9 @@ func sendTransactionAndWait(ctx *jsonrpc.Context, params *jsonrpc.Params) (inter
 		if service.TransactionPoolOverflowError.Equals(err) {
 			return nil, jsonrpc.ErrorCodeTxPoolOverflow.Wrap(err, debug)
 		}
+		if service.SenderQuotaExceededError.Equals(err) {
+			return nil, jsonrpc.ErrorCodeSenderQuota.Wrap(err, debug)
+		}
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
 
@@ -804,17 +1224,38 @@ func DebugMethodRepository() *jsonrpc.MethodRepository {
 
 	mr.RegisterMethod("debug_getTrace", getTrace)
 	mr.RegisterMethod("debug_estimateStep", estimateStep)
+	mr.RegisterMethod("debug_simulateTransactions", simulateTransactions)
+	mr.RegisterMethod("debug_getPoolTransactions", getPoolTransactions)
+	mr.RegisterMethod("debug_getPoolStatus", getPoolStatus)
+	mr.RegisterMethod("debug_getDroppedTransaction", getDroppedTransaction)
+	mr.RegisterSerialMethod("debug_evictTransactions", evictTransactions)
 
 	return mr
 }
 
 type traceCallback struct {
+	trace.CallTree
+
 	lock    sync.Mutex
 	logs    []interface{}
 	last    error
+	diff    module.StateDiff
 	channel chan interface{}
 }
 
+// traceStateCallback is used instead of traceCallback if the state diff is
+// requested, so the state is tracked only for the requests.
+type traceStateCallback struct {
+	*traceCallback
+}
+
+func (t traceStateCallback) OnStateDiff(diff module.StateDiff) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.diff = diff
+}
+
 type traceLog struct {
 	Level module.TraceLevel
 	Msg   string
@@ -837,12 +1278,24 @@ func (t *traceCallback) OnEnd(e error) {
 	close(t.channel)
 }
 
-func (t *traceCallback) result() interface{} {
+func (t *traceCallback) result(withDiff bool) (interface{}, error) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
 	result := map[string]interface{}{
-		"logs": t.logs,
+		"logs":  t.logs,
+		"calls": t.CallTree.ToJSON(),
+	}
+	if withDiff {
+		if t.diff != nil {
+			djso, err := t.diff.ToJSON(module.JSONVersionLast)
+			if err != nil {
+				return nil, err
+			}
+			result["stateDiff"] = djso
+		} else {
+			result["stateDiff"] = nil
+		}
 	}
 	if t.last == nil {
 		result["status"] = "0x1"
@@ -854,7 +1307,7 @@ func (t *traceCallback) result() interface{} {
 			"message": t.last.Error(),
 		}
 	}
-	return result
+	return result, nil
 }
 
 func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
@@ -882,6 +1335,8 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 			return nil, jsonrpc.ErrorCodePending.New("Pending")
 		}
 		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if block.PrunedBlockError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
 	} else if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
 	}
@@ -915,10 +1370,15 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 		logs:    make([]interface{}, 0, 100),
 		channel: make(chan interface{}, 10),
 	}
+	withDiff := ctx.IncludeStateDiff()
+	var tcb module.TraceCallback = cb
+	if withDiff {
+		tcb = traceStateCallback{cb}
+	}
 	canceller, err := tr2.ExecuteForTrace(module.TraceInfo{
 		Group:    txInfo.Group(),
 		Index:    txInfo.Index(),
-		Callback: cb,
+		Callback: tcb,
 	})
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
@@ -932,7 +1392,11 @@ func getTrace(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error)
 			return nil, jsonrpc.ErrorCodeSystemTimeout.Errorf(
 				"Not enough time to get result of %x", param.Hash.Bytes())
 		case <-cb.channel:
-			return cb.result(), nil
+			result, err := cb.result(withDiff)
+			if err != nil {
+				return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+			}
+			return result, nil
 		}
 	}
 	return nil, jsonrpc.ErrorCodeSystem.New("Unknown error on channel")
@@ -963,28 +1427,309 @@ func estimateStep(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, er
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
 	}
 
-	// new block information based on the last
+	bi := nextBlockInfo(blk)
+
+	// execute transaction
+	withDiff := ctx.IncludeStateDiff()
+	var rct module.Receipt
+	var diff module.StateDiff
+	if withDiff {
+		rct, diff, err = sm.ExecuteTransactionWithDiff(
+			blk.Result(),
+			blk.NextValidators().Hash(),
+			params.RawMessage(),
+			bi,
+		)
+	} else {
+		rct, err = sm.ExecuteTransaction(
+			blk.Result(),
+			blk.NextValidators().Hash(),
+			params.RawMessage(),
+			bi,
+		)
+	}
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+	if status := rct.Status(); status != module.StatusSuccess {
+		return nil, jsonrpc.ErrScoreWithStatus(status)
+	}
+	steps := new(common.HexInt)
+	steps.Set(rct.StepUsed())
+	if !withDiff {
+		return steps, nil
+	}
+	djso, err := diff.ToJSON(module.JSONVersionLast)
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return map[string]interface{}{
+		"steps":     steps,
+		"stateDiff": djso,
+	}, nil
+}
+
+// nextBlockInfo returns block information for execution on the result of
+// the block.
+func nextBlockInfo(blk module.Block) module.BlockInfo {
 	oldTS := blk.Timestamp()
 	newTS := common.UnixMicroFromTime(time.Now())
 	if newTS <= oldTS {
 		newTS = oldTS + 1
 	}
-	bi := common.NewBlockInfo(blk.Height()+1, newTS)
+	return common.NewBlockInfo(blk.Height()+1, newTS)
+}
 
-	// execute transaction
-	rct, err := sm.ExecuteTransaction(
+func simulateTransactions(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param SimulateTransactionsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+	if len(param.Transactions) > ConfigMaxSimulateTransactions {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"TooManyTransactions(n=%d,max=%d)",
+			len(param.Transactions), ConfigMaxSimulateTransactions)
+	}
+
+	txs := make([][]byte, 0, len(param.Transactions))
+	for _, tx := range param.Transactions {
+		js, err := json.Marshal(tx)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+		txs = append(txs, js)
+	}
+	overrides := make([]*module.AccountOverride, 0, len(param.Overrides))
+	for _, o := range param.Overrides {
+		ao := &module.AccountOverride{
+			Address: o.Address.Address(),
+		}
+		if len(o.Balance) > 0 {
+			balance, ok := new(big.Int).SetString(string(o.Balance), 0)
+			if !ok || balance.Sign() < 0 {
+				return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+					"InvalidBalance(%s)", o.Balance)
+			}
+			ao.Balance = balance
+		}
+		if len(o.Storage) > 0 {
+			ao.Storage = make(map[string][]byte, len(o.Storage))
+			for _, so := range o.Storage {
+				var value []byte
+				if len(so.Value) > 0 {
+					value = so.Value.Bytes()
+				}
+				ao.Storage[string(so.Key.Bytes())] = value
+			}
+		}
+		if len(o.Content) > 0 {
+			if o.ContentType == "" {
+				return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+					"NoContentType(address=%s)", o.Address)
+			}
+			ao.ContentType = o.ContentType
+			ao.Code = o.Content.Bytes()
+		}
+		overrides = append(overrides, ao)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	bm := chain.BlockManager()
+	sm := chain.ServiceManager()
+	if bm == nil || sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	blk, err := blockForQuery(bm, param.Height, debug)
+	if err != nil {
+		return nil, err
+	}
+
+	rcts, err := sm.SimulateTransactions(
 		blk.Result(),
 		blk.NextValidators().Hash(),
-		params.RawMessage(),
-		bi,
+		txs,
+		overrides,
+		nextBlockInfo(blk),
 	)
+	if service.PrunedStateError.Equals(err) {
+		return nil, jsonrpc.ErrorCodePruned.Wrap(err, debug)
+	} else if errors.IllegalArgumentError.Equals(err) ||
+		scoreresult.InvalidParameterError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	receipts := make([]interface{}, 0, len(rcts))
+	for idx, rct := range rcts {
+		res, err := rct.ToJSON(module.JSONVersion3)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		result := res.(map[string]interface{})
+		result["txIndex"] = "0x" + strconv.FormatInt(int64(idx), 16)
+		receipts = append(receipts, result)
+	}
+	return map[string]interface{}{
+		"blockHeight": "0x" + strconv.FormatInt(blk.Height(), 16),
+		"receipts":    receipts,
+	}, nil
+}
+
+func transactionGroupOf(name string) module.TransactionGroup {
+	if name == "patch" {
+		return module.TransactionGroupPatch
+	}
+	return module.TransactionGroupNormal
+}
+
+func getPoolTransactions(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param PoolTransactionsParam
+	if !params.IsEmpty() {
+		if err := params.Convert(&param); err != nil {
+			return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+		}
+	}
+	var offset, limit int64 = 0, ConfigDefaultPoolTxLimit
+	if len(param.Offset) > 0 {
+		offset = param.Offset.Value()
+	}
+	if len(param.Limit) > 0 {
+		limit = param.Limit.Value()
+	}
+	if offset < 0 || limit <= 0 || limit > ConfigMaxPoolTxLimit {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Errorf(
+			"InvalidRange(offset=%d,limit=%d,max=%d)",
+			offset, limit, ConfigMaxPoolTxLimit)
+	}
+
+	chain, err := ctx.Chain()
 	if err != nil {
 		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
 	}
-	if status := rct.Status(); status != module.StatusSuccess {
-		return nil, jsonrpc.ErrScoreWithStatus(status)
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
 	}
-	steps := new(common.HexInt)
-	steps.Set(rct.StepUsed())
-	return steps, nil
+
+	txs, total := sm.GetPoolTransactions(transactionGroupOf(param.Group),
+		int(offset), int(limit))
+	txList := make([]interface{}, 0, len(txs))
+	for _, tx := range txs {
+		js, err := tx.ToJSON(module.JSONVersionLast)
+		if err != nil {
+			return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+		}
+		txList = append(txList, js)
+	}
+	return map[string]interface{}{
+		"total":        "0x" + strconv.FormatInt(int64(total), 16),
+		"transactions": txList,
+	}, nil
+}
+
+func poolStatusToJSON(status *module.TransactionPoolStatus) map[string]interface{} {
+	senders := make(map[string]interface{}, len(status.Senders))
+	for addr, cnt := range status.Senders {
+		senders[addr] = "0x" + strconv.FormatInt(int64(cnt), 16)
+	}
+	return map[string]interface{}{
+		"size":            "0x" + strconv.FormatInt(int64(status.Size), 16),
+		"used":            "0x" + strconv.FormatInt(int64(status.Used), 16),
+		"oldestTimestamp": "0x" + strconv.FormatInt(status.OldestTimestamp, 16),
+		"senders":         senders,
+	}
+}
+
+func getPoolStatus(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	return map[string]interface{}{
+		"normal": poolStatusToJSON(sm.GetPoolStatus(module.TransactionGroupNormal)),
+		"patch":  poolStatusToJSON(sm.GetPoolStatus(module.TransactionGroupPatch)),
+	}, nil
+}
+
+func getDroppedTransaction(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param TransactionHashParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	if sm.HasTransaction(param.Hash.Bytes()) {
+		return nil, jsonrpc.ErrorCodePending.New("Pending")
+	}
+	drop, err := sm.GetTransactionDrop(param.Hash.Bytes())
+	if errors.NotFoundError.Equals(err) {
+		return nil, jsonrpc.ErrorCodeNotFound.Wrap(err, debug)
+	} else if err != nil {
+		return nil, jsonrpc.ErrorCodeSystem.Wrap(err, debug)
+	}
+	return map[string]interface{}{
+		"txHash":    param.Hash,
+		"reason":    drop.Reason.Error(),
+		"droppedAt": "0x" + strconv.FormatInt(common.UnixMicroFromTime(drop.Time), 16),
+	}, nil
+}
+
+func evictTransactions(ctx *jsonrpc.Context, params *jsonrpc.Params) (interface{}, error) {
+	debug := ctx.IncludeDebug()
+
+	var param EvictTransactionsParam
+	if err := params.Convert(&param); err != nil {
+		return nil, jsonrpc.ErrorCodeInvalidParams.Wrap(err, debug)
+	}
+
+	chain, err := ctx.Chain()
+	if err != nil {
+		return nil, jsonrpc.ErrorCodeServer.Wrap(err, debug)
+	}
+
+	sm := chain.ServiceManager()
+	if sm == nil {
+		return nil, jsonrpc.ErrorCodeServer.New("Stopped")
+	}
+
+	ids := make([][]byte, len(param.Hashes))
+	for i, hash := range param.Hashes {
+		ids[i] = hash.Bytes()
+	}
+	evicted := sm.EvictTransactions(ids)
+	hashes := make([]common.HexBytes, len(evicted))
+	for i, id := range evicted {
+		hashes[i] = id
+	}
+	return hashes, nil
 }
@@ -0,0 +1,86 @@
+package v3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/block"
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/service/txresult"
+	"github.com/icon-project/goloop/test"
+)
+
+type tPruneChain struct {
+	test.ChainBase
+	dbase db.Database
+}
+
+func (c *tPruneChain) Database() db.Database {
+	return c.dbase
+}
+
+func TestCheckPrunedBlock(t *testing.T) {
+	c := &tPruneChain{dbase: db.NewMapDB()}
+	assert.NoError(t, checkPrunedBlock(c, 0, false))
+
+	assert.NoError(t, block.SetBodyBaseOf(c.dbase, 10))
+	err := checkPrunedBlock(c, 9, false)
+	if assert.Error(t, err) {
+		je, ok := err.(*jsonrpc.Error)
+		assert.True(t, ok)
+		assert.Equal(t, jsonrpc.ErrorCodePruned, je.Code)
+	}
+	assert.NoError(t, checkPrunedBlock(c, 10, false))
+}
+
+type tLogsBlockManager struct {
+	test.BlockManagerBase
+	err error
+}
+
+func (bm *tLogsBlockManager) GetBlockByHeight(height int64) (module.Block, error) {
+	return nil, bm.err
+}
+
+func TestGetLogsWithIndex_NoBlock(t *testing.T) {
+	dbase := db.NewMapDB()
+	score := common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	sig := "Transfer(Address,Address,int)"
+	rct := txresult.NewReceipt(dbase, module.LatestRevision, score)
+	rct.AddLog(score, [][]byte{[]byte(sig), {0x01}, {0x02}}, [][]byte{{0x10}})
+	rct.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+	eli, err := txresult.NewEventLogIndex(dbase)
+	assert.NoError(t, err)
+	assert.NoError(t, eli.Add(5, txresult.NewReceiptListFromSlice(dbase,
+		[]txresult.Receipt{rct})))
+
+	param := &LogsParam{EventFilter: EventFilter{Addr: score, Signature: sig}}
+	assert.NoError(t, param.Compile())
+
+	cases := []struct {
+		name string
+		err  error
+		code jsonrpc.ErrorCode
+	}{
+		{"Missing", errors.NotFoundError.New("NoBlock"), jsonrpc.ErrorCodeNotFound},
+		{"Pruned", block.PrunedBlockError.New("PrunedBlock"), jsonrpc.ErrorCodePruned},
+		{"Failure", errors.UnknownError.New("Failure"), jsonrpc.ErrorCodeSystem},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bm := &tLogsBlockManager{err: c.err}
+			_, err := getLogsWithIndex(eli, bm, nil, param, 5, 5, false)
+			if assert.Error(t, err) {
+				je, ok := err.(*jsonrpc.Error)
+				assert.True(t, ok)
+				assert.Equal(t, c.code, je.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package v3
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+func TestEventFilter_Match(t *testing.T) {
+	score := common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	other := common.NewAddressFromString("cx0000000000000000000000000000000000000002")
+	from := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	to := common.NewAddressFromString("hx0000000000000000000000000000000000000002")
+	sig := []byte("Transfer(Address,Address,int)")
+
+	rct := txresult.NewReceipt(db.NewMapDB(), 0, score)
+	rct.AddLog(other, [][]byte{sig, from.Bytes(), to.Bytes()}, [][]byte{{0x10}})
+	rct.AddLog(score, [][]byte{sig, from.Bytes(), to.Bytes()}, [][]byte{{0x10}})
+	rct.AddLog(score, [][]byte{sig, to.Bytes(), from.Bytes()}, [][]byte{{0x20}})
+	rct.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+
+	var f EventFilter
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"addr": "cx0000000000000000000000000000000000000001",
+		"event": "Transfer(Address,Address,int)",
+		"indexed": ["hx0000000000000000000000000000000000000001"]
+	}`), &f))
+	assert.NoError(t, f.Compile())
+	assert.True(t, f.MatchBloom(rct.LogsBloom()))
+
+	indexes, ok := f.Match(rct)
+	assert.True(t, ok)
+	assert.Equal(t, []common.HexInt32{{Value: 1}}, indexes)
+
+	el, err := eventLogAt(rct, int(indexes[0].Value))
+	assert.NoError(t, err)
+	assert.True(t, el.Address().Equal(score))
+	jso, err := txresult.EventLogToJSON(el)
+	assert.NoError(t, err)
+	bs, err := json.Marshal(jso["data"])
+	assert.NoError(t, err)
+	assert.Equal(t, `["0x10"]`, string(bs))
+
+	_, err = eventLogAt(rct, 3)
+	assert.Error(t, err)
+
+	f = EventFilter{Signature: "Approval(Address,int)"}
+	assert.NoError(t, f.Compile())
+	_, ok = f.Match(rct)
+	assert.False(t, ok)
+
+	f = EventFilter{Signature: "BadSignature"}
+	assert.Error(t, f.Compile())
+}
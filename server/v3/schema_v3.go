@@ -26,14 +26,21 @@ type CallParam struct {
 	ToAddress   jsonrpc.Address `json:"to" validate:"required,t_addr_score"`
 	DataType    string          `json:"dataType" validate:"required,call"`
 	Data        interface{}     `json:"data"`
+	Height      jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
 }
 
 type AddressParam struct {
 	Address jsonrpc.Address `json:"address" validate:"required,t_addr"`
+	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
 }
 
 type ScoreAddressParam struct {
 	Address jsonrpc.Address `json:"address" validate:"required,t_addr_score"`
+	Height  jsonrpc.HexInt  `json:"height,omitempty" validate:"optional,t_int"`
+}
+
+type TotalSupplyParam struct {
+	Height jsonrpc.HexInt `json:"height,omitempty" validate:"optional,t_int"`
 }
 
 type TransactionHashParam struct {
@@ -80,3 +87,49 @@ type ProofEventsParam struct {
 	Index     jsonrpc.HexInt   `json:"index" validate:"required,t_int"`
 	Events    []jsonrpc.HexInt `json:"events" validate:"gt=0,dive,t_int"`
 }
+
+type ProofAccountParam struct {
+	BlockHash jsonrpc.HexBytes `json:"hash" validate:"required,t_hash"`
+	Address   jsonrpc.Address  `json:"address" validate:"required,t_addr"`
+}
+
+type ProofStorageParam struct {
+	BlockHash jsonrpc.HexBytes `json:"hash" validate:"required,t_hash"`
+	Address   jsonrpc.Address  `json:"address" validate:"required,t_addr_score"`
+	Key       jsonrpc.HexBytes `json:"key" validate:"required"`
+}
+
+type LogsParam struct {
+	EventFilter
+	FromHeight jsonrpc.HexInt `json:"fromHeight" validate:"required,t_int"`
+	ToHeight   jsonrpc.HexInt `json:"toHeight,omitempty" validate:"optional,t_int"`
+}
+
+type PoolTransactionsParam struct {
+	Group  string         `json:"group,omitempty" validate:"optional,oneof=normal patch"`
+	Offset jsonrpc.HexInt `json:"offset,omitempty" validate:"optional,t_int"`
+	Limit  jsonrpc.HexInt `json:"limit,omitempty" validate:"optional,t_int"`
+}
+
+type EvictTransactionsParam struct {
+	Hashes []jsonrpc.HexBytes `json:"txHashes" validate:"required,gt=0,dive,t_hash"`
+}
+
+type StorageOverrideParam struct {
+	Key   jsonrpc.HexBytes `json:"key" validate:"required"`
+	Value jsonrpc.HexBytes `json:"value,omitempty"`
+}
+
+type AccountOverrideParam struct {
+	Address     jsonrpc.Address        `json:"address" validate:"required,t_addr"`
+	Balance     jsonrpc.HexInt         `json:"balance,omitempty" validate:"optional,t_int"`
+	Storage     []StorageOverrideParam `json:"storage,omitempty" validate:"optional,dive"`
+	ContentType string                 `json:"contentType,omitempty" validate:"optional,oneof=application/zip application/java"`
+	Content     jsonrpc.HexBytes       `json:"content,omitempty"`
+}
+
+type SimulateTransactionsParam struct {
+	Height       jsonrpc.HexInt                `json:"height,omitempty" validate:"optional,t_int"`
+	Transactions []TransactionParamForEstimate `json:"transactions" validate:"required,gt=0,dive"`
+	Overrides    []AccountOverrideParam        `json:"stateOverrides,omitempty" validate:"optional,dive"`
+}
@@ -86,3 +86,59 @@ func TestTransactionParamValidator(t *testing.T) {
 		assert.Fail(t, "validate fail", err.Error())
 	}
 }
+
+func TestSimulateTransactionsParamValidator(t *testing.T) {
+
+	validator := jsonrpc.NewValidator()
+	RegisterValidationRule(validator)
+
+	var param SimulateTransactionsParam
+
+	params := []byte(`
+		{
+			"height": "0x10",
+			"transactions": [
+				{
+					"version": "0x3",
+					"from": "hx4873b94352c8c1f3b2f09aaeccea31ce9e90bd31",
+					"to": "cx059e19601bcb1424884f4ef19addc0a03de9e9cd",
+					"timestamp": "0x563a6cf330136",
+					"nid": "0x3",
+					"dataType": "call",
+					"data": {
+						"method": "transfer"
+					}
+				}
+			],
+			"stateOverrides": [
+				{
+					"address": "cx059e19601bcb1424884f4ef19addc0a03de9e9cd",
+					"balance": "0x10",
+					"storage": [
+						{ "key": "0x01", "value": "0x02" },
+						{ "key": "0x03" }
+					],
+					"contentType": "application/java",
+					"content": "0x121212"
+				}
+			]
+		}
+	`)
+
+	if err := json.Unmarshal(params, &param); err != nil {
+		assert.Fail(t, "unmarshal fail", err.Error())
+	}
+	if err := validator.Validate(&param); err != nil {
+		assert.Fail(t, "validate fail", err.Error())
+	}
+
+	param.Overrides[0].ContentType = "application/unknown"
+	assert.Error(t, validator.Validate(&param))
+
+	param.Overrides = nil
+	param.Transactions[0].FromAddress = "cx4873b94352c8c1f3b2f09aaeccea31ce9e90bd31"
+	assert.Error(t, validator.Validate(&param))
+
+	param.Transactions = nil
+	assert.Error(t, validator.Validate(&param))
+}
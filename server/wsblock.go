@@ -90,7 +90,7 @@ loop:
 			}
 			lb := blk.LogsBloom()
 			for i, f := range br.EventFilters {
-				if lb.Contain(f.lb) {
+				if f.MatchBloom(lb) {
 					if rl == nil {
 						rl, err = sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
 						if err != nil {
@@ -103,7 +103,7 @@ loop:
 						if err != nil {
 							break loop
 						}
-						if es, ok := f.match(r); ok {
+						if es, ok := f.Match(r); ok {
 							if len(br.bn.Indexes) < 1 {
 								br.bn.Indexes = indexes[:]
 								br.bn.Events = events[:]
@@ -128,7 +128,7 @@ loop:
 
 func (r *BlockRequest) compile() error {
 	for i, f := range r.EventFilters {
-		if err := f.compile(); err != nil {
+		if err := f.Compile(); err != nil {
 			return fmt.Errorf("fail to compile idx:%d, err:%v", i, err)
 		}
 	}
@@ -1,40 +1,40 @@
 package server
 
 import (
-	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/icon-project/goloop/common"
-	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
 	"github.com/icon-project/goloop/server/jsonrpc"
+	"github.com/icon-project/goloop/server/v3"
 	"github.com/icon-project/goloop/service/txresult"
 )
 
+type EventFilter = v3.EventFilter
+
 type EventRequest struct {
 	EventFilter
-	Height common.HexInt64 `json:"height"`
+	Height           common.HexInt64 `json:"height"`
+	EventFilters     []*EventFilter  `json:"eventFilters,omitempty"`
+	Logs             bool            `json:"logs,omitempty"`
+	ProgressInterval common.HexInt64 `json:"progressInterval,omitempty"`
+	filters          []*EventFilter
 }
 
-type EventFilter struct {
-	Addr       *common.Address `json:"addr,omitempty"`
-	Signature  string          `json:"event"`
-	Indexed    []*string       `json:"indexed,omitempty"`
-	Data       []*string       `json:"data,omitempty"`
-	indexedBSs [][]byte
-	dataBSs    [][]byte
-	numOfArgs  int
-	lb         module.LogsBloom
-	indexes    []int
+type EventNotification struct {
+	Hash    common.HexBytes          `json:"hash"`
+	Height  common.HexInt64          `json:"height"`
+	Index   common.HexInt32          `json:"index"`
+	Events  []common.HexInt32        `json:"events"`
+	Filters []common.HexInt32        `json:"filters,omitempty"`
+	Logs    []map[string]interface{} `json:"logs,omitempty"`
 }
 
-type EventNotification struct {
-	Hash   common.HexBytes   `json:"hash"`
-	Height common.HexInt64   `json:"height"`
-	Index  common.HexInt32   `json:"index"`
-	Events []common.HexInt32 `json:"events"`
+type ProgressNotification struct {
+	Progress common.HexInt64 `json:"progress"`
 }
 
 func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
@@ -70,6 +70,7 @@ func (wm *wsSessionManager) RunEventSession(ctx echo.Context) error {
 	go readLoop(wss.c, ech)
 
 	var bch <-chan module.Block
+	last := h
 
 loop:
 	for {
@@ -81,122 +82,145 @@ loop:
 		case err = <-ech:
 			break loop
 		case blk := <-bch:
-			if !blk.LogsBloom().Contain(er.lb) {
-				h++
-				continue loop
-			}
-			rl, err := sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
-			if err != nil {
+			if err = er.notifyEvents(wss.WriteJSON, sm, blk); err != nil {
+				wm.logger.Infof("fail to notify events err:%+v\n", err)
 				break loop
 			}
-			index := int32(0)
-			for rit := rl.Iterator(); rit.Has(); rit.Next() {
-				r, err := rit.Get()
-				if err != nil {
-					break loop
-				}
-				if es, ok := er.match(r); ok {
-					var en EventNotification
-					en.Height.Value = h
-					en.Hash = blk.ID()
-					en.Index.Value = index
-					en.Events = es
-					if err := wss.WriteJSON(&en); err != nil {
-						wm.logger.Infof("fail to write json EventNotification err:%+v\n", err)
-						break loop
-					}
-				}
-				index++
-			}
 		}
 		h++
+		if er.needProgress(h, last) {
+			last = h
+			if err = wss.WriteJSON(&ProgressNotification{
+				Progress: common.HexInt64{Value: h},
+			}); err != nil {
+				wm.logger.Infof("fail to write json ProgressNotification err:%+v\n", err)
+				break loop
+			}
+		}
 	}
 	wm.logger.Warnf("%+v\n", err)
 	return nil
 }
 
-func (f *EventFilter) compile() error {
-	lb := txresult.NewLogsBloom(nil)
-	if f.Addr != nil {
-		lb.AddAddressOfLog(f.Addr)
-	}
-	f.numOfArgs = len(f.Indexed) + len(f.Data)
-	name, pts := txresult.DecomposeEventSignature(f.Signature)
-	if len(name) == 0 || pts == nil || len(pts) < f.numOfArgs {
-		return errors.NewBase(errors.IllegalArgumentError, "bad event signature")
-	}
-	lb.AddIndexedOfLog(0, []byte(f.Signature))
-	idx := 0
-	f.indexedBSs = make([][]byte, len(f.Indexed))
-	for i, arg := range f.Indexed {
-		if arg != nil {
-			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
-			if err != nil {
-				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
-			}
-			lb.AddIndexedOfLog(i+1, bs)
-			f.indexedBSs[i] = bs
+func (r *EventRequest) compile() error {
+	if len(r.EventFilters) > 0 {
+		if len(r.Signature) > 0 {
+			return fmt.Errorf("both of event and eventFilters are specified")
 		}
-		idx++
+		r.filters = r.EventFilters
+	} else {
+		r.filters = []*EventFilter{&r.EventFilter}
 	}
-	f.dataBSs = make([][]byte, len(f.Data))
-	for i, arg := range f.Data {
-		if arg != nil {
-			bs, err := txresult.EventDataStringToBytesByType(pts[idx], string(*arg))
-			if err != nil {
-				return errors.NewBase(errors.IllegalArgumentError, "bad event data")
+	for i, f := range r.filters {
+		if err := f.Compile(); err != nil {
+			return fmt.Errorf("fail to compile idx:%d, err:%v", i, err)
+		}
+	}
+	if r.ProgressInterval.Value < 0 {
+		return fmt.Errorf("negative progressInterval")
+	}
+	return nil
+}
+
+// needProgress returns whether a progress notification is needed for the
+// height h when the last one was sent for the height last.
+func (r *EventRequest) needProgress(h, last int64) bool {
+	interval := r.ProgressInterval.Value
+	return interval > 0 && h-last >= interval
+}
+
+// notifyEvents writes a notification for each receipt with events matching
+// the filters in the result of the block. Notifications are written in order
+// of receipts, and each one has the events matching any of the filters and
+// the indexes of the matched filters if eventFilters is used.
+func (r *EventRequest) notifyEvents(write func(v interface{}) error, sm module.ServiceManager, blk module.Block) error {
+	lb := blk.LogsBloom()
+	var filters []int
+	for fi, f := range r.filters {
+		if f.MatchBloom(lb) {
+			filters = append(filters, fi)
+		}
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	rl, err := sm.ReceiptListFromResult(blk.Result(), module.TransactionGroupNormal)
+	if err != nil {
+		return err
+	}
+	index := int32(0)
+	for rit := rl.Iterator(); rit.Has(); rit.Next() {
+		rct, err := rit.Get()
+		if err != nil {
+			return err
+		}
+		if en := r.notificationOf(filters, rct); en != nil {
+			en.Height.Value = blk.Height()
+			en.Hash = blk.ID()
+			en.Index.Value = index
+			if r.Logs {
+				if en.Logs, err = eventLogsOf(rct, en.Events); err != nil {
+					return err
+				}
+			}
+			if err := write(en); err != nil {
+				return err
 			}
-			f.dataBSs[i] = bs
 		}
-		idx++
+		index++
 	}
-	f.lb = lb
 	return nil
 }
 
-// bytesEqual check equality of byte slice.
-// But it doesn't assume nil as empty bytes.
-func bytesEqual(b1 []byte, b2 []byte) bool {
-	if b1 == nil && b2 == nil {
-		return true
+// notificationOf returns a notification having the events in the receipt
+// matching any of the filters, or nil if there is no matching event.
+func (r *EventRequest) notificationOf(filters []int, rct module.Receipt) *EventNotification {
+	var en EventNotification
+	matched := make(map[int32]bool)
+	for _, fi := range filters {
+		es, ok := r.filters[fi].Match(rct)
+		if !ok {
+			continue
+		}
+		for _, e := range es {
+			matched[e.Value] = true
+		}
+		if len(r.EventFilters) > 0 {
+			en.Filters = append(en.Filters, common.HexInt32{Value: int32(fi)})
+		}
+	}
+	if len(matched) == 0 {
+		return nil
 	}
-	if b1 == nil || b2 == nil {
-		return false
+	en.Events = make([]common.HexInt32, 0, len(matched))
+	for e := range matched {
+		en.Events = append(en.Events, common.HexInt32{Value: e})
 	}
-	return bytes.Equal(b1, b2)
+	sort.Slice(en.Events, func(i, j int) bool {
+		return en.Events[i].Value < en.Events[j].Value
+	})
+	return &en
 }
 
-func (f *EventFilter) match(r module.Receipt) ([]common.HexInt32, bool) {
-	eventIndexes := make([]common.HexInt32, 0)
-	if r.LogsBloom().Contain(f.lb) {
-	loop:
-		for it, idx := r.EventLogIterator(), int32(0); it.Has(); _, idx = it.Next(), idx+1 {
-			if el, err := it.Get(); err == nil {
-				if bytes.Equal([]byte(f.Signature), el.Indexed()[0]) {
-					if f.Addr != nil && !el.Address().Equal(f.Addr) {
-						continue loop
-					}
-					if f.numOfArgs > 0 {
-						if (len(el.Indexed()) + len(el.Data())) <= f.numOfArgs {
-							continue loop
-						}
-
-						for i, arg := range f.indexedBSs {
-							if arg != nil && !bytesEqual(arg, el.Indexed()[i+1]) {
-								continue loop
-							}
-						}
-						for i, arg := range f.dataBSs {
-							if arg != nil && !bytesEqual(arg, el.Data()[i]) {
-								continue loop
-							}
-						}
-					}
-					eventIndexes = append(eventIndexes, common.HexInt32{Value: idx})
-				}
+func eventLogsOf(rct module.Receipt, es []common.HexInt32) ([]map[string]interface{}, error) {
+	logs := make([]map[string]interface{}, 0, len(es))
+	it := rct.EventLogIterator()
+	for idx, i := int32(0), 0; it.Has() && i < len(es); idx++ {
+		if es[i].Value == idx {
+			el, err := it.Get()
+			if err != nil {
+				return nil, err
 			}
+			jso, err := txresult.EventLogToJSON(el)
+			if err != nil {
+				return nil, err
+			}
+			logs = append(logs, jso)
+			i++
+		}
+		if err := it.Next(); err != nil {
+			return nil, err
 		}
-		return eventIndexes, len(eventIndexes) > 0
 	}
-	return eventIndexes, false
+	return logs, nil
 }
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/txresult"
+	"github.com/icon-project/goloop/test"
+)
+
+type tEventBlock struct {
+	test.BlockBase
+	height int64
+	lb     *txresult.LogsBloom
+}
+
+func (b *tEventBlock) ID() []byte {
+	return []byte{byte(b.height)}
+}
+
+func (b *tEventBlock) Height() int64 {
+	return b.height
+}
+
+func (b *tEventBlock) LogsBloom() module.LogsBloom {
+	return b.lb
+}
+
+func (b *tEventBlock) Result() []byte {
+	return nil
+}
+
+type tEventServiceManager struct {
+	test.ServiceManagerBase
+	rl module.ReceiptList
+}
+
+func (sm *tEventServiceManager) ReceiptListFromResult(result []byte, g module.TransactionGroup) (module.ReceiptList, error) {
+	return sm.rl, nil
+}
+
+var (
+	tScore    = common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	tFrom     = common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	tTo       = common.NewAddressFromString("hx0000000000000000000000000000000000000002")
+	tTransfer = []byte("Transfer(Address,Address,int)")
+	tApproval = []byte("Approval(Address,int)")
+)
+
+// newEventBlock returns a block with three receipts. The first has a
+// Transfer from tFrom and an Approval, the second has nothing and the last
+// has a Transfer from tTo.
+func newEventBlock() (*tEventBlock, *tEventServiceManager) {
+	mdb := db.NewMapDB()
+	r0 := txresult.NewReceipt(mdb, 0, tScore)
+	r0.AddLog(tScore, [][]byte{tTransfer, tFrom.Bytes(), tTo.Bytes()}, [][]byte{{0x10}})
+	r0.AddLog(tScore, [][]byte{tApproval, tFrom.Bytes()}, [][]byte{{0x20}})
+	r0.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+	r1 := txresult.NewReceipt(mdb, 0, tScore)
+	r1.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+	r2 := txresult.NewReceipt(mdb, 0, tScore)
+	r2.AddLog(tScore, [][]byte{tTransfer, tTo.Bytes(), tFrom.Bytes()}, [][]byte{{0x30}})
+	r2.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+
+	rcts := []txresult.Receipt{r0, r1, r2}
+	lb := txresult.NewLogsBloom(nil)
+	for _, rct := range rcts {
+		lb.Merge(rct.LogsBloom())
+	}
+	blk := &tEventBlock{height: 10, lb: lb}
+	sm := &tEventServiceManager{rl: txresult.NewReceiptListFromSlice(mdb, rcts)}
+	return blk, sm
+}
+
+func newEventRequest(t *testing.T, js string) *EventRequest {
+	er := new(EventRequest)
+	assert.NoError(t, json.Unmarshal([]byte(js), er))
+	assert.NoError(t, er.compile())
+	return er
+}
+
+func collectEvents(t *testing.T, er *EventRequest, blk module.Block, sm module.ServiceManager) []*EventNotification {
+	var ens []*EventNotification
+	err := er.notifyEvents(func(v interface{}) error {
+		ens = append(ens, v.(*EventNotification))
+		return nil
+	}, sm, blk)
+	assert.NoError(t, err)
+	return ens
+}
+
+func TestEventRequest_NotifyEvents(t *testing.T) {
+	blk, sm := newEventBlock()
+
+	er := newEventRequest(t, `{
+		"height": "0xa",
+		"event": "Transfer(Address,Address,int)",
+		"addr": "cx0000000000000000000000000000000000000001"
+	}`)
+	ens := collectEvents(t, er, blk, sm)
+	if assert.Len(t, ens, 2) {
+		assert.Equal(t, int64(10), ens[0].Height.Value)
+		assert.Equal(t, blk.ID(), []byte(ens[0].Hash))
+		assert.Equal(t, int32(0), ens[0].Index.Value)
+		assert.Equal(t, []common.HexInt32{{Value: 0}}, ens[0].Events)
+		assert.Nil(t, ens[0].Filters)
+		assert.Nil(t, ens[0].Logs)
+		assert.Equal(t, int32(2), ens[1].Index.Value)
+		assert.Equal(t, []common.HexInt32{{Value: 0}}, ens[1].Events)
+	}
+
+	er = newEventRequest(t, `{
+		"height": "0xa",
+		"event": "Approval(Address,int)",
+		"indexed": ["hx0000000000000000000000000000000000000002"]
+	}`)
+	ens = collectEvents(t, er, blk, sm)
+	assert.Len(t, ens, 0)
+}
+
+func TestEventRequest_NotifyEventsWithFilters(t *testing.T) {
+	blk, sm := newEventBlock()
+
+	er := newEventRequest(t, `{
+		"height": "0xa",
+		"eventFilters": [
+			{ "event": "Approval(Address,int)" },
+			{ "event": "Transfer(Address,Address,int)" },
+			{
+				"event": "Transfer(Address,Address,int)",
+				"indexed": ["hx0000000000000000000000000000000000000001"]
+			}
+		]
+	}`)
+	ens := collectEvents(t, er, blk, sm)
+
+	// notifications are in order of receipts, and a receipt matching
+	// multiple filters is notified once.
+	if assert.Len(t, ens, 2) {
+		assert.Equal(t, int32(0), ens[0].Index.Value)
+		assert.Equal(t, []common.HexInt32{{Value: 0}, {Value: 1}}, ens[0].Events)
+		assert.Equal(t, []common.HexInt32{{Value: 0}, {Value: 1}, {Value: 2}}, ens[0].Filters)
+		assert.Equal(t, int32(2), ens[1].Index.Value)
+		assert.Equal(t, []common.HexInt32{{Value: 0}}, ens[1].Events)
+		assert.Equal(t, []common.HexInt32{{Value: 1}}, ens[1].Filters)
+	}
+
+	er = newEventRequest(t, `{
+		"height": "0xa",
+		"eventFilters": [
+			{ "event": "Approval(Address,int)" },
+			{ "event": "Deposit(Address,int)" }
+		]
+	}`)
+	ens = collectEvents(t, er, blk, sm)
+	if assert.Len(t, ens, 1) {
+		assert.Equal(t, []common.HexInt32{{Value: 1}}, ens[0].Events)
+		assert.Equal(t, []common.HexInt32{{Value: 0}}, ens[0].Filters)
+	}
+
+	er = new(EventRequest)
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"event": "Approval(Address,int)",
+		"eventFilters": [ { "event": "Approval(Address,int)" } ]
+	}`), er))
+	assert.Error(t, er.compile())
+}
+
+func TestEventRequest_NotifyEventsWithLogs(t *testing.T) {
+	blk, sm := newEventBlock()
+
+	er := newEventRequest(t, `{
+		"height": "0xa",
+		"eventFilters": [
+			{ "event": "Approval(Address,int)" },
+			{ "event": "Transfer(Address,Address,int)" }
+		],
+		"logs": true
+	}`)
+	ens := collectEvents(t, er, blk, sm)
+	if assert.Len(t, ens, 2) {
+		if assert.Len(t, ens[0].Logs, 2) {
+			bs, err := json.Marshal(ens[0].Logs)
+			assert.NoError(t, err)
+			var logs []struct {
+				Indexed []string `json:"indexed"`
+				Data    []string `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(bs, &logs))
+			assert.Equal(t, string(tTransfer), logs[0].Indexed[0])
+			assert.Equal(t, []string{"0x10"}, logs[0].Data)
+			assert.Equal(t, string(tApproval), logs[1].Indexed[0])
+			assert.Equal(t, []string{"0x20"}, logs[1].Data)
+		}
+		assert.Len(t, ens[1].Logs, 1)
+	}
+}
+
+func TestEventRequest_Progress(t *testing.T) {
+	er := newEventRequest(t, `{
+		"height": "0xa",
+		"event": "Transfer(Address,Address,int)"
+	}`)
+	assert.False(t, er.needProgress(10, 10))
+	assert.False(t, er.needProgress(1000, 10))
+
+	er = newEventRequest(t, `{
+		"height": "0xa",
+		"event": "Transfer(Address,Address,int)",
+		"progressInterval": "0x5"
+	}`)
+	var progress []int64
+	last := int64(10)
+	for h := int64(11); h <= 22; h++ {
+		if er.needProgress(h, last) {
+			progress = append(progress, h)
+			last = h
+		}
+	}
+	assert.Equal(t, []int64{15, 20}, progress)
+
+	bs, err := json.Marshal(&ProgressNotification{
+		Progress: common.HexInt64{Value: 20},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"progress":"0x14"}`, string(bs))
+
+	er = new(EventRequest)
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"event": "Transfer(Address,Address,int)",
+		"progressInterval": "-0x1"
+	}`), er))
+	assert.Error(t, er.compile())
+}
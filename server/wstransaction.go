@@ -0,0 +1,149 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/jsonrpc"
+)
+
+const configTxNotificationBuffer = 1024
+
+type TransactionRequest struct {
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	DataType *string         `json:"dataType,omitempty"`
+	Full     bool            `json:"full,omitempty"`
+	Drops    bool            `json:"drops,omitempty"`
+}
+
+type TransactionNotification struct {
+	Hash        common.HexBytes `json:"hash"`
+	Dropped     bool            `json:"dropped,omitempty"`
+	Reason      string          `json:"reason,omitempty"`
+	Transaction interface{}     `json:"transaction,omitempty"`
+}
+
+type txTo interface {
+	To() module.Address
+}
+
+func (r *TransactionRequest) match(tx module.Transaction) bool {
+	if r.From != nil && !r.From.Equal(tx.From()) {
+		return false
+	}
+	if r.To != nil {
+		if ttx, ok := tx.(txTo); !ok || !r.To.Equal(ttx.To()) {
+			return false
+		}
+	}
+	if r.DataType != nil {
+		js, err := tx.ToJSON(module.JSONVersionLast)
+		if err != nil {
+			return false
+		}
+		jso, ok := js.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		dt, _ := jso["dataType"].(string)
+		if dt != *r.DataType {
+			return false
+		}
+	}
+	return true
+}
+
+// txMonitor delivers notifications of the transaction pool to the session.
+// It never blocks the pool. If the session can't follow notifications,
+// it closes overflow channel to finish the session.
+type txMonitor struct {
+	req      *TransactionRequest
+	ch       chan *TransactionNotification
+	overflow chan struct{}
+	once     sync.Once
+}
+
+func (m *txMonitor) notify(tx module.Transaction, dropped bool, reason error) {
+	if !m.req.match(tx) {
+		return
+	}
+	tn := &TransactionNotification{
+		Hash:    tx.ID(),
+		Dropped: dropped,
+	}
+	if reason != nil {
+		tn.Reason = reason.Error()
+	}
+	if m.req.Full {
+		if js, err := tx.ToJSON(module.JSONVersionLast); err == nil {
+			tn.Transaction = js
+		}
+	}
+	select {
+	case m.ch <- tn:
+	default:
+		m.once.Do(func() {
+			close(m.overflow)
+		})
+	}
+}
+
+func (m *txMonitor) OnTransactionAdded(tx module.Transaction) {
+	m.notify(tx, false, nil)
+}
+
+func (m *txMonitor) OnTransactionDropped(tx module.Transaction, reason error) {
+	if m.req.Drops {
+		m.notify(tx, true, reason)
+	}
+}
+
+func (wm *wsSessionManager) RunTransactionSession(ctx echo.Context) error {
+	var tr TransactionRequest
+	wss, err := wm.initSession(ctx, &tr)
+	if err != nil {
+		return err
+	}
+	defer wm.StopSession(wss)
+
+	sm := wss.chain.ServiceManager()
+	if sm == nil {
+		_ = wss.response(int(jsonrpc.ErrorCodeServer), "Stopped")
+		return nil
+	}
+
+	tm := &txMonitor{
+		req:      &tr,
+		ch:       make(chan *TransactionNotification, configTxNotificationBuffer),
+		overflow: make(chan struct{}),
+	}
+	sm.AddTransactionMonitor(tm)
+	defer sm.RemoveTransactionMonitor(tm)
+
+	_ = wss.response(0, "")
+
+	ech := make(chan error)
+	go readLoop(wss.c, ech)
+
+loop:
+	for {
+		select {
+		case err = <-ech:
+			break loop
+		case <-tm.overflow:
+			_ = wss.response(int(jsonrpc.ErrorLackOfResource), "too many notifications")
+			break loop
+		case tn := <-tm.ch:
+			if err = wss.WriteJSON(tn); err != nil {
+				wm.logger.Infof("fail to write json TransactionNotification err:%+v\n", err)
+				break loop
+			}
+		}
+	}
+	wm.logger.Warnf("%+v\n", err)
+	return nil
+}
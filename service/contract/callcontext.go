@@ -115,9 +115,37 @@ func (cc *callContext) pushFrame(handler ContractHandler, limit *big.Int) *callF
 		frame.snapshot = cc.GetSnapshot()
 	}
 	cc.frame = frame
+	cc.traceFrameEnter(handler, limit)
 	return frame
 }
 
+type traceableHandler interface {
+	traceInfo() (from, to module.Address, value *big.Int, method string, params interface{})
+}
+
+func (cc *callContext) traceFrameEnter(handler ContractHandler, limit *big.Int) {
+	if !cc.log.IsFrameTrace() {
+		return
+	}
+	if th, ok := handler.(traceableHandler); ok {
+		from, to, value, method, params := th.traceInfo()
+		cc.log.TFrameEnter(from, to, value, limit, method, params)
+	} else {
+		cc.log.TFrameEnter(nil, nil, nil, limit, "", nil)
+	}
+}
+
+func (cc *callContext) traceFrameExit(status error, stepUsed *big.Int, result *codec.TypedObj) {
+	if !cc.log.IsFrameTrace() {
+		return
+	}
+	var obj interface{}
+	if status == nil && result != nil {
+		obj, _ = common.DecodeAnyForJSON(result)
+	}
+	cc.log.TFrameExit(status, stepUsed, obj)
+}
+
 func (cc *callContext) popFrame(success bool) *callFrame {
 	cc.lock.Lock()
 	defer cc.lock.Unlock()
@@ -238,9 +266,11 @@ func (cc *callContext) cleanUpFrames(target *callFrame, err error) {
 	l := common.Lock(&cc.lock)
 	defer l.Unlock()
 	achs := make([]AsyncContractHandler, 0, 16)
+	frames := make([]*callFrame, 0, 16)
 	for cc.frame != nil && cc.frame.handler != nil {
 		frame := cc.frame
 		cc.frame = frame.parent
+		frames = append(frames, frame)
 		if ach, ok := frame.handler.(AsyncContractHandler); ok {
 			achs = append(achs, ach)
 		}
@@ -250,6 +280,10 @@ func (cc *callContext) cleanUpFrames(target *callFrame, err error) {
 	}
 	l.Unlock()
 
+	for _, frame := range frames {
+		cc.traceFrameExit(err, frame.getStepUsed(), nil)
+	}
+
 	if !target.isQuery {
 		cc.Reset(target.snapshot)
 	}
@@ -274,6 +308,7 @@ func (cc *callContext) handleResult(target *callFrame, status error, result *cod
 	if current == nil {
 		return false
 	}
+	cc.traceFrameExit(status, current.getStepUsed(), result)
 
 	if ach, ok := current.handler.(AsyncContractHandler); ok {
 		ach.Dispose()
@@ -325,6 +360,7 @@ func (cc *callContext) OnEvent(addr module.Address, indexed, data [][]byte) {
 		addr, indexed[0],
 		common.SliceOfHexBytes(indexed[1:]),
 		common.SliceOfHexBytes(data))
+	cc.log.TEvent(addr, indexed, data)
 	if err := cc.addLogToFrame(addr, indexed, data); err != nil {
 		cc.log.Errorf("Fail to log err=%+v", err)
 	}
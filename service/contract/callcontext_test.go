@@ -156,10 +156,6 @@ func (h *commonHandler) ResetSteps(*big.Int) {
 	panic("implement me")
 }
 
-func (h *commonHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	panic("implement me")
-}
-
 func (h *commonHandler) ResetLogger(logger log.Logger) {
 	// do nothing
 }
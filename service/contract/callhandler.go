@@ -77,36 +77,6 @@ func newCallHandlerWithTypedObj(ch *CommonHandler, method string,
 	}
 }
 
-func (h *CallHandler) prepareWorldContextAndAccount(ctx Context) (state.WorldContext, state.AccountState) {
-	lq := []state.LockRequest{
-		{string(h.to.ID()), state.AccountWriteLock},
-		{string(h.from.ID()), state.AccountWriteLock},
-	}
-	wc := ctx.GetFuture(lq)
-	wc.WorldVirtualState().Ensure()
-
-	as := wc.GetAccountState(h.to.ID())
-
-	info, err := as.APIInfo()
-	if err != nil || info == nil {
-		return wc, as
-	}
-
-	method := info.GetMethod(h.name)
-	if method == nil || method.IsIsolated() {
-		return wc, as
-	}
-
-	// Making new world context with locking the world
-	lq = []state.LockRequest{
-		{state.WorldIDStr, state.AccountWriteLock},
-	}
-	wc = ctx.GetFuture(lq)
-	as = wc.GetAccountState(h.to.ID())
-
-	return wc, as
-}
-
 func (h *CallHandler) prepareContractStore(ctx Context, wc state.WorldContext, c state.Contract) error {
 	h.lock.Lock()
 	defer h.lock.Unlock()
@@ -118,18 +88,6 @@ func (h *CallHandler) prepareContractStore(ctx Context, wc state.WorldContext, c
 	return nil
 }
 
-func (h *CallHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	wc, as := h.prepareWorldContextAndAccount(ctx)
-
-	c := h.contract(as)
-	if c == nil {
-		return wc, nil
-	}
-	h.prepareContractStore(ctx, wc, c)
-
-	return wc, nil
-}
-
 func (h *CallHandler) contract(as state.AccountState) state.Contract {
 	if as == nil || !as.IsContract() {
 		return nil
@@ -451,6 +409,15 @@ func (h *CallHandler) OnCall(from, to module.Address, value,
 	h.cc.OnCall(h.cm.GetCallHandler(from, to, value, method, params), limit)
 }
 
+func (h *CallHandler) traceInfo() (from, to module.Address, value *big.Int, method string, params interface{}) {
+	if h.paramObj != nil {
+		params, _ = common.DecodeAnyForJSON(h.paramObj)
+	} else if len(h.params) > 0 {
+		params = json.RawMessage(h.params)
+	}
+	return h.from, h.to, h.value, h.name, params
+}
+
 func (h *CallHandler) OnAPI(status error, info *scoreapi.Info) {
 	h.log.Panicln("Unexpected OnAPI() call")
 }
@@ -479,14 +446,6 @@ type TransferAndCallHandler struct {
 	*CallHandler
 }
 
-func (h *TransferAndCallHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	if h.to.IsContract() {
-		return h.CallHandler.Prepare(ctx)
-	} else {
-		return h.th.Prepare(ctx)
-	}
-}
-
 func (h *TransferAndCallHandler) ExecuteAsync(cc CallContext) error {
 	as := cc.GetAccountState(h.to.ID())
 	apiInfo, err := as.APIInfo()
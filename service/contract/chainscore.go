@@ -416,6 +416,27 @@ var chainMethods = []*chainMethod{
 		},
 		nil,
 	}, module.Revision7, 0},
+	{scoreapi.Method{scoreapi.Function, "addPriorityAccount",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil},
+		},
+		nil,
+	}, module.Revision9, 0},
+	{scoreapi.Method{scoreapi.Function, "removePriorityAccount",
+		scoreapi.FlagExternal, 1,
+		[]scoreapi.Parameter{
+			{"address", scoreapi.Address, nil},
+		},
+		nil,
+	}, module.Revision9, 0},
+	{scoreapi.Method{scoreapi.Function, "getPriorityAccounts",
+		scoreapi.FlagReadOnly, 0,
+		nil,
+		[]scoreapi.DataType{
+			scoreapi.List,
+		},
+	}, module.Revision9, 0},
 	{scoreapi.Method{scoreapi.Function, "getServiceConfig",
 		scoreapi.FlagReadOnly, 0,
 		nil,
@@ -1266,6 +1287,53 @@ func (s *ChainScore) Ex_getDeployers() ([]interface{}, error) {
 	return deployers, nil
 }
 
+func (s *ChainScore) Ex_addPriorityAccount(address module.Address) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	db := scoredb.NewArrayDB(as, state.VarPriorityAccounts)
+	for i := 0; i < db.Size(); i++ {
+		if db.Get(i).Address().Equal(address) == true {
+			return nil
+		}
+	}
+	return db.Put(address)
+}
+
+func (s *ChainScore) Ex_removePriorityAccount(address module.Address) error {
+	if err := s.checkGovernance(true); err != nil {
+		return err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	db := scoredb.NewArrayDB(as, state.VarPriorityAccounts)
+	for i := 0; i < db.Size(); i++ {
+		if db.Get(i).Address().Equal(address) == true {
+			rAddr := db.Pop().Address()
+			if i < db.Size() { // addr is not rAddr
+				if err := db.Set(i, rAddr); err != nil {
+					return err
+				}
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func (s *ChainScore) Ex_getPriorityAccounts() ([]interface{}, error) {
+	if err := s.tryChargeCall(); err != nil {
+		return nil, err
+	}
+	as := s.cc.GetAccountState(state.SystemID)
+	db := scoredb.NewArrayDB(as, state.VarPriorityAccounts)
+	accounts := make([]interface{}, db.Size())
+	for i := 0; i < db.Size(); i++ {
+		accounts[i] = db.Get(i).Address()
+	}
+	return accounts, nil
+}
+
 func (s *ChainScore) Ex_setDeployerWhiteListEnabled(yn bool) error {
 	if err := s.checkGovernance(true); err != nil {
 		return err
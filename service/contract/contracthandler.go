@@ -25,7 +25,6 @@ const (
 
 type (
 	ContractHandler interface {
-		Prepare(ctx Context) (state.WorldContext, error)
 		ResetLogger(logger log.Logger)
 	}
 
@@ -57,14 +56,6 @@ func newCommonHandler(from, to module.Address, value *big.Int, log log.Logger) *
 		log: trace.LoggerOf(log)}
 }
 
-func (h *CommonHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	lq := []state.LockRequest{
-		{string(h.from.ID()), state.AccountWriteLock},
-		{string(h.to.ID()), state.AccountWriteLock},
-	}
-	return ctx.GetFuture(lq), nil
-}
-
 func (h *CommonHandler) Logger() log.Logger {
 	return h.log
 }
@@ -72,3 +63,8 @@ func (h *CommonHandler) Logger() log.Logger {
 func (h *CommonHandler) ResetLogger(logger log.Logger) {
 	h.log = trace.LoggerOf(logger)
 }
+
+// traceInfo returns information of the handler for the structured trace.
+func (h *CommonHandler) traceInfo() (from, to module.Address, value *big.Int, method string, params interface{}) {
+	return h.from, h.to, h.value, "", nil
+}
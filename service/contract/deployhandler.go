@@ -107,13 +107,6 @@ func genContractAddr(from module.Address, timestamp int64, nonce *big.Int) []byt
 	return addr
 }
 
-func (h *DeployHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	lq := []state.LockRequest{
-		{state.WorldIDStr, state.AccountWriteLock},
-	}
-	return ctx.GetFuture(lq), nil
-}
-
 func (h *DeployHandler) ExecuteSync(cc CallContext) (error, *codec.TypedObj, module.Address) {
 	h.log = trace.LoggerOf(cc.Logger())
 	sysAs := cc.GetAccountState(state.SystemID)
@@ -215,12 +208,6 @@ func newAcceptHandler(ch *CommonHandler, txHash []byte, auditTxHash []byte) *Acc
 		txHash:        txHash, auditTxHash: auditTxHash}
 }
 
-// It's never called
-func (h *AcceptHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	lq := []state.LockRequest{{state.WorldIDStr, state.AccountWriteLock}}
-	return ctx.GetFuture(lq), nil
-}
-
 const (
 	deployUpdate = "on_update"
 )
@@ -305,12 +292,6 @@ func newCallGetAPIHandler(ch *CommonHandler) *callGetAPIHandler {
 	return &callGetAPIHandler{CommonHandler: ch, disposed: false}
 }
 
-// It's never called
-func (h *callGetAPIHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	h.log.Panicf("SHOULD not reach here")
-	return nil, nil
-}
-
 func (h *callGetAPIHandler) ExecuteAsync(cc CallContext) error {
 	h.cc = cc
 	h.log = trace.LoggerOf(cc.Logger())
@@ -0,0 +1,43 @@
+package contract
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/trace"
+)
+
+// OverrideCode replaces the code of the account with the code. It keeps
+// the storage of the account, and it doesn't call on_install or on_update
+// of the contract. It's only for simulations on the state to be discarded.
+func OverrideCode(ctx Context, addr module.Address, contentType string, code []byte) error {
+	if !addr.IsContract() {
+		return errors.IllegalArgumentError.Errorf("NotContractAddress(%s)", addr)
+	}
+	eeType := state.EETypeFromContentType(contentType)
+	if eeType == "" {
+		return errors.IllegalArgumentError.Errorf(
+			"InvalidContentType(%s)", contentType)
+	}
+	as := ctx.GetAccountState(addr.ID())
+	if !as.IsContract() {
+		as.InitContractAccount(state.SystemAddress)
+	}
+	txHash := crypto.SHA3Sum256(append(addr.Bytes(), code...))
+	if _, err := as.DeployContract(code, eeType, contentType, nil, txHash); err != nil {
+		return err
+	}
+
+	cc := NewCallContext(ctx, ctx.GetStepLimit(state.StepLimitTypeInvoke), false)
+	defer cc.Dispose()
+	logger := trace.LoggerOf(cc.Logger())
+	h := newCallGetAPIHandler(
+		newCommonHandler(as.ContractOwner(), addr, big.NewInt(0), logger))
+	if status, _, _, _ := cc.Call(h, cc.StepAvailable()); status != nil {
+		return status
+	}
+	return as.AcceptContract(txHash, txHash)
+}
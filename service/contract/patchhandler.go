@@ -20,16 +20,6 @@ type patchHandler struct {
 	patch *Patch
 }
 
-func (h *patchHandler) Prepare(ctx Context) (state.WorldContext, error) {
-	lq := []state.LockRequest{
-		{state.WorldIDStr, state.AccountWriteLock},
-	}
-	wc := ctx.GetFuture(lq)
-	wc.WorldVirtualState().Ensure()
-
-	return wc, nil
-}
-
 func RoundLimitFactorToRound(validator int, factor int64) int64 {
 	return (int64(validator)*factor + 2) / 3
 }
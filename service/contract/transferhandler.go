@@ -48,6 +48,7 @@ func (h *TransferHandler) ExecuteSync(cc CallContext) (error, *codec.TypedObj, m
 
 	h.log.TSystemf("TRANSFER from=%s to=%s value=%s",
 		h.from, h.to, h.value)
+	h.log.TTransfer(h.from, h.to, h.value)
 
 	if h.from.IsContract() {
 		if !h.to.IsContract() && !cc.ApplySteps(state.StepTypeContractCall, 1) {
@@ -30,6 +30,10 @@ func (da *databaseAdaptor) GetBucket(id db.BucketID) (db.Bucket, error) {
 	}
 }
 
+func (da *databaseAdaptor) NewBatch() db.Batch {
+	panic("Not allowed")
+}
+
 func (da *databaseAdaptor) Close() error {
 	panic("Not allowed to close database")
 	return nil
@@ -75,6 +79,10 @@ func (ba *bucketAdaptor) Delete(key []byte) error {
 	panic("Now allowed")
 }
 
+func (ba *bucketAdaptor) NewIterator(r *db.Range) db.Iterator {
+	return ba.bucket.NewIterator(r)
+}
+
 func newBucketAdaptor(da *databaseAdaptor, bk db.Bucket) *bucketAdaptor {
 	return &bucketAdaptor{
 		database: da,
@@ -15,6 +15,11 @@ const (
 	NotContractAddressError
 	InvalidPatchDataError
 	CommittedTransactionError
+	PrunedStateError
+	DuplicateNonceError
+	ReplacedTransactionError
+	SenderQuotaExceededError
+	TransactionRateLimitedError
 )
 
 var (
@@ -24,4 +29,6 @@ var (
 	ErrTransitionInterrupted   = errors.NewBase(TransitionInterruptedError, "TransitionInterrupted")
 	ErrInvalidTransaction      = errors.NewBase(InvalidTransactionError, "InvalidTransaction")
 	ErrCommittedTransaction    = errors.NewBase(CommittedTransactionError, "CommittedTransaction")
+	ErrEvictedTransaction      = errors.NewBase(errors.InvalidStateError, "EvictedTransaction")
+	ErrTransactionRateLimited  = errors.NewBase(TransactionRateLimitedError, "TransactionRateLimited")
 )
@@ -50,6 +50,8 @@ type manager struct {
 	trc       *transitionResultCache
 	tsc       *TxTimestampChecker
 	syncer    *ssync.Manager
+	eli       *txresult.EventLogIndex
+	journal   *txJournal
 
 	log log.Logger
 
@@ -79,9 +81,28 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 		chain.PatchTxPoolSize(), bk, pMetric, logger)
 	nTxPool := NewTransactionPool(module.TransactionGroupNormal,
 		chain.NormalTxPoolSize(), bk, nMetric, logger)
+	nTxPool.SetSenderQuota(chain.SenderTxPoolSize(), chain.SenderTxBytes())
+	if err := nTxPool.SetOrdering(chain.TxOrdering()); err != nil {
+		logger.Warnf("FAIL to set ordering of transactions : %v\n", err)
+		return nil, err
+	}
+	journal, err := newTxJournal(chain.Database(), logger)
+	if err != nil {
+		logger.Warnf("FAIL to create journal of transactions : %v\n", err)
+		return nil, err
+	}
+	pTxPool.SetJournal(journal)
+	nTxPool.SetJournal(journal)
 	tsc := NewTimestampChecker()
 	tm := NewTransactionManager(chain.NID(), tsc, pTxPool, nTxPool, bk, logger)
 	syncm := ssync.NewSyncManager(chain.Database(), chain.NetworkManager(), logger)
+	var eli *txresult.EventLogIndex
+	if chain.EventLogIndex() {
+		if eli, err = txresult.NewEventLogIndex(chain.Database()); err != nil {
+			logger.Warnf("FAIL to create eventLogIndex : %v\n", err)
+			return nil, err
+		}
+	}
 
 	mgr := &manager{
 		patchMetric:  pMetric,
@@ -92,6 +113,8 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 		cm:           cm,
 		eem:          eem,
 		syncer:       syncm,
+		eli:          eli,
+		journal:      journal,
 		trc: newTransitionResultCache(chain.Database(),
 			ConfigTransitionResultCacheEntryCount,
 			ConfigTransitionResultCacheEntrySize,
@@ -100,17 +123,54 @@ func NewManager(chain module.Chain, nm module.NetworkManager,
 		tsc: tsc,
 	}
 	if nm != nil {
-		mgr.txReactor = NewTransactionReactor(nm, tm)
+		mgr.txReactor = NewTransactionReactor(nm, tm, chain.PeerTxRate())
 	}
 	return mgr, nil
 }
 
 func (m *manager) Start() {
+	if wss, bi := m.lastState(); wss != nil {
+		m.tm.SetFinalizedState(state.NewWorldContext(
+			state.NewReadOnlyWorldState(wss), bi))
+		m.reloadTransactions(wss, bi)
+	}
 	if m.txReactor != nil {
 		m.txReactor.Start(m.chain.Wallet())
 	}
 }
 
+// lastState returns the world snapshot with the result of the last block,
+// which is the state finalized before the start.
+func (m *manager) lastState() (state.WorldSnapshot, module.BlockInfo) {
+	blk, err := m.chain.BlockManager().GetLastBlock()
+	if err != nil {
+		m.log.Warnf("FAIL to get last block : %v\n", err)
+		return nil, nil
+	}
+	wss, err := m.queryWorldSnapshot(blk.Result(), nil)
+	if err != nil {
+		m.log.Warnf("FAIL to get state of last block : %v\n", err)
+		return nil, nil
+	}
+	return wss, common.NewBlockInfo(blk.Height(), blk.Timestamp())
+}
+
+// reloadTransactions adds transactions in the journal to the pools. They
+// are validated with the result of the last block.
+func (m *manager) reloadTransactions(wss state.WorldSnapshot, bi module.BlockInfo) {
+	if m.journal.IsEmpty() {
+		return
+	}
+	ws, err := state.WorldStateFromSnapshot(wss)
+	if err != nil {
+		m.log.Warnf("FAIL to get state for journaled transactions : %v\n", err)
+		return
+	}
+	if n := m.tm.Reload(state.NewWorldContext(ws, bi), m.journal); n > 0 {
+		m.log.Infof("Reload journaled transactions count=%d", n)
+	}
+}
+
 func (m *manager) Term() {
 	if m.txReactor != nil {
 		m.txReactor.Stop()
@@ -279,6 +339,15 @@ func (m *manager) Finalize(t module.Transition, opt int) error {
 				return err
 			}
 			m.tm.NotifyFinalized(tst.patchTransactions, tst.patchReceipts, tst.normalTransactions, tst.normalReceipts)
+			m.tm.SetFinalizedState(state.NewWorldContext(
+				state.NewReadOnlyWorldState(tst.worldSnapshot), tst.bi))
+			if m.eli != nil && tst.normalReceipts != nil {
+				// failure breaks only the range covered by the index.
+				if err := m.eli.Add(tst.bi.Height(), tst.normalReceipts); err != nil {
+					m.log.Warnf("FAIL to index event logs height=%d err=%+v",
+						tst.bi.Height(), err)
+				}
+			}
 			now := time.Now()
 			m.patchMetric.OnFinalize(tst.patchTransactions.Hash(), now)
 			m.normalMetric.OnFinalize(tst.normalTransactions.Hash(), now)
@@ -393,6 +462,30 @@ func (m *manager) WaitTransactionResult(id []byte) (<-chan interface{}, error) {
 	return m.tm.WaitResult(id)
 }
 
+func (m *manager) AddTransactionMonitor(tm module.TransactionMonitor) {
+	m.tm.AddMonitor(tm)
+}
+
+func (m *manager) RemoveTransactionMonitor(tm module.TransactionMonitor) {
+	m.tm.RemoveMonitor(tm)
+}
+
+func (m *manager) GetPoolTransactions(g module.TransactionGroup, offset, limit int) ([]module.Transaction, int) {
+	return m.tm.PoolTransactions(g, offset, limit)
+}
+
+func (m *manager) GetPoolStatus(g module.TransactionGroup) *module.TransactionPoolStatus {
+	return m.tm.PoolStatus(g)
+}
+
+func (m *manager) GetTransactionDrop(id []byte) (*module.TransactionDrop, error) {
+	return m.tm.GetDrop(id)
+}
+
+func (m *manager) EvictTransactions(ids [][]byte) [][]byte {
+	return m.tm.Evict(ids)
+}
+
 func (m *manager) SendTransaction(txi interface{}) ([]byte, error) {
 	newTx, err := newTransaction(txi)
 	if err != nil {
@@ -429,7 +522,7 @@ func (m *manager) Call(resultHash []byte,
 	}
 
 	var wc state.WorldContext
-	if wss, err := m.trc.GetWorldSnapshot(resultHash, vl.Hash()); err == nil {
+	if wss, err := m.queryWorldSnapshot(resultHash, vl.Hash()); err == nil {
 		ws := state.NewReadOnlyWorldState(wss)
 		wc = state.NewWorldContext(ws, bi)
 	} else {
@@ -448,8 +541,17 @@ func (m *manager) ValidatorListFromHash(hash []byte) module.ValidatorList {
 	return valList
 }
 
+// queryWorldSnapshot returns the world snapshot of the result for queries.
+// It fails with PrunedStateError if the state is not available.
+func (m *manager) queryWorldSnapshot(result []byte, vh []byte) (state.WorldSnapshot, error) {
+	if err := m.trc.CheckState(result); err != nil {
+		return nil, err
+	}
+	return m.trc.GetWorldSnapshot(result, vh)
+}
+
 func (m *manager) GetBalance(result []byte, addr module.Address) (*big.Int, error) {
-	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	wss, err := m.queryWorldSnapshot(result, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -460,8 +562,20 @@ func (m *manager) GetBalance(result []byte, addr module.Address) (*big.Int, erro
 	return ass.GetBalance(), nil
 }
 
+func (m *manager) GetNonce(result []byte, addr module.Address) (*big.Int, error) {
+	wss, err := m.queryWorldSnapshot(result, nil)
+	if err != nil {
+		return nil, err
+	}
+	ass := wss.GetAccountSnapshot(addr.ID())
+	if ass == nil {
+		return big.NewInt(0), nil
+	}
+	return ass.GetNonce(), nil
+}
+
 func (m *manager) GetTotalSupply(result []byte) (*big.Int, error) {
-	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	wss, err := m.queryWorldSnapshot(result, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -490,7 +604,7 @@ func (m *manager) GetNetworkID(result []byte) (int64, error) {
 }
 
 func (m *manager) GetChainID(result []byte) (int64, error) {
-	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	wss, err := m.queryWorldSnapshot(result, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -507,7 +621,7 @@ func (m *manager) GetAPIInfo(result []byte, addr module.Address) (module.APIInfo
 	if !addr.IsContract() {
 		return nil, NotContractAddressError.Errorf("Given Address(%s) isn't contract", addr)
 	}
-	wss, err := m.trc.GetWorldSnapshot(result, nil)
+	wss, err := m.queryWorldSnapshot(result, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -525,6 +639,22 @@ func (m *manager) GetAPIInfo(result []byte, addr module.Address) (module.APIInfo
 	return info, nil
 }
 
+func (m *manager) GetProofForAccount(result []byte, addr module.Address) ([][]byte, error) {
+	wss, err := m.queryWorldSnapshot(result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return state.GetProofForAccount(wss, addr.ID())
+}
+
+func (m *manager) GetProofForStorage(result []byte, addr module.Address, key []byte) ([][]byte, error) {
+	wss, err := m.queryWorldSnapshot(result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return state.GetProofForStorage(wss, addr.ID(), key)
+}
+
 func (m *manager) GetMembers(result []byte) (module.MemberList, error) {
 	wss, err := m.trc.GetWorldSnapshot(result, nil)
 	if err != nil {
@@ -589,6 +719,41 @@ func (m *manager) ExportResult(result []byte, vh []byte, d db.Database) error {
 	return e.Run()
 }
 
+func (m *manager) ExportWorldState(result []byte, d db.Database) error {
+	if len(result) == 0 {
+		return nil
+	}
+	r, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return err
+	}
+	e := merkle.NewCopyContext(m.db, d)
+	if _, err := state.NewWorldSnapshotWithBuilder(e.Builder(), r.StateHash, nil); err != nil {
+		return err
+	}
+	return e.Run()
+}
+
+func (m *manager) ExportReceipts(result []byte, d db.Database) error {
+	if len(result) == 0 {
+		return nil
+	}
+	r, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return err
+	}
+	e := merkle.NewCopyContext(m.db, d)
+	txresult.NewReceiptListWithBuilder(e.Builder(), r.NormalReceiptHash)
+	txresult.NewReceiptListWithBuilder(e.Builder(), r.PatchReceiptHash)
+	return e.Run()
+}
+
+func (m *manager) ExportTransactions(hash []byte, d db.Database) error {
+	e := merkle.NewCopyContext(m.db, d)
+	transaction.NewTransactionListWithBuilder(e.Builder(), hash)
+	return e.Run()
+}
+
 func (m *manager) ImportResult(result []byte, vh []byte, src db.Database) error {
 	r, err := newTransitionResultFromBytes(result)
 	if err != nil {
@@ -602,29 +767,45 @@ func (m *manager) ImportResult(result []byte, vh []byte, src db.Database) error
 }
 
 func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, error) {
+	rct, _, err := m.executeTransaction(result, vh, js, bi, false)
+	return rct, err
+}
+
+func (m *manager) ExecuteTransactionWithDiff(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, module.StateDiff, error) {
+	return m.executeTransaction(result, vh, js, bi, true)
+}
+
+func (m *manager) executeTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo, withDiff bool) (module.Receipt, module.StateDiff, error) {
 	tx, err := transaction.NewTransactionFromJSON(js)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if err := tx.Verify(); err != nil && !transaction.InvalidSignatureError.Equals(err) {
-		return nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidTransaction")
+		return nil, nil, scoreresult.InvalidParameterError.Wrap(err, "InvalidTransaction")
 	}
 
 	txh, err := tx.GetHandler(m.cm)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer txh.Dispose()
 
 	var wc state.WorldContext
+	var tracker *state.DiffTracker
 	if wss, err := m.trc.GetWorldSnapshot(result, vh); err == nil {
 		ws, err := state.WorldStateFromSnapshot(wss)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if withDiff {
+			if tracker, err = state.NewDiffTracker(ws); err != nil {
+				return nil, nil, err
+			}
+			tracker.Start()
 		}
 		wc = state.NewWorldContext(ws, bi)
 	} else {
-		return nil, err
+		return nil, nil, err
 	}
 	ctx := contract.NewContext(wc, m.cm, m.eem, m.chain, m.log, nil)
 	ctx.SetTransactionInfo(&state.TransactionInfo{
@@ -637,5 +818,90 @@ func (m *manager) ExecuteTransaction(result []byte, vh []byte, js []byte, bi mod
 	})
 	ctx.UpdateSystemInfo()
 
-	return txh.Execute(ctx, true)
+	rct, err := txh.Execute(ctx, true)
+	if err != nil || tracker == nil {
+		return rct, nil, err
+	}
+	return rct, tracker.Diff(), nil
+}
+
+func (m *manager) SimulateTransactions(result []byte, vh []byte, txs [][]byte, overrides []*module.AccountOverride, bi module.BlockInfo) ([]module.Receipt, error) {
+	txList := make([]transaction.Transaction, 0, len(txs))
+	for i, js := range txs {
+		tx, err := transaction.NewTransactionFromJSON(js)
+		if err != nil {
+			return nil, scoreresult.InvalidParameterError.Wrapf(err, "InvalidTransaction(idx=%d)", i)
+		}
+		if err := tx.Verify(); err != nil && !transaction.InvalidSignatureError.Equals(err) {
+			return nil, scoreresult.InvalidParameterError.Wrapf(err, "InvalidTransaction(idx=%d)", i)
+		}
+		txList = append(txList, tx)
+	}
+
+	wss, err := m.queryWorldSnapshot(result, vh)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := state.WorldStateFromSnapshot(wss)
+	if err != nil {
+		return nil, err
+	}
+	wc := state.NewWorldContext(ws, bi)
+	ctx := contract.NewContext(wc, m.cm, m.eem, m.chain, m.log, nil)
+	ctx.UpdateSystemInfo()
+
+	for _, o := range overrides {
+		if err := applyAccountOverride(ctx, o); err != nil {
+			return nil, err
+		}
+	}
+
+	rcts := make([]module.Receipt, 0, len(txList))
+	for i, tx := range txList {
+		txh, err := tx.GetHandler(m.cm)
+		if err != nil {
+			return nil, err
+		}
+		ctx.SetTransactionInfo(&state.TransactionInfo{
+			Group:     module.TransactionGroupNormal,
+			Index:     int32(i),
+			Hash:      tx.ID(),
+			From:      tx.From(),
+			Timestamp: tx.Timestamp(),
+			Nonce:     tx.Nonce(),
+		})
+		ctx.UpdateSystemInfo()
+		rct, err := txh.Execute(ctx, true)
+		txh.Dispose()
+		if err != nil {
+			return nil, err
+		}
+		rcts = append(rcts, rct)
+	}
+	return rcts, nil
+}
+
+func applyAccountOverride(ctx contract.Context, o *module.AccountOverride) error {
+	as := ctx.GetAccountState(o.Address.ID())
+	if o.Balance != nil {
+		as.SetBalance(o.Balance)
+	}
+	for k, v := range o.Storage {
+		var err error
+		if v == nil {
+			_, err = as.DeleteValue([]byte(k))
+		} else {
+			_, err = as.SetValue([]byte(k), v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if o.Code != nil {
+		if err := contract.OverrideCode(ctx, o.Address, o.ContentType, o.Code); err != nil {
+			return scoreresult.InvalidParameterError.Wrapf(err,
+				"FailToOverrideCode(addr=%s)", o.Address)
+		}
+	}
+	return nil
 }
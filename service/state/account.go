@@ -25,6 +25,7 @@ import (
 const (
 	AccountVersion1 = iota + 1
 	AccountVersion2
+	AccountVersion3
 	AccountVersion = AccountVersion1
 )
 
@@ -34,6 +35,7 @@ type AccountSnapshot interface {
 	trie.Object
 	Version() int
 	GetBalance() *big.Int
+	GetNonce() *big.Int
 	IsContract() bool
 	IsEmpty() bool
 	GetValue(k []byte) ([]byte, error)
@@ -59,9 +61,11 @@ type AccountState interface {
 	Version() int
 	MigrateForRevision(v int) error
 	GetBalance() *big.Int
+	GetNonce() *big.Int
 	IsContract() bool
 	GetValue(k []byte) ([]byte, error)
 	SetBalance(v *big.Int)
+	SetNonce(v *big.Int)
 	SetValue(k, v []byte) ([]byte, error)
 	DeleteValue(k []byte) ([]byte, error)
 	GetSnapshot() AccountSnapshot
@@ -91,6 +95,7 @@ type AccountState interface {
 type accountSnapshotImpl struct {
 	version     int
 	balance     *common.HexInt
+	nonce       *common.HexInt
 	fIsContract bool
 	store       trie.Immutable
 	database    db.Database
@@ -137,6 +142,10 @@ func (s *accountSnapshotImpl) GetBalance() *big.Int {
 	return &s.balance.Int
 }
 
+func (s *accountSnapshotImpl) GetNonce() *big.Int {
+	return nonceOf(s.nonce)
+}
+
 func (s *accountSnapshotImpl) IsContract() bool {
 	return s.fIsContract
 }
@@ -149,7 +158,8 @@ func (s *accountSnapshotImpl) GetValue(k []byte) ([]byte, error) {
 }
 
 func (s *accountSnapshotImpl) IsEmpty() bool {
-	return s.balance.BitLen() == 0 && s.store == nil && s.contractOwner == nil
+	return s.balance.BitLen() == 0 && s.store == nil && s.contractOwner == nil &&
+		nonceOf(s.nonce).Sign() == 0
 }
 
 func (s *accountSnapshotImpl) Bytes() []byte {
@@ -220,6 +230,9 @@ func (s *accountSnapshotImpl) Equal(object trie.Object) bool {
 			s.balance.Cmp(&s2.balance.Int) != 0 || s.state != s2.state {
 			return false
 		}
+		if nonceOf(s.nonce).Cmp(nonceOf(s2.nonce)) != 0 {
+			return false
+		}
 		if s.contractOwner.Equal(s2.contractOwner) == false {
 			return false
 		}
@@ -348,6 +361,15 @@ func (s *accountSnapshotImpl) RLPEncodeSelf(e codec.Encoder) error {
 	); err != nil {
 		return err
 	}
+	if s.version >= AccountVersion3 {
+		nonce := s.nonce
+		if nonce == nil {
+			nonce = common.HexIntZero
+		}
+		if err := e2.Encode(nonce); err != nil {
+			return err
+		}
+	}
 	if s.objGraph != nil {
 		if err := e2.EncodeMulti(
 			s.objGraph.nextHash,
@@ -387,6 +409,13 @@ func (s *accountSnapshotImpl) RLPDecodeSelf(d codec.Decoder) error {
 	); err != nil {
 		return errors.Wrap(err, "Fail to decode accountSnapshot")
 	}
+	if s.version >= AccountVersion3 {
+		if err := d2.Decode(&s.nonce); err != nil {
+			return errors.Wrap(err, "Fail to decode accountSnapshot")
+		}
+	} else {
+		s.nonce = nil
+	}
 
 	if n, err := d2.DecodeMulti(
 		&objGraph.nextHash,
@@ -435,6 +464,7 @@ type accountStateImpl struct {
 	version    int
 	database   db.Database
 	balance    *common.HexInt
+	nonce      *common.HexInt
 	isContract bool
 
 	state         int
@@ -445,6 +475,10 @@ type accountStateImpl struct {
 	store         trie.Mutable
 
 	objGraph *objectGraph
+
+	// written keeps keys of the storage written after tracking is started.
+	// It's nil if it's not tracked.
+	written map[string]struct{}
 }
 
 type objectGraph struct {
@@ -690,6 +724,16 @@ func (s *accountStateImpl) SetBalance(v *big.Int) {
 	s.balance = nv
 }
 
+func (s *accountStateImpl) GetNonce() *big.Int {
+	return nonceOf(s.nonce)
+}
+
+func (s *accountStateImpl) SetNonce(v *big.Int) {
+	nv := new(common.HexInt)
+	nv.Set(v)
+	s.nonce = nv
+}
+
 func (s *accountStateImpl) IsContract() bool {
 	return s.isContract
 }
@@ -720,6 +764,7 @@ func (s *accountStateImpl) GetSnapshot() AccountSnapshot {
 		database:      s.database,
 		version:       s.version,
 		balance:       s.balance,
+		nonce:         s.nonce,
 		fIsContract:   s.isContract,
 		store:         store,
 		state:         s.state,
@@ -747,6 +792,7 @@ func (s *accountStateImpl) Reset(isnapshot AccountSnapshot) error {
 	}
 
 	s.balance = snapshot.balance
+	s.nonce = snapshot.nonce
 	s.isContract = snapshot.fIsContract
 	s.version = snapshot.version
 	s.apiInfo = snapshot.apiInfo
@@ -781,6 +827,7 @@ func (s *accountStateImpl) Reset(isnapshot AccountSnapshot) error {
 
 func (s *accountStateImpl) Clear() {
 	s.balance = common.HexIntZero
+	s.nonce = nil
 	s.isContract = false
 	s.version = AccountVersion
 	s.apiInfo = apiInfoStore{}
@@ -802,6 +849,7 @@ func (s *accountStateImpl) SetValue(k, v []byte) ([]byte, error) {
 		s.store = trie_manager.NewMutable(s.database, nil)
 		s.attachCacheForStore()
 	}
+	s.trackKey(k)
 	return s.store.Set(k, v)
 }
 
@@ -809,9 +857,16 @@ func (s *accountStateImpl) DeleteValue(k []byte) ([]byte, error) {
 	if s.store == nil {
 		return nil, nil
 	}
+	s.trackKey(k)
 	return s.store.Delete(k)
 }
 
+func (s *accountStateImpl) trackKey(k []byte) {
+	if s.written != nil {
+		s.written[string(k)] = struct{}{}
+	}
+}
+
 func (s *accountStateImpl) Contract() Contract {
 	if s.curContract == nil {
 		return nil
@@ -886,6 +941,10 @@ func (a *accountROState) SetBalance(v *big.Int) {
 	log.Panic("accountROState().SetBalance() is invoked")
 }
 
+func (a *accountROState) SetNonce(v *big.Int) {
+	log.Panic("accountROState().SetNonce() is invoked")
+}
+
 func (a *accountROState) SetValue(k, v []byte) ([]byte, error) {
 	return nil, errors.InvalidStateError.New("ReadOnlyState")
 }
@@ -951,7 +1010,18 @@ func accountVersionForRevision(rev int) int {
 	switch {
 	case rev < module.Revision8:
 		return AccountVersion1
-	default:
+	case rev < module.Revision9:
 		return AccountVersion2
+	default:
+		return AccountVersion3
+	}
+}
+
+// nonceOf returns the value of the nonce. Accounts before AccountVersion3
+// don't have the nonce, so it returns zero for them.
+func nonceOf(nonce *common.HexInt) *big.Int {
+	if nonce == nil {
+		return &common.HexIntZero.Int
 	}
+	return &nonce.Int
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
 )
 
 func TestAccountSnapshot_Equal(t *testing.T) {
@@ -76,3 +77,38 @@ func TestAccountSnapshot_Bytes(t *testing.T) {
 	tv2, _ := s2.GetValue(tv)
 	assert.Equal(t, tv, tv2)
 }
+
+func TestAccountSnapshot_Nonce(t *testing.T) {
+	database := db.NewMapDB()
+	as := newAccountState(database, nil, nil, false)
+	as.SetBalance(big.NewInt(3000))
+	s1 := as.GetSnapshot()
+	assert.Equal(t, 0, s1.GetNonce().Sign())
+
+	// accounts before AccountVersion3 don't keep the nonce
+	assert.NoError(t, as.MigrateForRevision(module.Revision8))
+	s2 := new(accountSnapshotImpl)
+	assert.NoError(t, s2.Reset(database, as.GetSnapshot().Bytes()))
+	assert.Equal(t, AccountVersion2, s2.Version())
+	assert.Equal(t, 0, s2.GetNonce().Sign())
+
+	assert.NoError(t, as.MigrateForRevision(module.Revision9))
+	as.SetNonce(big.NewInt(7))
+	s3 := as.GetSnapshot()
+	assert.False(t, s1.Equal(s3))
+	assert.Equal(t, 0, s3.GetNonce().Cmp(big.NewInt(7)))
+
+	serialized := s3.Bytes()
+	s4 := new(accountSnapshotImpl)
+	assert.NoError(t, s4.Reset(database, serialized))
+	assert.Equal(t, serialized, s4.Bytes())
+	assert.Equal(t, AccountVersion3, s4.Version())
+	assert.Equal(t, 0, s4.GetNonce().Cmp(big.NewInt(7)))
+	assert.True(t, s3.Equal(s4))
+
+	as2 := newAccountState(database, nil, nil, false)
+	assert.True(t, as2.GetSnapshot().IsEmpty())
+	assert.NoError(t, as2.MigrateForRevision(module.Revision9))
+	as2.SetNonce(big.NewInt(1))
+	assert.False(t, as2.GetSnapshot().IsEmpty())
+}
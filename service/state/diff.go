@@ -0,0 +1,280 @@
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+// DiffTracker collects changes of the world state made after Start.
+type DiffTracker struct {
+	ws     *worldStateImpl
+	before WorldSnapshot
+}
+
+func worldStateImplOf(ws WorldState) *worldStateImpl {
+	switch o := ws.(type) {
+	case *worldStateImpl:
+		return o
+	case *worldContext:
+		return worldStateImplOf(o.WorldState)
+	default:
+		return nil
+	}
+}
+
+// NewDiffTracker returns a tracker for the world state. The world state
+// should be the one returned by NewWorldState or WorldStateFromSnapshot, or
+// the world context of it.
+func NewDiffTracker(ws WorldState) (*DiffTracker, error) {
+	wsi := worldStateImplOf(ws)
+	if wsi == nil {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"UnsupportedWorldState(type=%T)", ws)
+	}
+	return &DiffTracker{ws: wsi}, nil
+}
+
+// Start takes the snapshot for comparison and starts to track written keys
+// of storages.
+func (t *DiffTracker) Start() {
+	t.before = t.ws.GetSnapshot()
+
+	t.ws.mutex.Lock()
+	defer t.ws.mutex.Unlock()
+
+	t.ws.tracking = true
+	for _, as := range t.ws.mutableAccounts {
+		as.(*accountStateImpl).written = make(map[string]struct{})
+	}
+}
+
+// Diff returns changes of the world state since Start.
+func (t *DiffTracker) Diff() *WorldDiff {
+	if t.before == nil {
+		return &WorldDiff{}
+	}
+	after := t.ws.GetSnapshot()
+
+	type trackedAccount struct {
+		id      []byte
+		as      *accountStateImpl
+		written [][]byte
+	}
+	t.ws.mutex.Lock()
+	accounts := make([]*trackedAccount, 0, len(t.ws.mutableAccounts))
+	for id, as := range t.ws.mutableAccounts {
+		if len(id) == 0 {
+			continue
+		}
+		asi := as.(*accountStateImpl)
+		ta := &trackedAccount{id: []byte(id), as: asi}
+		for k := range asi.written {
+			ta.written = append(ta.written, []byte(k))
+		}
+		accounts = append(accounts, ta)
+	}
+	t.ws.mutex.Unlock()
+
+	diff := new(WorldDiff)
+	for _, ta := range accounts {
+		old, _ := t.before.GetAccountSnapshot(ta.id).(*accountSnapshotImpl)
+		if old == nil {
+			old = newAccountSnapshot(t.ws.database)
+		}
+		cur := ta.as.GetSnapshot().(*accountSnapshotImpl)
+		if ad := diffAccount(ta.id, old, cur, ta.written); ad != nil {
+			diff.Accounts = append(diff.Accounts, ad)
+		}
+	}
+	sort.Slice(diff.Accounts, func(i, j int) bool {
+		return bytes.Compare(diff.Accounts[i].Address.Bytes(),
+			diff.Accounts[j].Address.Bytes()) < 0
+	})
+
+	ov := t.before.GetValidatorSnapshot()
+	nv := after.GetValidatorSnapshot()
+	if !bytes.Equal(ov.Hash(), nv.Hash()) {
+		diff.OldValidators = ov
+		diff.NewValidators = nv
+	}
+	return diff
+}
+
+// StorageDiff is a change of the value for the key. Old or New is nil if
+// the value doesn't exist.
+type StorageDiff struct {
+	Key []byte
+	Old []byte
+	New []byte
+}
+
+// AccountDiff is changes of an account.
+type AccountDiff struct {
+	Address         module.Address
+	Old             AccountSnapshot
+	New             AccountSnapshot
+	BalanceChanged  bool
+	NonceChanged    bool
+	ContractChanged bool
+	Storage         []*StorageDiff
+}
+
+func contractEqual(c1, c2 *contractSnapshotImpl) bool {
+	if c1 == nil || c2 == nil {
+		return c1 == c2
+	}
+	return c1.Equal(c2) && bytes.Equal(c1.deployTxHash, c2.deployTxHash)
+}
+
+func ownerEqual(o1, o2 *common.Address) bool {
+	if o1 == nil || o2 == nil {
+		return o1 == o2
+	}
+	return o1.Equal(o2)
+}
+
+func diffAccount(id []byte, old, cur *accountSnapshotImpl, written [][]byte) *AccountDiff {
+	ad := &AccountDiff{
+		Old: old,
+		New: cur,
+	}
+	if old.fIsContract || cur.fIsContract {
+		ad.Address = common.NewContractAddress(id)
+	} else {
+		ad.Address = common.NewAccountAddress(id)
+	}
+	ad.BalanceChanged = old.balance.Cmp(&cur.balance.Int) != 0
+	ad.NonceChanged = old.GetNonce().Cmp(cur.GetNonce()) != 0
+	ad.ContractChanged = old.fIsContract != cur.fIsContract ||
+		old.state != cur.state ||
+		!ownerEqual(old.contractOwner, cur.contractOwner) ||
+		!contractEqual(old.curContract, cur.curContract) ||
+		!contractEqual(old.nextContract, cur.nextContract)
+
+	sort.Slice(written, func(i, j int) bool {
+		return bytes.Compare(written[i], written[j]) < 0
+	})
+	for _, k := range written {
+		ov, _ := old.GetValue(k)
+		nv, _ := cur.GetValue(k)
+		if !bytes.Equal(ov, nv) {
+			ad.Storage = append(ad.Storage, &StorageDiff{k, ov, nv})
+		}
+	}
+	if !ad.BalanceChanged && !ad.NonceChanged && !ad.ContractChanged &&
+		len(ad.Storage) == 0 {
+		return nil
+	}
+	return ad
+}
+
+// WorldDiff is changes of the world state. OldValidators and NewValidators
+// are nil if validators are not changed.
+type WorldDiff struct {
+	Accounts      []*AccountDiff
+	OldValidators ValidatorSnapshot
+	NewValidators ValidatorSnapshot
+}
+
+func hexBytesOrNil(bs []byte) interface{} {
+	if bs == nil {
+		return nil
+	}
+	return common.HexBytes(bs)
+}
+
+func contractToJSON(c ContractSnapshot) interface{} {
+	if c == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"status":       c.Status().String(),
+		"codeHash":     hexBytesOrNil(c.CodeHash()),
+		"deployTxHash": hexBytesOrNil(c.DeployTxHash()),
+		"auditTxHash":  hexBytesOrNil(c.AuditTxHash()),
+	}
+}
+
+func contractStateToJSON(ass AccountSnapshot) interface{} {
+	if !ass.IsContract() {
+		return nil
+	}
+	return map[string]interface{}{
+		"owner":    ass.ContractOwner(),
+		"disabled": ass.IsDisabled(),
+		"blocked":  ass.IsBlocked(),
+		"current":  contractToJSON(ass.Contract()),
+		"next":     contractToJSON(ass.NextContract()),
+	}
+}
+
+func validatorsToJSON(vss ValidatorSnapshot) []interface{} {
+	validators := make([]interface{}, 0, vss.Len())
+	for i := 0; i < vss.Len(); i++ {
+		if v, ok := vss.Get(i); ok {
+			validators = append(validators, v.Address())
+		}
+	}
+	return validators
+}
+
+func (ad *AccountDiff) ToJSON(version module.JSONVersion) (interface{}, error) {
+	jso := map[string]interface{}{
+		"address": ad.Address,
+	}
+	if ad.BalanceChanged {
+		jso["balance"] = map[string]interface{}{
+			"old": common.NewHexInt(0).Set(ad.Old.GetBalance()),
+			"new": common.NewHexInt(0).Set(ad.New.GetBalance()),
+		}
+	}
+	if ad.NonceChanged {
+		jso["nonce"] = map[string]interface{}{
+			"old": common.NewHexInt(0).Set(ad.Old.GetNonce()),
+			"new": common.NewHexInt(0).Set(ad.New.GetNonce()),
+		}
+	}
+	if ad.ContractChanged {
+		jso["contract"] = map[string]interface{}{
+			"old": contractStateToJSON(ad.Old),
+			"new": contractStateToJSON(ad.New),
+		}
+	}
+	if len(ad.Storage) > 0 {
+		storage := make([]interface{}, 0, len(ad.Storage))
+		for _, sd := range ad.Storage {
+			storage = append(storage, map[string]interface{}{
+				"key": common.HexBytes(sd.Key),
+				"old": hexBytesOrNil(sd.Old),
+				"new": hexBytesOrNil(sd.New),
+			})
+		}
+		jso["storage"] = storage
+	}
+	return jso, nil
+}
+
+func (d *WorldDiff) ToJSON(version module.JSONVersion) (interface{}, error) {
+	accounts := make([]interface{}, 0, len(d.Accounts))
+	for _, ad := range d.Accounts {
+		ajso, err := ad.ToJSON(version)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, ajso)
+	}
+	jso := map[string]interface{}{
+		"accounts": accounts,
+	}
+	if d.OldValidators != nil && d.NewValidators != nil {
+		jso["validators"] = map[string]interface{}{
+			"old": validatorsToJSON(d.OldValidators),
+			"new": validatorsToJSON(d.NewValidators),
+		}
+	}
+	return jso, nil
+}
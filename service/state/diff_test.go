@@ -0,0 +1,87 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestDiffTracker(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil)
+
+	id1 := common.NewAddressFromString("hx0000000000000000000000000000000000000001").ID()
+	id2 := common.NewAddressFromString("hx0000000000000000000000000000000000000002").ID()
+	id3 := common.NewAddressFromString("hx0000000000000000000000000000000000000003").ID()
+
+	as1 := ws.GetAccountState(id1)
+	as1.SetBalance(big.NewInt(100))
+	as1.SetValue([]byte("k1"), []byte("v1"))
+	as1.SetValue([]byte("k2"), []byte("v2"))
+	ws.GetAccountState(id2).SetBalance(big.NewInt(200))
+
+	tracker, err := NewDiffTracker(NewWorldContext(ws, common.NewBlockInfo(1, 0)))
+	if err != nil {
+		t.Fatalf("Fail to create tracker err=%+v", err)
+	}
+	tracker.Start()
+
+	as1.SetBalance(big.NewInt(90))
+	as1.SetValue([]byte("k1"), []byte("v1-new"))
+	as1.DeleteValue([]byte("k2"))
+	as1.SetValue([]byte("k3"), []byte("v3"))
+	as1.SetValue([]byte("k4"), []byte("v4"))
+	as1.DeleteValue([]byte("k4"))
+	ws.GetAccountState(id2).SetBalance(big.NewInt(200))
+	ws.GetAccountState(id3).SetBalance(big.NewInt(10))
+
+	diff := tracker.Diff()
+	if len(diff.Accounts) != 2 {
+		t.Fatalf("Unexpected number of accounts=%d", len(diff.Accounts))
+	}
+	if diff.OldValidators != nil || diff.NewValidators != nil {
+		t.Errorf("Validators shouldn't be changed")
+	}
+
+	for _, ad := range diff.Accounts {
+		switch {
+		case bytes.Equal(ad.Address.ID(), id1):
+			if !ad.BalanceChanged || ad.ContractChanged {
+				t.Errorf("Unexpected changes for account1")
+			}
+			if ad.Old.GetBalance().Int64() != 100 || ad.New.GetBalance().Int64() != 90 {
+				t.Errorf("Unexpected balance old=%s new=%s",
+					ad.Old.GetBalance(), ad.New.GetBalance())
+			}
+			expected := []*StorageDiff{
+				{[]byte("k1"), []byte("v1"), []byte("v1-new")},
+				{[]byte("k2"), []byte("v2"), nil},
+				{[]byte("k3"), nil, []byte("v3")},
+			}
+			if len(ad.Storage) != len(expected) {
+				t.Fatalf("Unexpected storage changes=%d", len(ad.Storage))
+			}
+			for i, sd := range ad.Storage {
+				if !bytes.Equal(sd.Key, expected[i].Key) ||
+					!bytes.Equal(sd.Old, expected[i].Old) ||
+					!bytes.Equal(sd.New, expected[i].New) {
+					t.Errorf("Unexpected storage change key=%q old=%q new=%q",
+						sd.Key, sd.Old, sd.New)
+				}
+			}
+		case bytes.Equal(ad.Address.ID(), id3):
+			if !ad.BalanceChanged || ad.Old.GetBalance().Sign() != 0 {
+				t.Errorf("Unexpected changes for account3")
+			}
+		default:
+			t.Errorf("Unexpected account=%s", ad.Address)
+		}
+	}
+
+	if _, err := diff.ToJSON(0); err != nil {
+		t.Errorf("Fail to make JSON err=%+v", err)
+	}
+}
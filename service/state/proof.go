@@ -0,0 +1,77 @@
+package state
+
+import (
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/trie/trie_manager"
+)
+
+// GetProofForAccount returns the merkle proof of the account in the
+// world state. The last element of the proof includes the encoded account.
+func GetProofForAccount(wss WorldSnapshot, id []byte) ([][]byte, error) {
+	accounts := trie_manager.NewImmutable(wss.Database(), wss.StateHash())
+	proof := accounts.GetProof(addressIDToKey(id))
+	if proof == nil {
+		return nil, errors.NotFoundError.Errorf("NoAccount(id=%x)", id)
+	}
+	return proof, nil
+}
+
+// GetProofForStorage returns the merkle proof of the value for the key
+// in the storage of the account.
+func GetProofForStorage(wss WorldSnapshot, id []byte, key []byte) ([][]byte, error) {
+	ass := wss.GetAccountSnapshot(id)
+	if ass == nil {
+		return nil, errors.NotFoundError.Errorf("NoAccount(id=%x)", id)
+	}
+	s, ok := ass.(*accountSnapshotImpl)
+	if !ok || s.store == nil {
+		return nil, errors.NotFoundError.Errorf("NoStorage(id=%x)", id)
+	}
+	proof := s.store.GetProof(key)
+	if proof == nil {
+		return nil, errors.NotFoundError.Errorf("NoValue(id=%x,key=%x)", id, key)
+	}
+	return proof, nil
+}
+
+// VerifyProofForAccount verifies the proof of the account against the
+// hash of the world state, then it returns the account in the proof.
+// It doesn't require any data other than the proof, so the returned
+// account can't be used for accessing its storage or its contract.
+func VerifyProofForAccount(stateHash []byte, id []byte, proof [][]byte) (AccountSnapshot, error) {
+	accounts := trie_manager.NewImmutable(db.NewMapDB(), stateHash)
+	bs, err := accounts.Prove(addressIDToKey(id), proof)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidProof(id=%x)", id)
+	}
+	ass := newAccountSnapshot(db.NewMapDB())
+	if _, err := codec.BC.UnmarshalFromBytes(bs, ass); err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidAccount(id=%x)", id)
+	}
+	return ass, nil
+}
+
+// VerifyProofForStorage verifies the proof of the value for the key in
+// the storage of the account, which should be returned by
+// VerifyProofForAccount, then it returns the value in the proof.
+func VerifyProofForStorage(ass AccountSnapshot, key []byte, proof [][]byte) ([]byte, error) {
+	s, ok := ass.(*accountSnapshotImpl)
+	if !ok {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"InvalidAccountType(type=%T)", ass)
+	}
+	if s.store == nil {
+		return nil, errors.IllegalArgumentError.New("NoStorage")
+	}
+	store := trie_manager.NewImmutable(db.NewMapDB(), s.store.Hash())
+	value, err := store.Prove(key, proof)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrapf(err,
+			"InvalidProof(key=%x)", key)
+	}
+	return value, nil
+}
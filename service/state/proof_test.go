@@ -0,0 +1,52 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestProofForAccountAndStorage(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil)
+
+	id1 := []byte("account1")
+	id2 := []byte("account2")
+	as1 := ws.GetAccountState(id1)
+	as1.SetBalance(big.NewInt(0x1000))
+	_, err := as1.SetValue([]byte("key1"), []byte("value1"))
+	assert.NoError(t, err)
+	_, err = as1.SetValue([]byte("key2"), []byte("value2"))
+	assert.NoError(t, err)
+	ws.GetAccountState(id2).SetBalance(big.NewInt(0x2000))
+
+	wss := ws.GetSnapshot()
+	assert.NoError(t, wss.Flush())
+	stateHash := wss.StateHash()
+
+	proof, err := GetProofForAccount(wss, id1)
+	assert.NoError(t, err)
+	ass, err := VerifyProofForAccount(stateHash, id1, proof)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ass.GetBalance().Cmp(big.NewInt(0x1000)))
+
+	_, err = VerifyProofForAccount(stateHash, id2, proof)
+	assert.Error(t, err)
+	_, err = VerifyProofForAccount(stateHash, id1, proof[:len(proof)-1])
+	assert.Error(t, err)
+
+	sproof, err := GetProofForStorage(wss, id1, []byte("key2"))
+	assert.NoError(t, err)
+	value, err := VerifyProofForStorage(ass, []byte("key2"), sproof)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+
+	_, err = VerifyProofForStorage(ass, []byte("key3"), sproof)
+	assert.Error(t, err)
+
+	_, err = GetProofForStorage(wss, id2, []byte("key1"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/icon-project/goloop/common/errors"
+)
+
+type accessRecorder struct {
+	base       *worldSnapshotImpl
+	accounts   map[string]struct{}
+	validators bool
+}
+
+func (r *accessRecorder) recordAccount(id string) {
+	if r != nil {
+		r.accounts[id] = struct{}{}
+	}
+}
+
+// ReadWriteSet is a set of accounts accessed by the execution on the world
+// state returned by NewRecordingWorldState.
+type ReadWriteSet struct {
+	// Accessed has IDs of accounts read or written.
+	Accessed map[string]struct{}
+	// Written has snapshots of changed accounts for their IDs.
+	Written map[string]AccountSnapshot
+
+	// ValidatorsAccessed is true if validators are read or written.
+	ValidatorsAccessed bool
+	// Validators is the snapshot of changed validators. It's nil if
+	// validators are not changed.
+	Validators ValidatorSnapshot
+}
+
+// Conflicts returns whether it accessed something written by w.
+func (s *ReadWriteSet) Conflicts(w *ReadWriteSet) bool {
+	if w.Validators != nil && s.ValidatorsAccessed {
+		return true
+	}
+	if len(s.Accessed) < len(w.Written) {
+		for id := range s.Accessed {
+			if _, ok := w.Written[id]; ok {
+				return true
+			}
+		}
+	} else {
+		for id := range w.Written {
+			if _, ok := s.Accessed[id]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewRecordingWorldState returns a world state on the snapshot, which
+// records accessed accounts. Use ReadWriteSetOf to get them.
+func NewRecordingWorldState(wss WorldSnapshot) (WorldState, error) {
+	base, ok := wss.(*worldSnapshotImpl)
+	if !ok {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"UnsupportedWorldSnapshot(type=%T)", wss)
+	}
+	ws, err := WorldStateFromSnapshot(base)
+	if err != nil {
+		return nil, err
+	}
+	ws.(*worldStateImpl).recorder = &accessRecorder{
+		base:     base,
+		accounts: make(map[string]struct{}),
+	}
+	return ws, nil
+}
+
+// ReadWriteSetOf returns accounts accessed on the world state returned by
+// NewRecordingWorldState.
+func ReadWriteSetOf(ws WorldState) (*ReadWriteSet, error) {
+	wsi := worldStateImplOf(ws)
+	if wsi == nil || wsi.recorder == nil {
+		return nil, errors.IllegalArgumentError.Errorf(
+			"NotRecordingWorldState(type=%T)", ws)
+	}
+
+	wsi.mutex.Lock()
+	defer wsi.mutex.Unlock()
+
+	r := wsi.recorder
+	rws := &ReadWriteSet{
+		Accessed:           make(map[string]struct{}, len(r.accounts)),
+		Written:            make(map[string]AccountSnapshot),
+		ValidatorsAccessed: r.validators,
+	}
+	for id := range r.accounts {
+		rws.Accessed[id] = struct{}{}
+		as, ok := wsi.mutableAccounts[id]
+		if !ok {
+			continue
+		}
+		cur := as.GetSnapshot().(*accountSnapshotImpl)
+		old, _ := r.base.GetAccountSnapshot([]byte(id)).(*accountSnapshotImpl)
+		if old == nil {
+			if cur.IsEmpty() {
+				continue
+			}
+		} else if old.Equal(cur) {
+			continue
+		}
+		rws.Written[id] = cur
+	}
+	if r.validators {
+		vss := wsi.validators.GetSnapshot()
+		if !bytes.Equal(vss.Hash(), r.base.GetValidatorSnapshot().Hash()) {
+			rws.Validators = vss
+		}
+	}
+	return rws, nil
+}
+
+// ApplyReadWriteSet applies changes in the set to the world state.
+func ApplyReadWriteSet(ws WorldState, rws *ReadWriteSet) error {
+	for id, ass := range rws.Written {
+		as := ws.GetAccountState([]byte(id))
+		as.Clear()
+		if err := as.Reset(ass); err != nil {
+			return err
+		}
+	}
+	if rws.Validators != nil {
+		ws.GetValidatorState().Reset(rws.Validators)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+)
+
+func TestReadWriteSet(t *testing.T) {
+	database := db.NewMapDB()
+	ws := NewWorldState(database, nil, nil)
+
+	id1 := common.NewAddressFromString("hx0000000000000000000000000000000000000001").ID()
+	id2 := common.NewAddressFromString("hx0000000000000000000000000000000000000002").ID()
+	id3 := common.NewAddressFromString("hx0000000000000000000000000000000000000003").ID()
+
+	ws.GetAccountState(id1).SetBalance(big.NewInt(100))
+	ws.GetAccountState(id2).SetBalance(big.NewInt(200))
+	base := ws.GetSnapshot()
+
+	// tx1 : transfer 10 from id1 to id3
+	ws1, err := NewRecordingWorldState(base)
+	assert.NoError(t, err)
+	as1 := ws1.GetAccountState(id1)
+	as1.SetBalance(new(big.Int).Sub(as1.GetBalance(), big.NewInt(10)))
+	ws1.GetAccountState(id3).SetBalance(big.NewInt(10))
+	rws1, err := ReadWriteSetOf(ws1)
+	assert.NoError(t, err)
+	assert.Len(t, rws1.Accessed, 2)
+	assert.Len(t, rws1.Written, 2)
+	assert.Nil(t, rws1.Validators)
+
+	// tx2 : read id2 and write nothing
+	ws2, err := NewRecordingWorldState(base)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ws2.GetAccountSnapshot(id2).GetBalance().Cmp(big.NewInt(200)))
+	as2 := ws2.GetAccountState(id2)
+	as2.SetBalance(big.NewInt(300))
+	as2.SetBalance(big.NewInt(200))
+	rws2, err := ReadWriteSetOf(ws2)
+	assert.NoError(t, err)
+	assert.Len(t, rws2.Accessed, 1)
+	assert.Len(t, rws2.Written, 0)
+
+	// tx3 : read id3
+	ws3, err := NewRecordingWorldState(base)
+	assert.NoError(t, err)
+	ws3.GetAccountSnapshot(id3)
+	rws3, err := ReadWriteSetOf(ws3)
+	assert.NoError(t, err)
+
+	assert.False(t, rws2.Conflicts(rws1))
+	assert.True(t, rws3.Conflicts(rws1))
+	assert.False(t, rws1.Conflicts(rws2))
+
+	// commit tx1
+	ws4, err := WorldStateFromSnapshot(base)
+	assert.NoError(t, err)
+	assert.NoError(t, ApplyReadWriteSet(ws4, rws1))
+	wss := ws4.GetSnapshot()
+	assert.Equal(t, ws1.GetSnapshot().StateHash(), wss.StateHash())
+	assert.Equal(t, 0, wss.GetAccountSnapshot(id1).GetBalance().Cmp(big.NewInt(90)))
+	assert.Equal(t, 0, wss.GetAccountSnapshot(id3).GetBalance().Cmp(big.NewInt(10)))
+
+	_, err = ReadWriteSetOf(ws4)
+	assert.Error(t, err)
+}
@@ -33,6 +33,7 @@ const (
 	VarRoundLimitFactor   = "round_limit_factor"
 	VarMinimizeBlockGen   = "minimize_block_gen"
 	VarTxHashToAddress    = "tx_to_address"
+	VarPriorityAccounts   = "priority_accounts"
 )
 
 const (
@@ -82,8 +83,6 @@ type WorldContext interface {
 	Governance() module.Address
 	GetInfo() map[string]interface{}
 	WorldStateChanged(ws WorldState) WorldContext
-	WorldVirtualState() WorldVirtualState
-	GetFuture(lq []LockRequest) WorldContext
 	SetTransactionInfo(ti *TransactionInfo)
 	GetTransactionInfo(ti *TransactionInfo) bool
 	SetContractInfo(si *ContractInfo)
@@ -121,7 +120,6 @@ type ContractInfo struct {
 
 type worldContext struct {
 	WorldState
-	virtualState WorldVirtualState
 
 	treasury   module.Address
 	governance module.Address
@@ -137,27 +135,6 @@ type worldContext struct {
 	skipTransaction bool
 }
 
-func (c *worldContext) WorldVirtualState() WorldVirtualState {
-	return c.virtualState
-}
-
-func (c *worldContext) GetFuture(lq []LockRequest) WorldContext {
-	lq2 := make([]LockRequest, len(lq)+1)
-	copy(lq2, lq)
-	lq2[len(lq)] = LockRequest{
-		Lock: AccountReadLock,
-		ID:   SystemIDStr,
-	}
-
-	var wvs WorldVirtualState
-	if c.virtualState != nil {
-		wvs = c.virtualState.GetFuture(lq2)
-	} else {
-		wvs = NewWorldVirtualState(c.WorldState, lq2)
-	}
-	return c.WorldStateChanged(wvs)
-}
-
 // TODO What if some values such as deployer don't use cache here and are resolved on demand.
 type systemStorageInfo struct {
 	ass          AccountSnapshot
@@ -312,19 +289,13 @@ func (c *worldContext) Governance() module.Address {
 	return c.governance
 }
 
-func tryVirtualState(ws WorldState) WorldVirtualState {
-	wvs, _ := ws.(WorldVirtualState)
-	return wvs
-}
-
 func (c *worldContext) WorldStateChanged(ws WorldState) WorldContext {
 	wc := &worldContext{
-		WorldState:   ws,
-		virtualState: tryVirtualState(ws),
-		treasury:     c.treasury,
-		governance:   c.governance,
-		systemInfo:   c.systemInfo,
-		blockInfo:    c.blockInfo,
+		WorldState: ws,
+		treasury:   c.treasury,
+		governance: c.governance,
+		systemInfo: c.systemInfo,
+		blockInfo:  c.blockInfo,
 	}
 	return wc
 }
@@ -401,11 +372,10 @@ func NewWorldContext(ws WorldState, bi module.BlockInfo) WorldContext {
 		governance = common.NewAddressFromString("cx0000000000000000000000000000000000000001")
 	}
 	wc := &worldContext{
-		WorldState:   ws,
-		virtualState: tryVirtualState(ws),
-		treasury:     treasury,
-		governance:   governance,
-		blockInfo:    BlockInfo{Timestamp: bi.Timestamp(), Height: bi.Height()},
+		WorldState: ws,
+		treasury:   treasury,
+		governance: governance,
+		blockInfo:  BlockInfo{Timestamp: bi.Timestamp(), Height: bi.Height()},
 	}
 	wc.UpdateSystemInfo()
 	return wc
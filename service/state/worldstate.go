@@ -93,9 +93,20 @@ type worldStateImpl struct {
 	validators      ValidatorState
 
 	nodeCacheEnabled bool
+
+	// tracking is true if it tracks written keys of storages of accounts.
+	tracking bool
+
+	// recorder records accessed accounts. It's nil if it's not recorded.
+	recorder *accessRecorder
 }
 
 func (ws *worldStateImpl) GetValidatorState() ValidatorState {
+	if ws.recorder != nil {
+		ws.mutex.Lock()
+		ws.recorder.validators = true
+		ws.mutex.Unlock()
+	}
 	return ws.validators
 }
 
@@ -138,6 +149,7 @@ func (ws *worldStateImpl) GetAccountState(id []byte) AccountState {
 	defer ws.mutex.Unlock()
 
 	ids := string(id)
+	ws.recorder.recordAccount(ids)
 	if a, ok := ws.mutableAccounts[ids]; ok {
 		return a
 	}
@@ -152,6 +164,9 @@ func (ws *worldStateImpl) GetAccountState(id []byte) AccountState {
 		as = obj.(*accountSnapshotImpl)
 	}
 	ac := newAccountState(ws.database, as, key, ws.nodeCacheEnabled)
+	if ws.tracking && ac != nil {
+		ac.(*accountStateImpl).written = make(map[string]struct{})
+	}
 	ws.mutableAccounts[ids] = ac
 	return ac
 }
@@ -196,6 +211,7 @@ func (ws *worldStateImpl) GetAccountSnapshot(id []byte) AccountSnapshot {
 	ws.mutex.Lock()
 	defer ws.mutex.Unlock()
 
+	ws.recorder.recordAccount(string(id))
 	if a, ok := ws.mutableAccounts[string(id)]; ok {
 		return a.GetSnapshot()
 	}
@@ -0,0 +1,217 @@
+package trace
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/txresult"
+)
+
+type eventLog struct {
+	addr    module.Address
+	indexed [][]byte
+	data    [][]byte
+}
+
+func (e *eventLog) Address() module.Address {
+	return e.addr
+}
+
+func (e *eventLog) Indexed() [][]byte {
+	return e.indexed
+}
+
+func (e *eventLog) Data() [][]byte {
+	return e.data
+}
+
+type Transfer struct {
+	From  module.Address
+	To    module.Address
+	Value *big.Int
+}
+
+// CallFrame is a node of the call tree. It's for a call of SCORE method,
+// a transfer or a deployment.
+type CallFrame struct {
+	From      module.Address
+	To        module.Address
+	Value     *big.Int
+	StepLimit *big.Int
+	Method    string
+	Params    interface{}
+
+	StepUsed *big.Int
+	Status   error
+	Result   interface{}
+	Done     bool
+
+	Events    []module.EventLog
+	Transfers []*Transfer
+	Calls     []*CallFrame
+}
+
+// CallTree builds the tree of frames from structured notifications.
+// It implements module.TraceFrameCallback.
+type CallTree struct {
+	lock   sync.Mutex
+	frames []*CallFrame
+	stack  []*CallFrame
+}
+
+func (t *CallTree) current() *CallFrame {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	return t.stack[len(t.stack)-1]
+}
+
+func (t *CallTree) OnFrameEnter(from, to module.Address, value, limit *big.Int, method string, params interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	frame := &CallFrame{
+		From:      from,
+		To:        to,
+		Value:     value,
+		StepLimit: limit,
+		Method:    method,
+		Params:    params,
+	}
+	if parent := t.current(); parent != nil {
+		parent.Calls = append(parent.Calls, frame)
+	} else {
+		t.frames = append(t.frames, frame)
+	}
+	t.stack = append(t.stack, frame)
+}
+
+func (t *CallTree) OnFrameExit(status error, stepUsed *big.Int, result interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	frame := t.current()
+	if frame == nil {
+		return
+	}
+	frame.StepUsed = stepUsed
+	frame.Status = status
+	frame.Result = result
+	frame.Done = true
+	if status != nil {
+		frame.dropEvents()
+	}
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// dropEvents removes events of the frame and its nested calls. They are
+// reverted with the frame, so they are not in the receipt.
+func (f *CallFrame) dropEvents() {
+	f.Events = nil
+	for _, c := range f.Calls {
+		c.dropEvents()
+	}
+}
+
+func (t *CallTree) OnEvent(addr module.Address, indexed, data [][]byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if frame := t.current(); frame != nil {
+		frame.Events = append(frame.Events, &eventLog{addr, indexed, data})
+	}
+}
+
+func (t *CallTree) OnTransfer(from, to module.Address, value *big.Int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if frame := t.current(); frame != nil {
+		frame.Transfers = append(frame.Transfers, &Transfer{from, to, value})
+	}
+}
+
+// Frames returns frames in the top level.
+func (t *CallTree) Frames() []*CallFrame {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.frames
+}
+
+// ToJSON returns JSON representation of frames in the top level.
+func (t *CallTree) ToJSON() []interface{} {
+	frames := t.Frames()
+	jso := make([]interface{}, 0, len(frames))
+	for _, frame := range frames {
+		jso = append(jso, frame.ToJSON())
+	}
+	return jso
+}
+
+func hexIntOf(v *big.Int) *common.HexInt {
+	if v == nil {
+		return nil
+	}
+	hv := new(common.HexInt)
+	hv.Set(v)
+	return hv
+}
+
+func (f *CallFrame) ToJSON() map[string]interface{} {
+	jso := map[string]interface{}{
+		"from":  f.From,
+		"to":    f.To,
+		"value": hexIntOf(f.Value),
+	}
+	if f.Method != "" {
+		jso["method"] = f.Method
+	}
+	if f.Params != nil {
+		jso["params"] = f.Params
+	}
+	if f.StepLimit != nil {
+		jso["stepLimit"] = hexIntOf(f.StepLimit)
+	}
+	if f.Done {
+		jso["stepUsed"] = hexIntOf(f.StepUsed)
+		if f.Status == nil {
+			jso["status"] = "0x1"
+			if f.Result != nil {
+				jso["result"] = f.Result
+			}
+		} else {
+			jso["status"] = "0x0"
+			status, _ := scoreresult.StatusOf(f.Status)
+			jso["failure"] = map[string]interface{}{
+				"code":    status,
+				"message": f.Status.Error(),
+			}
+		}
+	}
+	events := make([]interface{}, 0, len(f.Events))
+	for _, e := range f.Events {
+		if ejso, err := txresult.EventLogToJSON(e); err == nil {
+			events = append(events, ejso)
+		}
+	}
+	jso["events"] = events
+	transfers := make([]interface{}, 0, len(f.Transfers))
+	for _, tr := range f.Transfers {
+		transfers = append(transfers, map[string]interface{}{
+			"from":  tr.From,
+			"to":    tr.To,
+			"value": hexIntOf(tr.Value),
+		})
+	}
+	jso["transfers"] = transfers
+	calls := make([]interface{}, 0, len(f.Calls))
+	for _, c := range f.Calls {
+		calls = append(calls, c.ToJSON())
+	}
+	jso["calls"] = calls
+	return jso
+}
@@ -0,0 +1,59 @@
+package trace
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/service/scoreresult"
+)
+
+func TestCallTree(t *testing.T) {
+	eoa := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	score1 := common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	score2 := common.NewAddressFromString("cx0000000000000000000000000000000000000002")
+
+	tree := new(CallTree)
+	tree.OnFrameEnter(eoa, score1, big.NewInt(0), big.NewInt(1000), "transfer", nil)
+	tree.OnFrameEnter(score1, score2, big.NewInt(10), big.NewInt(500), "", nil)
+	tree.OnTransfer(score1, score2, big.NewInt(10))
+	tree.OnFrameEnter(score2, score1, big.NewInt(0), big.NewInt(200), "notify", nil)
+	tree.OnEvent(score1, [][]byte{[]byte("Notified(int)")}, [][]byte{{0x02}})
+	tree.OnFrameExit(nil, big.NewInt(50), nil)
+	tree.OnEvent(score2, [][]byte{[]byte("Received(int)")}, [][]byte{{0x0a}})
+	tree.OnFrameExit(scoreresult.ErrOutOfBalance, big.NewInt(100), nil)
+	tree.OnEvent(score1, [][]byte{[]byte("Done(int)")}, [][]byte{{0x01}})
+	tree.OnFrameExit(nil, big.NewInt(300), "0x1")
+
+	frames := tree.Frames()
+	assert.Len(t, frames, 1)
+	root := frames[0]
+	assert.True(t, root.Done)
+	assert.NoError(t, root.Status)
+	assert.Equal(t, "transfer", root.Method)
+	assert.Len(t, root.Events, 1)
+	assert.Len(t, root.Calls, 1)
+
+	child := root.Calls[0]
+	assert.Equal(t, scoreresult.ErrOutOfBalance, child.Status)
+	assert.Equal(t, 0, child.StepUsed.Cmp(big.NewInt(100)))
+	assert.Len(t, child.Transfers, 1)
+	// events of the failed call and its nested calls are reverted
+	assert.Empty(t, child.Events)
+	assert.Len(t, child.Calls, 1)
+	assert.NoError(t, child.Calls[0].Status)
+	assert.Empty(t, child.Calls[0].Events)
+
+	jso := tree.ToJSON()
+	assert.Len(t, jso, 1)
+	rjso := jso[0].(map[string]interface{})
+	assert.Equal(t, "0x1", rjso["status"])
+	assert.Equal(t, "0x1", rjso["result"])
+	cjso := rjso["calls"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "0x0", cjso["status"])
+	assert.NotNil(t, cjso["failure"])
+	assert.Empty(t, cjso["events"])
+	assert.Len(t, rjso["events"], 1)
+}
@@ -2,6 +2,7 @@ package trace
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/icon-project/goloop/common/log"
 	"github.com/icon-project/goloop/module"
@@ -11,6 +12,7 @@ type Logger struct {
 	log.Logger
 	isTrace bool
 	onLog   func(lv module.TraceLevel, msg string)
+	frames  module.TraceFrameCallback
 }
 
 func (l *Logger) TLog(lv module.TraceLevel, a ...interface{}) {
@@ -65,11 +67,42 @@ func (l *Logger) TSystemf(f string, a ...interface{}) {
 	l.onLog(module.TSystemLevel, fmt.Sprintf(f, a...))
 }
 
+// IsFrameTrace returns whether the callback accepts structured
+// notifications of frames.
+func (l *Logger) IsFrameTrace() bool {
+	return l.frames != nil
+}
+
+func (l *Logger) TFrameEnter(from, to module.Address, value, limit *big.Int, method string, params interface{}) {
+	if l.frames != nil {
+		l.frames.OnFrameEnter(from, to, value, limit, method, params)
+	}
+}
+
+func (l *Logger) TFrameExit(status error, stepUsed *big.Int, result interface{}) {
+	if l.frames != nil {
+		l.frames.OnFrameExit(status, stepUsed, result)
+	}
+}
+
+func (l *Logger) TEvent(addr module.Address, indexed, data [][]byte) {
+	if l.frames != nil {
+		l.frames.OnEvent(addr, indexed, data)
+	}
+}
+
+func (l *Logger) TTransfer(from, to module.Address, value *big.Int) {
+	if l.frames != nil {
+		l.frames.OnTransfer(from, to, value)
+	}
+}
+
 func (l *Logger) WithFields(f log.Fields) log.Logger {
 	return &Logger{
 		Logger:  l.Logger.WithFields(f),
 		isTrace: l.isTrace,
 		onLog:   l.onLog,
+		frames:  l.frames,
 	}
 }
 
@@ -79,10 +112,12 @@ func dummyLog(lv module.TraceLevel, msg string) {
 
 func NewLogger(l log.Logger, t module.TraceCallback) *Logger {
 	if t != nil {
+		frames, _ := t.(module.TraceFrameCallback)
 		return &Logger{
 			Logger:  l,
 			isTrace: true,
 			onLog:   t.OnLog,
+			frames:  frames,
 		}
 	} else {
 		return &Logger{
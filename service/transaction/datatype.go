@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// DataType specifies a custom data type of transactions in addition to the
+// built-in types(message, call, deploy and patch).
+type DataType struct {
+	// Revision is the revision enabling the type. Transactions of the type
+	// are rejected below the revision, so all nodes start to accept them at
+	// the same block.
+	Revision int
+
+	// Verify checks the data of the transaction regardless of the state.
+	// It's optional.
+	Verify func(from, to module.Address, value *big.Int, data []byte) error
+
+	// Steps returns the number of steps for the data in addition to the
+	// default steps and the input steps. It's optional.
+	Steps func(wc state.WorldContext, data []byte) (int64, error)
+
+	// NewHandler returns the handler executing the transaction.
+	NewHandler func(from, to module.Address, value *big.Int, data []byte) (contract.SyncContractHandler, error)
+}
+
+var dataTypes = struct {
+	lock  sync.RWMutex
+	types map[string]*DataType
+}{
+	types: make(map[string]*DataType),
+}
+
+func isBuiltinDataType(name string) bool {
+	switch name {
+	case DataTypeMessage, DataTypeCall, DataTypeDeploy, DataTypePatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// RegisterDataType registers the custom data type. It should be called
+// before any transaction is handled, usually in init().
+func RegisterDataType(name string, dt *DataType) error {
+	if isBuiltinDataType(name) {
+		return errors.IllegalArgumentError.Errorf("BuiltinDataType(%s)", name)
+	}
+	if dt == nil || dt.NewHandler == nil {
+		return errors.IllegalArgumentError.Errorf("NoHandler(type=%s)", name)
+	}
+
+	dataTypes.lock.Lock()
+	defer dataTypes.lock.Unlock()
+
+	if _, ok := dataTypes.types[name]; ok {
+		return errors.IllegalArgumentError.Errorf("DuplicateDataType(%s)", name)
+	}
+	dataTypes.types[name] = dt
+	return nil
+}
+
+// GetDataType returns the registered custom data type. It returns nil if
+// there is no such type.
+func GetDataType(name string) *DataType {
+	dataTypes.lock.RLock()
+	defer dataTypes.lock.RUnlock()
+
+	return dataTypes.types[name]
+}
+
+// CheckRevision returns an error if the type is not enabled in the revision.
+func (dt *DataType) CheckRevision(name string, rev int) error {
+	if rev < dt.Revision {
+		return InvalidFormat.Errorf("IllegalDataType(type=%s,rev=%d)", name, rev)
+	}
+	return nil
+}
+
+// StepsFor returns the number of additional steps for the data.
+func (dt *DataType) StepsFor(wc state.WorldContext, data []byte) (int64, error) {
+	if dt.Steps == nil {
+		return 0, nil
+	}
+	return dt.Steps(wc, data)
+}
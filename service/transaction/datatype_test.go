@@ -0,0 +1,210 @@
+package transaction
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/scoreresult"
+	"github.com/icon-project/goloop/service/state"
+)
+
+func unregisterDataType(name string) {
+	dataTypes.lock.Lock()
+	defer dataTypes.lock.Unlock()
+
+	delete(dataTypes.types, name)
+}
+
+func TestRegisterDataType(t *testing.T) {
+	const name = "test"
+	defer unregisterDataType(name)
+
+	newHandler := func(from, to module.Address, value *big.Int, data []byte) (contract.SyncContractHandler, error) {
+		return nil, errors.UnsupportedError.New("NotImplemented")
+	}
+	assert.Error(t, RegisterDataType(DataTypeCall, &DataType{NewHandler: newHandler}))
+	assert.Error(t, RegisterDataType(name, &DataType{}))
+	assert.Nil(t, GetDataType(name))
+
+	dt := &DataType{
+		Revision:   module.Revision9,
+		NewHandler: newHandler,
+	}
+	assert.NoError(t, RegisterDataType(name, dt))
+	assert.Error(t, RegisterDataType(name, dt))
+	assert.Equal(t, dt, GetDataType(name))
+
+	assert.True(t, InvalidFormat.Equals(dt.CheckRevision(name, module.Revision8)))
+	assert.NoError(t, dt.CheckRevision(name, module.Revision9))
+
+	steps, err := dt.StepsFor(nil, nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, steps)
+
+	dt.Steps = func(wc state.WorldContext, data []byte) (int64, error) {
+		return int64(len(data) * 10), nil
+	}
+	steps, err = dt.StepsFor(nil, []byte("[1,2]"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 50, steps)
+
+	// handler of the type is used for the transaction
+	from := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	dataType := name
+	_, err = NewHandler(nil, from, from, big.NewInt(0), big.NewInt(100), nil, &dataType, nil)
+	assert.Error(t, err)
+	dataType = "unknown"
+	_, err = NewHandler(nil, from, from, big.NewInt(0), big.NewInt(100), nil, &dataType, nil)
+	assert.True(t, InvalidFormat.Equals(err))
+}
+
+// batchTransferHandler pays the value to the recipients in equal shares.
+type batchTransferHandler struct {
+	from       module.Address
+	recipients []common.Address
+	value      *big.Int
+}
+
+func (h *batchTransferHandler) ResetLogger(logger log.Logger) {
+}
+
+func (h *batchTransferHandler) ExecuteSync(cc contract.CallContext) (error, *codec.TypedObj, module.Address) {
+	share := new(big.Int).Div(h.value, big.NewInt(int64(len(h.recipients))))
+	as1 := cc.GetAccountState(h.from.ID())
+	for i := range h.recipients {
+		to := &h.recipients[i]
+		bal1 := as1.GetBalance()
+		if bal1.Cmp(share) < 0 {
+			return scoreresult.ErrOutOfBalance, nil, nil
+		}
+		as1.SetBalance(new(big.Int).Sub(bal1, share))
+		as2 := cc.GetAccountState(to.ID())
+		as2.SetBalance(new(big.Int).Add(as2.GetBalance(), share))
+		cc.OnEvent(h.from, [][]byte{
+			[]byte("ICXTransfer(Address,Address,int)"),
+			h.from.Bytes(), to.Bytes(), share.Bytes(),
+		}, nil)
+	}
+	return nil, nil, nil
+}
+
+func recipientsOf(data []byte) ([]common.Address, error) {
+	var recipients []common.Address
+	if err := json.Unmarshal(data, &recipients); err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, errors.IllegalArgumentError.New("NoRecipients")
+	}
+	return recipients, nil
+}
+
+func TestDataType_Transaction(t *testing.T) {
+	const name = "batchTransfer"
+	defer unregisterDataType(name)
+	assert.NoError(t, RegisterDataType(name, &DataType{
+		Revision: module.Revision9,
+		Verify: func(from, to module.Address, value *big.Int, data []byte) error {
+			_, err := recipientsOf(data)
+			return err
+		},
+		Steps: func(wc state.WorldContext, data []byte) (int64, error) {
+			recipients, err := recipientsOf(data)
+			return int64(len(recipients)) * 1000, err
+		},
+		NewHandler: func(from, to module.Address, value *big.Int, data []byte) (contract.SyncContractHandler, error) {
+			recipients, err := recipientsOf(data)
+			if err != nil {
+				return nil, err
+			}
+			return &batchTransferHandler{from, recipients, value}, nil
+		},
+	}))
+
+	priv, pub := crypto.GenerateKeyPair()
+	from := common.NewAccountAddressFromPublicKey(pub)
+	to1 := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	to2 := common.NewAddressFromString("hx0000000000000000000000000000000000000002")
+	newTx := func(stepLimit int64, data string) Transaction {
+		dataType := name
+		txd := &transactionV3Data{
+			Version:   common.HexUint16{Value: 3},
+			From:      *from,
+			To:        *from,
+			Value:     common.NewHexInt(100),
+			StepLimit: *common.NewHexInt(stepLimit),
+			TimeStamp: common.HexInt64{Value: 1},
+			DataType:  &dataType,
+			Data:      json.RawMessage(data),
+		}
+		hash, err := txd.calcHash()
+		assert.NoError(t, err)
+		sig, err := crypto.NewSignature(hash, priv)
+		assert.NoError(t, err)
+		txd.Signature = common.Signature{Signature: sig}
+		js, err := json.Marshal(txd)
+		assert.NoError(t, err)
+
+		// it's decoded from JSON, and from bytes as it's in a block
+		tx, err := NewTransactionFromJSON(js)
+		assert.NoError(t, err)
+		tx2, err := NewTransaction(tx.Bytes())
+		assert.NoError(t, err)
+		assert.Equal(t, tx.ID(), tx2.ID())
+		return tx2
+	}
+	recipients := `["` + to1.String() + `","` + to2.String() + `"]`
+
+	// data of the type is verified by the type
+	assert.True(t, InvalidTxValue.Equals(newTx(100000, `[]`).Verify()))
+	tx := newTx(100000, recipients)
+	assert.NoError(t, tx.Verify())
+
+	ws := state.NewWorldState(db.NewMapDB(), nil, nil)
+	ws.GetAccountState(from.ID()).SetBalance(big.NewInt(1000000))
+	sas := ws.GetAccountState(state.SystemID)
+	assert.NoError(t, scoredb.NewVarDB(sas, state.VarStepPrice).Set(1))
+	assert.NoError(t, scoredb.NewArrayDB(sas, state.VarStepLimitTypes).Put(LimitTypeInvoke))
+	assert.NoError(t, scoredb.NewDictDB(sas, state.VarStepLimit, 1).Set(LimitTypeInvoke, 100000))
+	revision := scoredb.NewVarDB(sas, state.VarRevision)
+	assert.NoError(t, revision.Set(module.Revision8))
+	wc := state.NewWorldContext(ws, common.NewBlockInfo(1, 1000))
+
+	// it's rejected below the revision, and it needs steps for the type
+	assert.True(t, InvalidFormat.Equals(tx.PreValidate(wc, false)))
+	assert.NoError(t, revision.Set(module.Revision9))
+	wc.UpdateSystemInfo()
+	assert.True(t, NotEnoughStepError.Equals(newTx(1999, recipients).PreValidate(wc, false)))
+	assert.NoError(t, tx.PreValidate(wc, false))
+
+	handler, err := tx.GetHandler(nil)
+	assert.NoError(t, err)
+	defer handler.Dispose()
+	ctx := contract.NewContext(wc, nil, nil, nil, log.New(), nil)
+	rct, err := handler.Execute(ctx, false)
+	assert.NoError(t, err)
+	assert.Equal(t, module.StatusSuccess, rct.Status())
+	assert.True(t, rct.StepUsed().Cmp(big.NewInt(2000)) >= 0)
+	assert.Equal(t, big.NewInt(50), ws.GetAccountState(to1.ID()).GetBalance())
+	assert.Equal(t, big.NewInt(50), ws.GetAccountState(to2.ID()).GetBalance())
+	fee := new(big.Int).Mul(rct.StepUsed(), rct.StepPrice())
+	assert.Equal(t, new(big.Int).Sub(big.NewInt(1000000-100), fee),
+		ws.GetAccountState(from.ID()).GetBalance())
+	events := 0
+	for it := rct.EventLogIterator(); it.Has(); it.Next() {
+		events++
+	}
+	assert.Equal(t, 2, events)
+}
@@ -13,4 +13,6 @@ const (
 	NotEnoughStepError
 	NotEnoughBalanceError
 	AccessDeniedError
+	InvalidNonceError
+	FutureNonceError
 )
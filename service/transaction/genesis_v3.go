@@ -155,13 +155,6 @@ func (g *genesisV3) ValidateNetwork(nid int) bool {
 	return g.NID() == nid
 }
 
-func (g *genesisV3) Prepare(ctx contract.Context) (state.WorldContext, error) {
-	lq := []state.LockRequest{
-		{state.WorldIDStr, state.AccountWriteLock},
-	}
-	return ctx.GetFuture(lq), nil
-}
-
 func (g *genesisV3) Execute(ctx contract.Context, estimate bool) (txresult.Receipt, error) {
 	cc := contract.NewCallContext(ctx, ctx.GetStepLimit(LimitTypeInvoke), false)
 	defer cc.Dispose()
@@ -285,6 +278,10 @@ func (g *genesisV3) Nonce() *big.Int {
 	return nil
 }
 
+func (g *genesisV3) GetStepLimit() *big.Int {
+	return big.NewInt(0)
+}
+
 func (g *genesisV3) To() module.Address {
 	return common.NewContractAddress(state.SystemID)
 }
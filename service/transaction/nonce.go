@@ -0,0 +1,37 @@
+package transaction
+
+import (
+	"math/big"
+
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// CheckNonce checks whether the nonce of the transaction is the next nonce
+// of the sender account. Transactions without nonce aren't checked.
+func CheckNonce(wc state.WorldContext, as state.AccountState, nonce *big.Int) error {
+	if nonce == nil || wc.Revision() < module.Revision9 {
+		return nil
+	}
+	expected := as.GetNonce()
+	switch nonce.Cmp(expected) {
+	case -1:
+		return InvalidNonceError.Errorf("InvalidNonce(nonce=%s,expected=%s)", nonce, expected)
+	case 1:
+		return FutureNonceError.Errorf("FutureNonce(nonce=%s,expected=%s)", nonce, expected)
+	}
+	return nil
+}
+
+// AdvanceNonce updates the nonce of the sender account after the
+// transaction with the nonce is applied.
+func AdvanceNonce(rev int, as state.AccountState, nonce *big.Int) error {
+	if nonce == nil || rev < module.Revision9 {
+		return nil
+	}
+	if err := as.MigrateForRevision(rev); err != nil {
+		return err
+	}
+	as.SetNonce(new(big.Int).Add(nonce, big.NewInt(1)))
+	return nil
+}
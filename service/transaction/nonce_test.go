@@ -0,0 +1,46 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+func TestTransactionHandler_ExecuteWithNonce(t *testing.T) {
+	ws := state.NewWorldState(db.NewMapDB(), nil, nil)
+	as := ws.GetAccountState(state.SystemID)
+	assert.NoError(t, scoredb.NewVarDB(as, state.VarRevision).Set(module.Revision9))
+	ctx := contract.NewContext(state.NewWorldContext(ws, common.NewBlockInfo(1, 1000)),
+		nil, nil, nil, nil, nil)
+
+	from := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	to := common.NewAddressFromString("hx0000000000000000000000000000000000000002")
+	executeWith := func(nonce int64) error {
+		th := &transactionHandler{
+			from:      from,
+			to:        to,
+			value:     big.NewInt(0),
+			stepLimit: big.NewInt(100000),
+			nonce:     big.NewInt(nonce),
+		}
+		_, err := th.Execute(ctx, false)
+		return err
+	}
+
+	// a transaction with a wrong nonce fails without advancing the nonce
+	ass := ws.GetAccountState(from.ID())
+	assert.True(t, FutureNonceError.Equals(executeWith(1)))
+	assert.Equal(t, 0, ass.GetNonce().Sign())
+
+	assert.NoError(t, AdvanceNonce(module.Revision9, ass, big.NewInt(2)))
+	assert.True(t, InvalidNonceError.Equals(executeWith(1)))
+	assert.Equal(t, big.NewInt(3), ass.GetNonce())
+}
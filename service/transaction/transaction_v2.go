@@ -96,14 +96,6 @@ func (tx *transactionV2) GetHandler(cm contract.ContractManager) (Handler, error
 	return tx, nil
 }
 
-func (tx *transactionV2) Prepare(ctx contract.Context) (state.WorldContext, error) {
-	lq := []state.LockRequest{
-		{string(tx.From().ID()), state.AccountWriteLock},
-		{string(tx.To().ID()), state.AccountWriteLock},
-	}
-	return ctx.GetFuture(lq), nil
-}
-
 func (tx *transactionV2) Execute(ctx contract.Context, estimate bool) (txresult.Receipt, error) {
 	r := txresult.NewReceipt(ctx.Database(), ctx.Revision(), tx.To())
 	trans := new(big.Int).Add(&tx.Value.Int, version2FixedFee)
@@ -159,6 +151,10 @@ func (tx *transactionV2) Nonce() *big.Int {
 	return nil
 }
 
+func (tx *transactionV2) GetStepLimit() *big.Int {
+	return version2StepUsed
+}
+
 func (tx *transactionV2) To() module.Address {
 	return &tx.transactionV3Data.To
 }
@@ -198,6 +198,12 @@ func (tx *transactionV3) Verify() error {
 			if _, err := contract.ParsePatchData(tx.Data); err != nil {
 				return InvalidTxValue.Wrap(err, "TxData is invalid")
 			}
+		default:
+			if dt := GetDataType(*tx.DataType); dt != nil && dt.Verify != nil {
+				if err := dt.Verify(tx.From(), tx.To(), tx.valueOrZero(), tx.Data); err != nil {
+					return InvalidTxValue.Wrap(err, "TxData is invalid")
+				}
+			}
 		}
 	}
 
@@ -223,6 +229,18 @@ func (tx *transactionV3) PreValidate(wc state.WorldContext, update bool) error {
 		return err
 	}
 	minStep := big.NewInt(wc.StepsFor(state.StepTypeDefault, 1) + wc.StepsFor(state.StepTypeInput, cnt))
+	if tx.DataType != nil {
+		if dt := GetDataType(*tx.DataType); dt != nil {
+			if err := dt.CheckRevision(*tx.DataType, wc.Revision()); err != nil {
+				return err
+			}
+			steps, err := dt.StepsFor(wc, tx.Data)
+			if err != nil {
+				return InvalidTxValue.Wrap(err, "TxData is invalid")
+			}
+			minStep.Add(minStep, big.NewInt(steps))
+		}
+	}
 	if tx.StepLimit.Cmp(minStep) < 0 {
 		return NotEnoughStepError.Errorf("NotEnoughStep(txStepLimit:%s, minStep:%s)", tx.StepLimit, minStep)
 	}
@@ -241,10 +259,18 @@ func (tx *transactionV3) PreValidate(wc state.WorldContext, update bool) error {
 		return NotEnoughBalanceError.Errorf("OutOfBalance(balance:%s, value:%s)", balance1, trans)
 	}
 
+	nonce := tx.Nonce()
+	if err := CheckNonce(wc, as1, nonce); err != nil {
+		return err
+	}
+
 	// for cumulative balance check
 	if update {
 		as2 := wc.GetAccountState(tx.To().ID())
 		as1.SetBalance(new(big.Int).Sub(balance1, trans))
+		if err := AdvanceNonce(wc.Revision(), as1, nonce); err != nil {
+			return err
+		}
 		if tx.Value != nil {
 			balance2 := as2.GetBalance()
 			as2.SetBalance(new(big.Int).Add(balance2, &tx.Value.Int))
@@ -253,18 +279,20 @@ func (tx *transactionV3) PreValidate(wc state.WorldContext, update bool) error {
 	return nil
 }
 
-func (tx *transactionV3) GetHandler(cm contract.ContractManager) (Handler, error) {
-	var value *big.Int
+func (tx *transactionV3) valueOrZero() *big.Int {
 	if tx.Value != nil {
-		value = &tx.Value.Int
-	} else {
-		value = big.NewInt(0)
+		return &tx.Value.Int
 	}
+	return big.NewInt(0)
+}
+
+func (tx *transactionV3) GetHandler(cm contract.ContractManager) (Handler, error) {
 	return NewHandler(cm,
 		tx.From(),
 		tx.To(),
-		value,
+		tx.valueOrZero(),
 		&tx.StepLimit.Int,
+		tx.Nonce(),
 		tx.DataType,
 		tx.Data)
 }
@@ -313,6 +341,10 @@ func (tx *transactionV3) Nonce() *big.Int {
 	return nil
 }
 
+func (tx *transactionV3) GetStepLimit() *big.Int {
+	return &tx.StepLimit.Int
+}
+
 func (tx *transactionV3) To() module.Address {
 	return &tx.transactionV3Data.To
 }
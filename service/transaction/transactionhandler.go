@@ -23,7 +23,6 @@ const (
 )
 
 type Handler interface {
-	Prepare(ctx contract.Context) (state.WorldContext, error)
 	Execute(ctx contract.Context, estimate bool) (txresult.Receipt, error)
 	Dispose()
 }
@@ -33,22 +32,28 @@ type transactionHandler struct {
 	to        module.Address
 	value     *big.Int
 	stepLimit *big.Int
+	nonce     *big.Int
 	data      []byte
 
 	chandler contract.ContractHandler
 
+	// custom data type if it's not built-in
+	dataType string
+	custom   *DataType
+
 	// Assigned at Execute()
 	cc contract.CallContext
 }
 
 func NewHandler(cm contract.ContractManager, from, to module.Address,
-	value, stepLimit *big.Int, dataType *string, data []byte,
+	value, stepLimit, nonce *big.Int, dataType *string, data []byte,
 ) (Handler, error) {
 	th := &transactionHandler{
 		from:      from,
 		to:        to,
 		value:     value,
 		stepLimit: stepLimit,
+		nonce:     nonce,
 		data:      data,
 	}
 	ctype := contract.CTypeNone // invalid contract type
@@ -65,7 +70,18 @@ func NewHandler(cm contract.ContractManager, from, to module.Address,
 		case DataTypePatch:
 			ctype = contract.CTypePatch
 		default:
-			return nil, InvalidFormat.Errorf("IllegalDataType(type=%s)", *dataType)
+			dt := GetDataType(*dataType)
+			if dt == nil {
+				return nil, InvalidFormat.Errorf("IllegalDataType(type=%s)", *dataType)
+			}
+			handler, err := dt.NewHandler(from, to, value, data)
+			if err != nil {
+				return nil, errors.InvalidStateError.Wrap(err, "NoSuitableHandler")
+			}
+			th.chandler = handler
+			th.dataType = *dataType
+			th.custom = dt
+			return th, nil
 		}
 	}
 
@@ -77,11 +93,16 @@ func NewHandler(cm contract.ContractManager, from, to module.Address,
 	return th, nil
 }
 
-func (th *transactionHandler) Prepare(ctx contract.Context) (state.WorldContext, error) {
-	return th.chandler.Prepare(ctx)
-}
-
 func (th *transactionHandler) Execute(ctx contract.Context, estimate bool) (txresult.Receipt, error) {
+	// The nonce is checked again, because a transaction in a block may not
+	// be validated by the pool.
+	if !estimate {
+		as := ctx.GetAccountState(th.from.ID())
+		if err := CheckNonce(ctx, as, th.nonce); err != nil {
+			return nil, err
+		}
+	}
+
 	// Make a copy of initial state
 	wcs := ctx.GetSnapshot()
 
@@ -111,6 +132,8 @@ func (th *transactionHandler) Execute(ctx contract.Context, estimate bool) (txre
 		} else {
 			if !cc.ApplySteps(state.StepTypeInput, cnt) {
 				status = scoreresult.ErrOutOfStep
+			} else if th.custom != nil {
+				status = th.applyDataTypeSteps(ctx, cc)
 			}
 
 			// Execute
@@ -157,6 +180,9 @@ func (th *transactionHandler) Execute(ctx contract.Context, estimate bool) (txre
 		}
 	}
 	as.SetBalance(new(big.Int).Sub(bal, fee))
+	if err := AdvanceNonce(ctx.Revision(), as, th.nonce); err != nil {
+		return nil, err
+	}
 
 	// Make a receipt
 	receipt := txresult.NewReceipt(ctx.Database(), ctx.Revision(), th.to)
@@ -171,6 +197,21 @@ func (th *transactionHandler) Execute(ctx contract.Context, estimate bool) (txre
 	return receipt, nil
 }
 
+// applyDataTypeSteps applies steps for the custom data type.
+func (th *transactionHandler) applyDataTypeSteps(ctx contract.Context, cc contract.CallContext) error {
+	if err := th.custom.CheckRevision(th.dataType, ctx.Revision()); err != nil {
+		return scoreresult.IllegalFormatError.Wrap(err, "DisabledDataType")
+	}
+	steps, err := th.custom.StepsFor(ctx, th.data)
+	if err != nil {
+		return scoreresult.InvalidParameterError.Wrap(err, "InvalidData")
+	}
+	if !cc.DeductSteps(big.NewInt(steps)) {
+		return scoreresult.ErrOutOfStep
+	}
+	return nil
+}
+
 func (th *transactionHandler) Dispose() {
 	// Actually it is called after calling Execute(), so cc can't be nil.
 	if th.cc != nil {
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/icon-project/goloop/module"
@@ -22,6 +23,13 @@ type transactionList struct {
 
 	idMap        []map[string]*txElement
 	srcMapToLast []map[string]*txElement
+	srcUsage     map[string]*txUsage
+}
+
+// txUsage is the usage of the list by a sender.
+type txUsage struct {
+	count int
+	bytes int
 }
 
 type txElement struct {
@@ -150,6 +158,8 @@ func (l *transactionList) Add(tx transaction.Transaction, ts bool) error {
 			e.srcNext = insertPos
 			insertPos.srcPrev = e
 		} else {
+			e.srcPrev = t2
+			t2.srcNext = e
 			l.srcMapToLast[uidBk][uidSlot] = e
 		}
 	} else {
@@ -176,6 +186,15 @@ func (l *transactionList) Add(tx transaction.Transaction, ts bool) error {
 	}
 	e.updateBloom()
 	l.size += 1
+
+	uKey := string(tx.From().ID())
+	u, ok := l.srcUsage[uKey]
+	if !ok {
+		u = new(txUsage)
+		l.srcUsage[uKey] = u
+	}
+	u.count += 1
+	u.bytes += len(tx.Bytes())
 	return nil
 }
 
@@ -228,6 +247,15 @@ func (l *transactionList) Remove(t *txElement) bool {
 	tidBk, tidSlot := indexAndBucketKeyFromKey(string(t.value.ID()))
 	delete(l.idMap[tidBk], tidSlot)
 
+	uKey := string(t.value.From().ID())
+	if u, ok := l.srcUsage[uKey]; ok {
+		u.count -= 1
+		u.bytes -= len(t.value.Bytes())
+		if u.count <= 0 {
+			delete(l.srcUsage, uKey)
+		}
+	}
+
 	l.size -= 1
 	t.list = nil
 	return true
@@ -247,6 +275,42 @@ func (l *transactionList) HasTx(id []byte) bool {
 	return ok
 }
 
+func (l *transactionList) Get(id []byte) *txElement {
+	tidBk, tidSlot := indexAndBucketKeyFromKey(string(id))
+	return l.idMap[tidBk][tidSlot]
+}
+
+// UsageOf returns the number and the total bytes of the transactions of
+// the sender.
+func (l *transactionList) UsageOf(from module.Address) (int, int) {
+	if u, ok := l.srcUsage[string(from.ID())]; ok {
+		return u.count, u.bytes
+	}
+	return 0, 0
+}
+
+// GetByNonce returns the transaction of the sender with the nonce.
+func (l *transactionList) GetByNonce(from module.Address, nonce *big.Int) *txElement {
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from.ID()))
+	for e := l.srcMapToLast[uidBk][uidSlot]; e != nil; e = e.srcPrev {
+		if n := e.value.Nonce(); n != nil && n.Cmp(nonce) == 0 {
+			return e
+		}
+	}
+	return nil
+}
+
+// rebuildBloom rebuilds blooms of the transactions, so removed transactions
+// are excluded from them.
+func (l *transactionList) rebuildBloom() {
+	for e := l.listFront; e != nil; e = e.listNext {
+		e.bloom = nil
+	}
+	for e := l.listFront; e != nil; e = e.listNext {
+		e.updateBloom()
+	}
+}
+
 func (l *transactionList) GetBloom() *TxBloom {
 	if l.listFront == nil {
 		return &TxBloom{}
@@ -259,6 +323,7 @@ func newTransactionList() *transactionList {
 
 	l.idMap = make([]map[string]*txElement, txBucketCount)
 	l.srcMapToLast = make([]map[string]*txElement, txBucketCount)
+	l.srcUsage = make(map[string]*txUsage)
 	for i := 0; i < txBucketCount; i++ {
 		l.idMap[i] = make(map[string]*txElement)
 		l.srcMapToLast[i] = make(map[string]*txElement)
@@ -16,6 +16,8 @@ type mockTransaction struct {
 	id        []byte
 	from      module.Address
 	timeStamp int64
+	nonce     *big.Int
+	stepLimit *big.Int
 }
 
 func (*mockTransaction) Group() module.TransactionGroup {
@@ -39,7 +41,7 @@ func (*mockTransaction) Hash() []byte {
 }
 
 func (*mockTransaction) Verify() error {
-	panic("implement me")
+	return nil
 }
 
 func (*mockTransaction) Version() int {
@@ -62,8 +64,12 @@ func (t *mockTransaction) Timestamp() int64 {
 	return t.timeStamp
 }
 
-func (*mockTransaction) Nonce() *big.Int {
-	panic("implement me")
+func (t *mockTransaction) Nonce() *big.Int {
+	return t.nonce
+}
+
+func (t *mockTransaction) GetStepLimit() *big.Int {
+	return t.stepLimit
 }
 
 func (t *mockTransaction) To() module.Address {
@@ -208,3 +214,78 @@ func TestTransactionList_TestSort(t *testing.T) {
 		t.Errorf("First item should be tx4 but tx=%x", tx.ID())
 	}
 }
+
+func TestTransactionList_LinkOfSender(t *testing.T) {
+	from1 := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	tx1 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x01}, from1, 1)
+	tx2 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x02}, from1, 2)
+	tx3 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x03}, from1, 3)
+
+	// appended transactions should be linked to the previous one
+	l := newTransactionList()
+	l.Add(tx1, false)
+	l.Add(tx2, false)
+	l.Add(tx3, false)
+	var txs []module.Transaction
+	uidBk, uidSlot := indexAndBucketKeyFromKey(string(from1.ID()))
+	for e := l.srcMapToLast[uidBk][uidSlot]; e != nil; e = e.srcPrev {
+		txs = append(txs, e.Value())
+		if e.srcPrev != nil && e.srcPrev.srcNext != e {
+			t.Errorf("srcNext of the previous should be tx=%x", e.Value().ID())
+		}
+	}
+	if len(txs) != 3 || txs[0] != tx3 || txs[1] != tx2 || txs[2] != tx1 {
+		t.Errorf("All transactions of the sender should be linked txs=%d", len(txs))
+	}
+
+	// after removing the last one, the previous one becomes the last.
+	l = newTransactionList()
+	l.Add(tx2, false)
+	l.Add(tx3, false)
+	l.RemoveTx(tx3)
+	l.Add(tx1, false)
+	e := l.Front()
+	if tx := e.Value(); tx != tx1 {
+		t.Errorf("First item should be tx1 but tx=%x", tx.ID())
+	}
+	e = e.Next()
+	if tx := e.Value(); tx != tx2 {
+		t.Errorf("Second item should be tx2 but tx=%x", tx.ID())
+	}
+}
+
+func TestTransactionList_GetByNonce(t *testing.T) {
+	from1 := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	from2 := common.NewAddressFromString("hx0000000000000000000000000000000000000002")
+	tx1 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x01}, from1, 1)
+	tx1.nonce = big.NewInt(1)
+	tx2 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x02}, from1, 2)
+	tx2.nonce = big.NewInt(2)
+	tx3 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x03}, from1, 3)
+	tx4 := newMockTransaction([]byte{0x00, 0x00, 0x00, 0x04}, from2, 1)
+	tx4.nonce = big.NewInt(1)
+
+	l := newTransactionList()
+	l.Add(tx2, false)
+	l.Add(tx3, false)
+	l.Add(tx1, false)
+	l.Add(tx4, false)
+
+	if e := l.GetByNonce(from1, big.NewInt(1)); e == nil || e.Value() != tx1 {
+		t.Error("It should return tx1 for nonce 1 of from1")
+	}
+	if e := l.GetByNonce(from1, big.NewInt(2)); e == nil || e.Value() != tx2 {
+		t.Error("It should return tx2 for nonce 2 of from1")
+	}
+	if e := l.GetByNonce(from2, big.NewInt(1)); e == nil || e.Value() != tx4 {
+		t.Error("It should return tx4 for nonce 1 of from2")
+	}
+	if e := l.GetByNonce(from2, big.NewInt(2)); e != nil {
+		t.Errorf("It should return nil for unknown nonce tx=%x", e.Value().ID())
+	}
+
+	l.RemoveTx(tx1)
+	if e := l.GetByNonce(from1, big.NewInt(1)); e != nil {
+		t.Errorf("It should return nil after removal tx=%x", e.Value().ID())
+	}
+}
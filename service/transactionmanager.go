@@ -3,6 +3,7 @@ package service
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/errors"
@@ -14,6 +15,8 @@ import (
 
 const (
 	hashSize = 32
+
+	configDropHistorySize = 4096
 )
 
 type hashValue [hashSize]byte
@@ -29,9 +32,22 @@ type TransactionManager struct {
 	normalTxPool *TransactionPool
 	lastTS       [2]int64
 
+	// finalized is the world context of the last finalized block.
+	// It's used to check nonce of transactions.
+	finalized atomic.Value
+
 	callback func()
 
 	txWaiters map[hashValue][]chan<- interface{}
+
+	// drops keeps reasons of recently dropped transactions. dropKeys is
+	// used as a ring buffer to limit the size of the history.
+	drops    map[hashValue]*module.TransactionDrop
+	dropKeys []hashValue
+	dropNext int
+
+	monitorLock sync.Mutex
+	monitors    []module.TransactionMonitor
 }
 
 func (m *TransactionManager) getTxPool(g module.TransactionGroup) *TransactionPool {
@@ -52,6 +68,31 @@ func (m *TransactionManager) RemoveOldTxByBlockTS(group module.TransactionGroup,
 	m.getTxPool(group).DropOldTXs(ts)
 }
 
+// SetFinalizedState sets the world context of the last finalized block.
+func (m *TransactionManager) SetFinalizedState(wc state.WorldContext) {
+	m.finalized.Store(wc)
+}
+
+// checkNonceInLock rejects the transaction if its nonce is already used by
+// the sender. It returns true if the nonce of the transaction is checked.
+func (m *TransactionManager) checkNonceInLock(tx transaction.Transaction) (bool, error) {
+	nonce := tx.Nonce()
+	if nonce == nil || tx.Version() != module.TransactionVersion3 {
+		return false, nil
+	}
+	wc, _ := m.finalized.Load().(state.WorldContext)
+	if wc == nil || wc.Revision() < module.Revision9 {
+		return false, nil
+	}
+	as := wc.GetAccountState(tx.From().ID())
+	if err := transaction.CheckNonce(wc, as, nonce); err != nil {
+		if !transaction.FutureNonceError.Equals(err) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 func (m *TransactionManager) HasTx(id []byte) bool {
 	return m.normalTxPool.HasTx(id) || m.patchTxPool.HasTx(id)
 }
@@ -136,18 +177,110 @@ func (m *TransactionManager) removeWaiters(id []byte) []chan<- interface{} {
 type TxDrop struct {
 	ID  []byte
 	Err error
+	Tx  module.Transaction
 }
 
 func (m *TransactionManager) OnTxDrops(drops []TxDrop) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	now := time.Now()
 	for _, drop := range drops {
 		ws := m.removeWaitersInLock(drop.ID)
 		for _, c := range ws {
 			c <- drop.Err
 			close(c)
 		}
+		m.recordDropInLock(drop, now)
+	}
+	m.notifyDrops(drops)
+}
+
+func (m *TransactionManager) recordDropInLock(drop TxDrop, now time.Time) {
+	var hv hashValue
+	copy(hv[:], drop.ID)
+	if _, ok := m.drops[hv]; !ok {
+		if len(m.dropKeys) < configDropHistorySize {
+			m.dropKeys = append(m.dropKeys, hv)
+		} else {
+			delete(m.drops, m.dropKeys[m.dropNext])
+			m.dropKeys[m.dropNext] = hv
+			m.dropNext = (m.dropNext + 1) % configDropHistorySize
+		}
+	}
+	m.drops[hv] = &module.TransactionDrop{
+		Reason: drop.Err,
+		Time:   now,
+	}
+}
+
+// GetDrop returns the reason of the transaction dropped recently.
+func (m *TransactionManager) GetDrop(id []byte) (*module.TransactionDrop, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var hv hashValue
+	copy(hv[:], id)
+	if drop, ok := m.drops[hv]; ok {
+		return drop, nil
+	}
+	return nil, errors.NotFoundError.Errorf("NoDropHistory(id=%#x)", id)
+}
+
+func (m *TransactionManager) PoolTransactions(g module.TransactionGroup, offset, limit int) ([]module.Transaction, int) {
+	return m.getTxPool(g).Transactions(offset, limit)
+}
+
+func (m *TransactionManager) PoolStatus(g module.TransactionGroup) *module.TransactionPoolStatus {
+	return m.getTxPool(g).Status()
+}
+
+// Evict removes the transactions from the pools, then it returns ids of
+// removed transactions.
+func (m *TransactionManager) Evict(ids [][]byte) [][]byte {
+	evicted := m.normalTxPool.Evict(ids, ErrEvictedTransaction)
+	return append(evicted, m.patchTxPool.Evict(ids, ErrEvictedTransaction)...)
+}
+
+func (m *TransactionManager) AddMonitor(tm module.TransactionMonitor) {
+	m.monitorLock.Lock()
+	defer m.monitorLock.Unlock()
+
+	m.monitors = append(m.monitors, tm)
+}
+
+func (m *TransactionManager) RemoveMonitor(tm module.TransactionMonitor) {
+	m.monitorLock.Lock()
+	defer m.monitorLock.Unlock()
+
+	for i, mon := range m.monitors {
+		if mon == tm {
+			last := len(m.monitors) - 1
+			m.monitors[i] = m.monitors[last]
+			m.monitors[last] = nil
+			m.monitors = m.monitors[:last]
+			return
+		}
+	}
+}
+
+func (m *TransactionManager) notifyAdded(tx module.Transaction) {
+	m.monitorLock.Lock()
+	defer m.monitorLock.Unlock()
+
+	for _, mon := range m.monitors {
+		mon.OnTransactionAdded(tx)
+	}
+}
+
+func (m *TransactionManager) notifyDrops(drops []TxDrop) {
+	m.monitorLock.Lock()
+	defer m.monitorLock.Unlock()
+
+	for _, mon := range m.monitors {
+		for _, drop := range drops {
+			mon.OnTransactionDropped(drop.Tx, drop.Err)
+		}
 	}
 }
 
@@ -207,11 +340,22 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 	if m.txBucket.Has(tx.ID()) {
 		return ErrCommittedTransaction
 	}
+	checked, err := m.checkNonceInLock(tx)
+	if err != nil {
+		return err
+	}
 
 	pool := m.getTxPool(tx.Group())
-	if err := pool.Add(tx, direct); err != nil {
+	if checked {
+		// a transaction with same nonce may be replaced
+		err = pool.AddOrReplace(tx, direct)
+	} else {
+		err = pool.Add(tx, direct)
+	}
+	if err != nil {
 		return err
 	}
+	m.notifyAdded(tx)
 	if m.callback != nil {
 		cb := m.callback
 		m.callback = nil
@@ -220,6 +364,46 @@ func (m *TransactionManager) addInLock(tx transaction.Transaction, direct bool)
 	return nil
 }
 
+// Reload adds journaled transactions to the pools. They are validated
+// again with the world context and the timestamp threshold, and invalid
+// ones are removed from the journal. It returns the number of transactions
+// added to the pools.
+func (m *TransactionManager) Reload(wc state.WorldContext, j *txJournal) int {
+	items := j.Load()
+	if len(items) == 0 {
+		return 0
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	loaded := 0
+	for _, item := range items {
+		tx := item.tx
+		err := NewTxTimestampRangeFor(wc, tx.Group()).CheckTx(tx)
+		if err != nil && !ExpiredTransactionError.Equals(err) {
+			err = nil
+		}
+		if err == nil {
+			err = tx.PreValidate(wc, true)
+			if transaction.NotEnoughBalanceError.Equals(err) ||
+				transaction.FutureNonceError.Equals(err) {
+				err = nil
+			}
+		}
+		if err == nil {
+			err = m.addInLock(tx, item.direct)
+		}
+		if err == nil {
+			loaded += 1
+		} else if err != ErrDuplicateTransaction {
+			m.log.Debugf("DROP JOURNALED TX: id=%#x reason=%v", tx.ID(), err)
+			j.Remove(tx.ID())
+		}
+	}
+	return loaded
+}
+
 func (m *TransactionManager) Wait(wc state.WorldContext, cb func()) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -258,6 +442,7 @@ func NewTransactionManager(nid int, tsc *TxTimestampChecker, ptp *TransactionPoo
 		txBucket:     bk,
 		log:          logger,
 		txWaiters:    map[hashValue][]chan<- interface{}{},
+		drops:        map[hashValue]*module.TransactionDrop{},
 	}
 	ptp.SetTxManager(txm)
 	ntp.SetTxManager(txm)
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+)
+
+type mockTxMonitor struct {
+	added   []module.Transaction
+	dropped []module.Transaction
+	reasons []error
+}
+
+func (m *mockTxMonitor) OnTransactionAdded(tx module.Transaction) {
+	m.added = append(m.added, tx)
+}
+
+func (m *mockTxMonitor) OnTransactionDropped(tx module.Transaction, reason error) {
+	m.dropped = append(m.dropped, tx)
+	m.reasons = append(m.reasons, reason)
+}
+
+func TestTransactionManager_Monitor(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, _ := dbase.GetBucket(db.TransactionLocatorByHash)
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 5000, bk, &mockMonitor{}, log.New())
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, bk, &mockMonitor{}, log.New())
+	tm := NewTransactionManager(1, NewTimestampChecker(), ptp, ntp, bk, log.New())
+
+	mon := &mockTxMonitor{}
+	tm.AddMonitor(mon)
+
+	addr := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	tx1 := newMockTransaction([]byte("tx1"), addr, 1)
+	tx2 := newMockTransaction([]byte("tx2"), addr, 2)
+	tx1.NID, tx2.NID = 1, 1
+	assert.NoError(t, tm.Add(tx1, true))
+	assert.Equal(t, []module.Transaction{tx1}, mon.added)
+
+	// transactions failing to be added are not notified
+	assert.Equal(t, ErrDuplicateTransaction, tm.Add(tx1, true))
+	assert.Equal(t, []module.Transaction{tx1}, mon.added)
+
+	assert.NoError(t, tm.Add(tx2, true))
+	assert.Equal(t, []module.Transaction{tx1, tx2}, mon.added)
+
+	ntp.DropOldTXs(1)
+	assert.Equal(t, []module.Transaction{tx1}, mon.dropped)
+	assert.True(t, ExpiredTransactionError.Equals(mon.reasons[0]))
+
+	tm.RemoveMonitor(mon)
+	ntp.DropOldTXs(2)
+	assert.Len(t, mon.dropped, 1)
+}
+
+func TestTransactionManager_Inspect(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, _ := dbase.GetBucket(db.TransactionLocatorByHash)
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 5000, bk, &mockMonitor{}, log.New())
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, bk, &mockMonitor{}, log.New())
+	tm := NewTransactionManager(1, NewTimestampChecker(), ptp, ntp, bk, log.New())
+
+	addr1 := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.NewAddressFromString("hx2222222222222222222222222222222222222222")
+	tx1 := newMockTransaction([]byte("tx1"), addr1, 3)
+	tx2 := newMockTransaction([]byte("tx2"), addr1, 2)
+	tx3 := newMockTransaction([]byte("tx3"), addr2, 5)
+	assert.NoError(t, ntp.Add(tx1, true))
+	assert.NoError(t, ntp.Add(tx2, true))
+	assert.NoError(t, ntp.Add(tx3, true))
+
+	txs, total := tm.PoolTransactions(module.TransactionGroupNormal, 1, 10)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []module.Transaction{tx1, tx3}, txs)
+	txs, _ = tm.PoolTransactions(module.TransactionGroupNormal, 0, 1)
+	assert.Equal(t, []module.Transaction{tx2}, txs)
+
+	status := tm.PoolStatus(module.TransactionGroupNormal)
+	assert.Equal(t, 5000, status.Size)
+	assert.Equal(t, 3, status.Used)
+	assert.Equal(t, int64(2), status.OldestTimestamp)
+	assert.Equal(t, map[string]int{addr1.String(): 2, addr2.String(): 1}, status.Senders)
+	assert.Equal(t, 0, tm.PoolStatus(module.TransactionGroupPatch).Used)
+
+	_, err := tm.GetDrop(tx1.ID())
+	assert.Error(t, err)
+
+	evicted := tm.Evict([][]byte{tx1.ID(), []byte("tx4")})
+	assert.Equal(t, [][]byte{tx1.ID()}, evicted)
+	assert.False(t, tm.HasTx(tx1.ID()))
+
+	drop, err := tm.GetDrop(tx1.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, ErrEvictedTransaction, drop.Reason)
+}
@@ -17,6 +17,8 @@
 package service
 
 import (
+	"bytes"
+	"math/big"
 	"sync"
 	"time"
 
@@ -32,6 +34,10 @@ import (
 const (
 	configDefaultTxSliceCapacity = 1024
 	configMaxTxCount             = 1500
+
+	// configTxReplaceBumpRate is the minimum rate(in percent) of increase of
+	// the step limit to replace a transaction with same nonce.
+	configTxReplaceBumpRate = 10
 )
 
 type Monitor interface {
@@ -67,10 +73,16 @@ type TransactionPool struct {
 	size int
 	txdb db.Bucket
 
-	list *transactionList
+	list     *transactionList
+	ordering txOrdering
+
+	// limits of transactions of a sender. zero means no limit.
+	senderCount int
+	senderBytes int
 
 	mutex sync.Mutex
 
+	journal *txJournal
 	txm     TxWaiterManager
 	monitor Monitor
 	pcm     PoolCapacityMonitor
@@ -110,7 +122,8 @@ func (tp *TransactionPool) DropOldTXs(bts int64) {
 					"ExpiredTransaction(diff=%s)", TimestampToDuration(bts-tx.Timestamp()))
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), iter.err)
-			drops = append(drops, TxDrop{tx.ID(), iter.err})
+			drops = append(drops, TxDrop{tx.ID(), iter.err, tx})
+			tp.journal.Remove(tx.ID())
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
 		}
 		iter = next
@@ -147,7 +160,12 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	expired := make([]*txElement, 0, configDefaultTxSliceCapacity)
 	poolSize := tp.list.Len()
 	txSize := int(0)
-	for e := tp.list.Front(); e != nil && txSize < maxBytes && len(txs) < maxCount; e = e.Next() {
+	ordering := tp.ordering
+	for e := tp.list.Front(); e != nil; e = e.Next() {
+		// without ordering, transactions are selected in the order of the pool
+		if ordering == nil && (txSize >= maxBytes || len(txs) >= maxCount) {
+			break
+		}
 		tx := e.Value()
 		if err := tsr.CheckTx(tx); err != nil {
 			if ExpiredTransactionError.Equals(err) {
@@ -158,15 +176,21 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 			}
 			continue
 		}
-		bs := tx.Bytes()
-		if txSize+len(bs) > maxBytes {
-			break
+		if ordering == nil {
+			bs := tx.Bytes()
+			if txSize+len(bs) > maxBytes {
+				break
+			}
+			txSize += len(bs)
 		}
-		txSize += len(bs)
 		txs = append(txs, e)
 	}
 	lock.Unlock()
 
+	if ordering != nil {
+		txs = limitTxElements(ordering(wc, txs), maxBytes, maxCount)
+	}
+
 	// make list of valid transactions
 	validTxs := make([]module.Transaction, len(txs))
 	valNum := 0
@@ -190,7 +214,8 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 				tp.log.Debugf("PREVALIDATE FAIL: id=%#x reason=%v",
 					tx.ID(), err)
 			}
-			if !transaction.NotEnoughBalanceError.Equals(err) {
+			if !transaction.NotEnoughBalanceError.Equals(err) &&
+				!transaction.FutureNonceError.Equals(err) {
 				txs[invalidNum] = e
 				invalidNum += 1
 			}
@@ -216,6 +241,19 @@ func (tp *TransactionPool) Candidate(wc state.WorldContext, maxBytes int, maxCou
 	return validTxs[:valNum], txSize
 }
 
+// limitTxElements returns leading transactions within the limits.
+func limitTxElements(txs []*txElement, maxBytes int, maxCount int) []*txElement {
+	txSize := 0
+	for i, e := range txs {
+		bs := e.Value().Bytes()
+		if i >= maxCount || txSize+len(bs) > maxBytes {
+			return txs[:i]
+		}
+		txSize += len(bs)
+	}
+	return txs
+}
+
 func (tp *TransactionPool) CheckTxs(wc state.WorldContext) bool {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -245,12 +283,20 @@ func (tp *TransactionPool) Add(tx transaction.Transaction, direct bool) error {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
 
+	return tp.addInLock(tx, direct)
+}
+
+func (tp *TransactionPool) addInLock(tx transaction.Transaction, direct bool) error {
 	if tp.list.Len() >= tp.size {
 		return ErrTransactionPoolOverFlow
 	}
+	if err := tp.checkSenderQuotaInLock(tx, nil); err != nil {
+		return err
+	}
 
 	err := tp.list.Add(tx, direct)
 	if err == nil {
+		tp.journal.Add(tx, direct)
 		tp.monitor.OnAddTx(len(tx.Bytes()), direct)
 		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
 	}
@@ -277,6 +323,7 @@ func (tp *TransactionPool) RemoveList(txs module.TransactionList) {
 			continue
 		}
 		if ok, ts := tp.list.RemoveTx(t); ok {
+			tp.journal.Remove(t.ID())
 			if ts != 0 {
 				duration += now.Sub(time.Unix(0, ts))
 				count += 1
@@ -298,6 +345,88 @@ func (tp *TransactionPool) HasTx(tid []byte) bool {
 	return tp.list.HasTx(tid)
 }
 
+// checkSenderQuotaInLock returns SenderQuotaExceededError if the sender of
+// the transaction can't add it to the pool. The transaction to be replaced
+// is excluded from the usage of the sender.
+func (tp *TransactionPool) checkSenderQuotaInLock(tx, replaced transaction.Transaction) error {
+	if tp.senderCount <= 0 && tp.senderBytes <= 0 {
+		return nil
+	}
+	count, size := tp.list.UsageOf(tx.From())
+	count += 1
+	size += len(tx.Bytes())
+	if replaced != nil {
+		count -= 1
+		size -= len(replaced.Bytes())
+	}
+	if tp.senderCount > 0 && count > tp.senderCount {
+		return SenderQuotaExceededError.Errorf(
+			"SenderQuotaExceeded(from=%s,count=%d,limit=%d)",
+			tx.From(), count, tp.senderCount)
+	}
+	if tp.senderBytes > 0 && size > tp.senderBytes {
+		return SenderQuotaExceededError.Errorf(
+			"SenderQuotaExceeded(from=%s,bytes=%d,limit=%d)",
+			tx.From(), size, tp.senderBytes)
+	}
+	return nil
+}
+
+// canReplace returns whether tx2 has the step limit high enough to replace
+// tx1.
+func canReplace(tx1, tx2 transaction.Transaction) bool {
+	limit := new(big.Int).Mul(tx1.GetStepLimit(), big.NewInt(100+configTxReplaceBumpRate))
+	limit.Div(limit, big.NewInt(100))
+	return tx2.GetStepLimit().Cmp(limit) >= 0 &&
+		tx2.GetStepLimit().Cmp(tx1.GetStepLimit()) > 0
+}
+
+// AddOrReplace adds the transaction like Add. But if there is a transaction
+// of the sender with same nonce, then it replaces the transaction if the
+// new one has higher step limit enough. The replaced transaction is dropped
+// with ReplacedTransactionError.
+func (tp *TransactionPool) AddOrReplace(tx transaction.Transaction, direct bool) error {
+	if tx == nil {
+		return nil
+	}
+	lock := common.LockForAutoCall(&tp.mutex)
+	defer lock.Unlock()
+
+	e := tp.list.GetByNonce(tx.From(), tx.Nonce())
+	if e == nil {
+		return tp.addInLock(tx, direct)
+	}
+	old := e.Value()
+	if bytes.Equal(old.ID(), tx.ID()) {
+		return ErrDuplicateTransaction
+	}
+	if !canReplace(old, tx) {
+		return DuplicateNonceError.Errorf(
+			"DuplicateNonce(nonce=%s,tx=%#x,stepLimit=%s)",
+			tx.Nonce(), old.ID(), old.GetStepLimit())
+	}
+	if err := tp.checkSenderQuotaInLock(tx, old); err != nil {
+		return err
+	}
+	if err := tp.list.Add(tx, direct); err != nil {
+		return err
+	}
+	tp.journal.Add(tx, direct)
+	tp.monitor.OnAddTx(len(tx.Bytes()), direct)
+
+	tp.list.Remove(e)
+	tp.journal.Remove(old.ID())
+	tp.list.rebuildBloom()
+	e.err = ReplacedTransactionError.Errorf("ReplacedBy(tx=%#x)", tx.ID())
+	tp.log.Debugf("DROP TX: id=0x%x reason=%v", old.ID(), e.err)
+	tp.monitor.OnDropTx(len(old.Bytes()), e.ts != 0)
+	drops := []TxDrop{{old.ID(), e.err, old}}
+	lock.CallAfterUnlock(func() {
+		tp.txm.OnTxDrops(drops)
+	})
+	return nil
+}
+
 func (tp *TransactionPool) Size() int {
 	return tp.size
 }
@@ -309,6 +438,80 @@ func (tp *TransactionPool) Used() int {
 	return tp.list.Len()
 }
 
+// Transactions returns at most limit transactions starting at offset in
+// the order of the pool and the number of all transactions in the pool.
+func (tp *TransactionPool) Transactions(offset, limit int) ([]module.Transaction, int) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	txs := make([]module.Transaction, 0, limit)
+	idx := 0
+	for e := tp.list.Front(); e != nil && len(txs) < limit; e = e.Next() {
+		if idx >= offset {
+			txs = append(txs, e.Value())
+		}
+		idx++
+	}
+	return txs, tp.list.Len()
+}
+
+func (tp *TransactionPool) Status() *module.TransactionPoolStatus {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	status := &module.TransactionPoolStatus{
+		Size:    tp.size,
+		Used:    tp.list.Len(),
+		Senders: make(map[string]int),
+	}
+	for e := tp.list.Front(); e != nil; e = e.Next() {
+		tx := e.Value()
+		if ts := tx.Timestamp(); status.OldestTimestamp == 0 || ts < status.OldestTimestamp {
+			status.OldestTimestamp = ts
+		}
+		status.Senders[tx.From().String()] += 1
+	}
+	return status
+}
+
+// Evict removes the transactions in the pool for the reason.
+// It returns ids of removed transactions.
+func (tp *TransactionPool) Evict(ids [][]byte, reason error) [][]byte {
+	lock := common.LockForAutoCall(&tp.mutex)
+	defer lock.Unlock()
+
+	var drops []TxDrop
+	var evicted [][]byte
+	for _, id := range ids {
+		e := tp.list.Get(id)
+		if e == nil || !tp.list.Remove(e) {
+			continue
+		}
+		tx := e.Value()
+		e.err = reason
+		tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), e.err)
+		drops = append(drops, TxDrop{tx.ID(), e.err, tx})
+		evicted = append(evicted, tx.ID())
+		tp.journal.Remove(tx.ID())
+		tp.monitor.OnDropTx(len(tx.Bytes()), e.ts != 0)
+	}
+	if len(drops) > 0 {
+		lock.CallAfterUnlock(func() {
+			tp.txm.OnTxDrops(drops)
+		})
+		tp.pcm.OnPoolCapacityUpdated(tp.group, tp.size, tp.list.Len())
+	}
+	return evicted
+}
+
+// SetJournal sets the journal to record transactions in the pool.
+func (tp *TransactionPool) SetJournal(j *txJournal) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	tp.journal = j
+}
+
 func (tp *TransactionPool) SetTxManager(txm TxWaiterManager) {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -316,6 +519,30 @@ func (tp *TransactionPool) SetTxManager(txm TxWaiterManager) {
 	tp.txm = txm
 }
 
+// SetOrdering sets the ordering policy of transactions for block candidates.
+// Available policies are listed in TxOrderingOptions.
+func (tp *TransactionPool) SetOrdering(name string) error {
+	ordering, err := txOrderingFor(name)
+	if err != nil {
+		return err
+	}
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	tp.ordering = ordering
+	return nil
+}
+
+// SetSenderQuota sets the maximum number and bytes of the transactions of
+// a sender in the pool. Zero or negative value means no limit.
+func (tp *TransactionPool) SetSenderQuota(count, bytes int) {
+	tp.mutex.Lock()
+	defer tp.mutex.Unlock()
+
+	tp.senderCount = count
+	tp.senderBytes = bytes
+}
+
 func (tp *TransactionPool) SetPoolCapacityMonitor(pcm PoolCapacityMonitor) {
 	tp.mutex.Lock()
 	defer tp.mutex.Unlock()
@@ -344,7 +571,8 @@ func (tp *TransactionPool) dropTransactions(txs []*txElement) {
 				tp.log.Panicf("No reason to drop the tx=<%#x>", tx.ID())
 			}
 			tp.log.Debugf("DROP TX: id=0x%x reason=%v", tx.ID(), e.err)
-			drops = append(drops, TxDrop{tx.ID(), e.err})
+			drops = append(drops, TxDrop{tx.ID(), e.err, tx})
+			tp.journal.Remove(tx.ID())
 			tp.monitor.OnDropTx(len(tx.Bytes()), direct)
 		}
 	}
@@ -1,9 +1,13 @@
 package service
 
 import (
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/icon-project/goloop/common"
 	"github.com/icon-project/goloop/common/db"
 	"github.com/icon-project/goloop/common/log"
@@ -42,3 +46,120 @@ func TestTransactionPool_Add(t *testing.T) {
 		t.Error("Fail to add transaction with valid network ID")
 	}
 }
+
+func TestTransactionPool_AddOrReplace(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, _ := dbase.GetBucket(db.TransactionLocatorByHash)
+	ptp := NewTransactionPool(module.TransactionGroupPatch, 5000, bk, &mockMonitor{}, log.New())
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, bk, &mockMonitor{}, log.New())
+	tm := NewTransactionManager(1, NewTimestampChecker(), ptp, ntp, bk, log.New())
+
+	addr := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	newTx := func(id string, ts int64, nonce, stepLimit int64) *mockTransaction {
+		tx := newMockTransaction([]byte(id), addr, ts)
+		tx.nonce = big.NewInt(nonce)
+		tx.stepLimit = big.NewInt(stepLimit)
+		return tx
+	}
+	tx1 := newTx("tx1", 1, 1, 100000)
+	tx2 := newTx("tx2", 2, 2, 100000)
+	assert.NoError(t, ntp.AddOrReplace(tx1, true))
+	assert.NoError(t, ntp.AddOrReplace(tx2, true))
+	assert.Equal(t, ErrDuplicateTransaction, ntp.AddOrReplace(tx1, true))
+
+	// not enough increase of the step limit
+	tx3 := newTx("tx3", 3, 1, 105000)
+	err := ntp.AddOrReplace(tx3, true)
+	assert.True(t, DuplicateNonceError.Equals(err))
+	assert.False(t, ntp.HasTx(tx3.ID()))
+
+	rc, err := tm.WaitResult(tx1.ID())
+	assert.NoError(t, err)
+
+	tx4 := newTx("tx4", 4, 1, 110000)
+	assert.NoError(t, ntp.AddOrReplace(tx4, true))
+	assert.False(t, ntp.HasTx(tx1.ID()))
+	assert.True(t, ntp.HasTx(tx4.ID()))
+	assert.Equal(t, 2, ntp.Used())
+
+	bloom := ntp.GetBloom()
+	assert.False(t, bloom.Contains(tx1.ID()))
+	assert.True(t, bloom.Contains(tx2.ID()))
+	assert.True(t, bloom.Contains(tx4.ID()))
+
+	select {
+	case r := <-rc:
+		assert.True(t, ReplacedTransactionError.Equals(r.(error)))
+	case <-time.After(time.Second):
+		assert.Fail(t, "replaced transaction isn't reported")
+	}
+	drop, err := tm.GetDrop(tx1.ID())
+	assert.NoError(t, err)
+	assert.True(t, ReplacedTransactionError.Equals(drop.Reason))
+}
+
+func TestTransactionPool_ReplaceBloom(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, _ := dbase.GetBucket(db.TransactionLocatorByHash)
+	ntp := NewTransactionPool(module.TransactionGroupNormal, 5000, bk, &mockMonitor{}, log.New())
+
+	addr := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	newTx := func(id string, ts int64, nonce, stepLimit int64) *mockTransaction {
+		tx := newMockTransaction([]byte(id), addr, ts)
+		tx.nonce = big.NewInt(nonce)
+		tx.stepLimit = big.NewInt(stepLimit)
+		return tx
+	}
+	var txs []*mockTransaction
+	for i := 0; i < 10; i++ {
+		tx := newTx(fmt.Sprintf("tx%d", i), int64(i), int64(i), 100000)
+		assert.NoError(t, ntp.AddOrReplace(tx, true))
+		txs = append(txs, tx)
+	}
+
+	// replaced ones are evicted from the bloom
+	var rtxs []*mockTransaction
+	for i := 0; i < 3; i++ {
+		rtx := newTx(fmt.Sprintf("rtx%d", i), int64(10+i), int64(i), 110000)
+		assert.NoError(t, ntp.AddOrReplace(rtx, true))
+		rtxs = append(rtxs, rtx)
+
+		bloom := ntp.GetBloom()
+		assert.False(t, bloom.Contains(txs[i].ID()))
+		assert.True(t, bloom.Contains(rtx.ID()))
+	}
+	bloom := ntp.GetBloom()
+	for _, tx := range txs[0:3] {
+		assert.False(t, bloom.Contains(tx.ID()))
+	}
+	for _, tx := range append(txs[3:], rtxs...) {
+		assert.True(t, bloom.Contains(tx.ID()))
+	}
+	assert.Equal(t, 10, ntp.Used())
+}
+
+func TestTransactionPool_SenderQuota(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, _ := dbase.GetBucket(db.TransactionLocatorByHash)
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, bk, &mockMonitor{}, log.New())
+	pool.SetSenderQuota(2, 0)
+
+	addr1 := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.NewAddressFromString("hx2222222222222222222222222222222222222222")
+	assert.NoError(t, pool.Add(newMockTransaction([]byte("tx1"), addr1, 1), true))
+	assert.NoError(t, pool.Add(newMockTransaction([]byte("tx2"), addr1, 2), true))
+	err := pool.Add(newMockTransaction([]byte("tx3"), addr1, 3), true)
+	assert.True(t, SenderQuotaExceededError.Equals(err))
+	assert.NoError(t, pool.Add(newMockTransaction([]byte("tx4"), addr2, 4), true))
+
+	// removal of a transaction gives the room back to the sender
+	pool.Evict([][]byte{[]byte("tx1")}, ErrEvictedTransaction)
+	assert.NoError(t, pool.Add(newMockTransaction([]byte("tx3"), addr1, 3), true))
+
+	// limit by bytes of transactions(bytes of mock transactions are ids)
+	pool.SetSenderQuota(0, 8)
+	assert.NoError(t, pool.Add(newMockTransaction([]byte("tx5"), addr2, 5), true))
+	err = pool.Add(newMockTransaction([]byte("tx6"), addr2, 6), true)
+	assert.True(t, SenderQuotaExceededError.Equals(err))
+	assert.Equal(t, 4, pool.Used())
+}
@@ -33,9 +33,18 @@ type TransactionReactor struct {
 	tsc        *TxTimestampChecker
 	log        log.Logger
 	ts         *TransactionShare
+	limiter    *txRateLimiter
 }
 
 func (r *TransactionReactor) OnReceive(subProtocol module.ProtocolInfo, buf []byte, peerId module.PeerID) (bool, error) {
+	switch subProtocol {
+	case protoPropagateTransaction, protoResponseTransaction:
+		if !r.limiter.Allow(peerId) {
+			r.log.Debugf("Drop transaction from=%s by rate limit", peerId.String())
+			return false, ErrTransactionRateLimited
+		}
+	}
+
 	switch subProtocol {
 	case protoPropagateTransaction:
 		tx, err := transaction.NewTransaction(buf)
@@ -91,6 +100,7 @@ func (r *TransactionReactor) OnJoin(id module.PeerID) {
 
 func (r *TransactionReactor) OnLeave(id module.PeerID) {
 	r.ts.HandleLeave(id)
+	r.limiter.Remove(id)
 }
 
 func (r *TransactionReactor) Start(wallet module.Wallet) {
@@ -104,12 +114,16 @@ func (r *TransactionReactor) Stop() {
 	_ = r.nm.UnregisterReactor(r)
 }
 
-func NewTransactionReactor(nm module.NetworkManager, tm *TransactionManager) *TransactionReactor {
+// NewTransactionReactor returns a reactor for transactions. It accepts
+// rate transactions per second from each peer at most. Zero or negative
+// rate means no limit.
+func NewTransactionReactor(nm module.NetworkManager, tm *TransactionManager, rate int) *TransactionReactor {
 	ra := &TransactionReactor{
-		tm:  tm,
-		nm:  nm,
-		log: tm.Logger(),
-		ts:  NewTransactionShare(tm),
+		tm:      tm,
+		nm:      nm,
+		log:     tm.Logger(),
+		ts:      NewTransactionShare(tm),
+		limiter: newTxRateLimiter(rate),
 	}
 	return ra
 }
@@ -112,7 +112,8 @@ type transition struct {
 
 	syncer ssync.Syncer
 
-	ti *module.TraceInfo
+	ti      *module.TraceInfo
+	tracker *state.DiffTracker
 }
 
 type transitionResult struct {
@@ -138,6 +139,19 @@ func (tr *transitionResult) Bytes() []byte {
 	}
 }
 
+// StateHashFromResult returns the hash of the world state in the result
+// of the block. It's the root of proofs for accounts in the state.
+func StateHashFromResult(result []byte) ([]byte, error) {
+	if len(result) == 0 {
+		return nil, nil
+	}
+	tr, err := newTransitionResultFromBytes(result)
+	if err != nil {
+		return nil, errors.IllegalArgumentError.Wrap(err, "InvalidResult")
+	}
+	return tr.StateHash, nil
+}
+
 func patchTransition(t *transition, patchTXs module.TransactionList) *transition {
 	if patchTXs == nil {
 		patchTXs = transaction.NewTransactionListFromSlice(t.db, nil)
@@ -494,6 +508,15 @@ func (t *transition) doExecute(alreadyValidated bool) {
 	ctx := contract.NewContext(wc, t.cm, t.eem, t.chain, t.log, t.ti)
 	ctx.ClearCache()
 
+	if t.ti != nil {
+		if _, ok := t.ti.Callback.(module.TraceStateCallback); ok {
+			if t.tracker, err = state.NewDiffTracker(wc); err != nil {
+				t.reportExecution(err)
+				return
+			}
+		}
+	}
+
 	startTime := time.Now()
 
 	patchReceipts := make([]txresult.Receipt, patchCount)
@@ -605,12 +628,21 @@ func (t *transition) executeTxs(l module.TransactionList, ctx contract.Context,
 		}
 		return nil
 	}
-	if cc := t.chain.ConcurrencyLevel(); cc > 1 {
+	if cc := t.chain.ConcurrencyLevel(); cc > 1 && t.ti == nil {
 		return t.executeTxsConcurrent(cc, l, ctx, rctBuf)
 	}
 	return t.executeTxsSequential(l, ctx, rctBuf)
 }
 
+// stateTraceOf returns the tracker and the callback for state changes
+// if the transaction is the one to be traced.
+func (t *transition) stateTraceOf(group module.TransactionGroup, idx int) (*state.DiffTracker, module.TraceStateCallback) {
+	if t.tracker == nil || t.ti.Group != group || t.ti.Index != idx {
+		return nil, nil
+	}
+	return t.tracker, t.ti.Callback.(module.TraceStateCallback)
+}
+
 func (t *transition) finalizeNormalTransaction() error {
 	return t.normalTransactions.Flush()
 }
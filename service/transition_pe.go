@@ -2,127 +2,211 @@ package service
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/icon-project/goloop/common/errors"
 	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/server/metric"
 	"github.com/icon-project/goloop/service/contract"
 	"github.com/icon-project/goloop/service/state"
 	"github.com/icon-project/goloop/service/transaction"
 	"github.com/icon-project/goloop/service/txresult"
 )
 
-type executionContext struct {
-	waiter    chan struct{}
-	lastError error
+// speculation is the result of the execution of a transaction on the state
+// after committing first base transactions.
+type speculation struct {
+	base int
+	rct  txresult.Receipt
+	rws  *state.ReadWriteSet
+	err  error
+	done chan struct{}
+}
+
+// parallelExecutor executes transactions optimistically in parallel.
+// Each transaction is executed on the latest committed state while
+// recording accessed accounts. Then they are committed in the order of
+// the list. If accounts accessed by a transaction are changed by the
+// transactions committed after its base state, then the transaction is
+// executed again on the committed state. A transaction failed on the
+// state before the committed state is also executed again, because the
+// failure may be caused by the stale state. So the result is same as the
+// result of sequential execution.
+type parallelExecutor struct {
+	t   *transition
+	txs []transaction.Transaction
+
 	lock      sync.Mutex
+	committed int
+	snapshot  state.WorldSnapshot
+
+	next    int32
+	stopped int32
 }
 
-func (c *executionContext) Done() {
-	c.waiter <- struct{}{}
+func (e *parallelExecutor) latest() (int, state.WorldSnapshot) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.committed, e.snapshot
 }
 
-func (c *executionContext) Ready() {
-	<-c.waiter
+func (e *parallelExecutor) commit(n int, wss state.WorldSnapshot) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.committed = n
+	e.snapshot = wss
 }
 
-func (c *executionContext) Error() error {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	return c.lastError
+func (e *parallelExecutor) canceled() bool {
+	return atomic.LoadInt32(&e.stopped) != 0 || e.t.canceled()
 }
 
-func (c *executionContext) Report(e error) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	if c.lastError != nil {
-		c.lastError = e
+// work executes transactions until all of them are taken. After it's
+// canceled, it completes the remaining ones with ErrTransitionInterrupted
+// without execution, so waiting for them never blocks.
+func (e *parallelExecutor) work(specs []*speculation) {
+	for {
+		idx := int(atomic.AddInt32(&e.next, 1))
+		if idx >= len(specs) {
+			return
+		}
+		spec := specs[idx]
+		if e.canceled() {
+			spec.err = ErrTransitionInterrupted
+		} else {
+			base, wss := e.latest()
+			spec.base = base
+			spec.rct, spec.rws, spec.err = e.t.executeTxOn(wss, e.txs[idx], idx)
+		}
+		close(spec.done)
 	}
 }
 
-func newExecutionContext(n int) *executionContext {
-	ch := make(chan struct{}, n)
-	for i := 0; i < n; i++ {
-		ch <- struct{}{}
+// executeTxOn executes the transaction on the snapshot, then it returns the
+// receipt and accessed accounts.
+func (t *transition) executeTxOn(wss state.WorldSnapshot, txo transaction.Transaction, idx int) (txresult.Receipt, *state.ReadWriteSet, error) {
+	for trial := 0; ; trial++ {
+		ws, err := state.NewRecordingWorldState(wss)
+		if err != nil {
+			return nil, nil, err
+		}
+		ws.EnableNodeCache()
+		ctx := contract.NewContext(state.NewWorldContext(ws, t.bi),
+			t.cm, t.eem, t.chain, t.log, t.ti)
+
+		txh, err := txo.GetHandler(t.cm)
+		if err != nil {
+			t.log.Errorf("Fail to GetHandler err=%+v", err)
+			return nil, nil, err
+		}
+		ctx.SetTransactionInfo(&state.TransactionInfo{
+			Group:     txo.Group(),
+			Index:     int32(idx),
+			Timestamp: txo.Timestamp(),
+			Nonce:     txo.Nonce(),
+			Hash:      txo.ID(),
+			From:      txo.From(),
+		})
+		ctx.UpdateSystemInfo()
+		rct, err := txh.Execute(ctx, false)
+		txh.Dispose()
+		if err == nil {
+			rws, err := state.ReadWriteSetOf(ws)
+			return rct, rws, err
+		}
+		if !errors.ExecutionFailError.Equals(err) {
+			t.log.Warnf("Fail to execute transaction err=%+v", err)
+			return nil, nil, err
+		}
+		if trial == RetryCount {
+			t.log.Warnf("Fail to execute transaction retry=%d err=%+v", trial, err)
+			return nil, nil, err
+		}
+		t.log.Warnf("RETRY TX <%#x> for err=%+v", txo.ID(), err)
 	}
-	return &executionContext{waiter: ch}
 }
 
 func (t *transition) executeTxsConcurrent(level int, l module.TransactionList, ctx contract.Context, rctBuf []txresult.Receipt) error {
-	ec := newExecutionContext(level)
-
-	cnt := 0
+	var txs []transaction.Transaction
 	for i := l.Iterator(); i.Has(); i.Next() {
-		if err := ec.Error(); err != nil {
+		txi, _, err := i.Get()
+		if err != nil {
+			t.log.Errorf("Fail to iterate transaction list err=%+v", err)
 			return err
 		}
+		txs = append(txs, txi.(transaction.Transaction))
+	}
+	if len(txs) == 0 {
+		return nil
+	}
 
-		if t.step == stepCanceled {
+	e := &parallelExecutor{
+		t:        t,
+		txs:      txs,
+		snapshot: ctx.GetSnapshot(),
+		next:     -1,
+	}
+	specs := make([]*speculation, len(txs))
+	for i := range specs {
+		specs[i] = &speculation{done: make(chan struct{})}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(level)
+	for i := 0; i < level; i++ {
+		go func() {
+			defer wg.Done()
+			e.work(specs)
+		}()
+	}
+	defer func() {
+		atomic.StoreInt32(&e.stopped, 1)
+		wg.Wait()
+	}()
+
+	written := make([]*state.ReadWriteSet, 0, len(txs))
+	conflicts := 0
+	for idx, spec := range specs {
+		<-spec.done
+		if spec.err == ErrTransitionInterrupted || t.canceled() {
 			return ErrTransitionInterrupted
 		}
 
-		txi, _, err := i.Get()
-		if err != nil {
-			t.log.Errorf("Fail to iterate transaction list err=%+v", err)
-			return err
+		base, wss := e.latest()
+		rct, rws := spec.rct, spec.rws
+		conflict := spec.err != nil
+		if conflict && spec.base == base {
+			return spec.err
 		}
-		txo := txi.(transaction.Transaction)
-		txh, err := txo.GetHandler(t.cm)
+		for i := spec.base; !conflict && i < base; i++ {
+			conflict = rws.Conflicts(written[i])
+		}
+		if conflict {
+			var err error
+			conflicts++
+			t.log.Tracef("CONFLICT TX <%#x> base=%d", txs[idx].ID(), spec.base)
+			if rct, rws, err = t.executeTxOn(wss, txs[idx], idx); err != nil {
+				return err
+			}
+		}
+
+		ws, err := state.WorldStateFromSnapshot(wss)
 		if err != nil {
-			t.log.Debugf("Fail to handle transaction for %+v", err)
 			return err
 		}
-		wc, err2 := txh.Prepare(ctx)
-		ctx = contract.NewContext(wc, t.cm, t.eem, t.chain, t.log, t.ti)
-		if err2 != nil {
-			t.log.Debugf("Fail to prepare for %+v", err2)
-			return err2
+		if err := state.ApplyReadWriteSet(ws, rws); err != nil {
+			return err
 		}
-
-		ec.Ready()
-		go func(ctx contract.Context, wc state.WorldContext, txo transaction.Transaction, cnt int, rb *txresult.Receipt) {
-			ctx.SetTransactionInfo(&state.TransactionInfo{
-				Group:     txo.Group(),
-				Index:     int32(cnt),
-				Timestamp: txo.Timestamp(),
-				Nonce:     txo.Nonce(),
-				Hash:      txo.ID(),
-				From:      txo.From(),
-			})
-			ctx.UpdateSystemInfo()
-			wvs := ctx.WorldVirtualState()
-			for trials := RetryCount + 1; trials > 0; trials -= 1 {
-				rct, err := txh.Execute(ctx, false)
-				txh.Dispose()
-
-				if err == nil {
-					*rb = rct
-					break
-				}
-
-				if !errors.ExecutionFailError.Equals(err) || trials <= 1 {
-					t.log.Debugf("Fail to execute transaction err=%+v", err)
-					ec.Report(err)
-					break
-				}
-
-				t.log.Warnf("RETRY TX <%#x> for err=%+v", txo.ID(), err)
-
-				txh, err = txo.GetHandler(t.cm)
-				if err != nil {
-					t.log.Debugf("Fail to get handler err=%+v", err)
-					ec.Report(err)
-					break
-				}
-				ctx = contract.NewContext(wc, t.cm, t.eem, t.chain, t.log, t.ti)
-			}
-			wvs.Commit()
-			ec.Done()
-		}(ctx, wc, txo, cnt, &rctBuf[cnt])
-
-		cnt++
+		rctBuf[idx] = rct
+		written = append(written, rws)
+		e.commit(base+1, ws.GetSnapshot())
 	}
-	if wvs := ctx.WorldVirtualState(); wvs != nil {
-		wvs.Realize()
+
+	_, wss := e.latest()
+	if err := ctx.Reset(wss); err != nil {
+		return err
 	}
+	t.log.Debugf("Parallel execution txs=%d conflicts=%d", len(txs), conflicts)
+	metric.OnParallelExecution(t.chain.MetricContext(), len(txs), conflicts)
 	return nil
 }
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/common/intconv"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/contract"
+	"github.com/icon-project/goloop/service/state"
+	"github.com/icon-project/goloop/service/transaction"
+	"github.com/icon-project/goloop/service/txresult"
+	"github.com/icon-project/goloop/test"
+)
+
+type peChain struct {
+	test.ChainBase
+	level int
+}
+
+func (c *peChain) ConcurrencyLevel() int {
+	return c.level
+}
+
+func (c *peChain) MetricContext() context.Context {
+	return context.Background()
+}
+
+var peCounterKey = []byte("counter")
+
+const (
+	peTransfer = iota
+	peIncrement
+	peExpect
+)
+
+// peTransaction is a transaction for the test of parallel execution.
+// peTransfer transfers value from the sender to the receiver, peIncrement
+// increases the counter in the storage of the receiver, and peExpect fails
+// with an error unless the counter is same as value. onExecute is called
+// on every execution if it's not nil.
+type peTransaction struct {
+	*mockTransaction
+	op        int
+	to        module.Address
+	value     int64
+	onExecute func()
+}
+
+func (t *peTransaction) To() module.Address {
+	return t.to
+}
+
+func (t *peTransaction) GetHandler(cm contract.ContractManager) (transaction.Handler, error) {
+	return &peHandler{t}, nil
+}
+
+type peHandler struct {
+	tx *peTransaction
+}
+
+func (h *peHandler) Execute(ctx contract.Context, estimate bool) (txresult.Receipt, error) {
+	tx := h.tx
+	if tx.onExecute != nil {
+		tx.onExecute()
+	}
+	status := module.StatusSuccess
+	rct := txresult.NewReceipt(ctx.Database(), ctx.Revision(), tx.to)
+	switch tx.op {
+	case peTransfer:
+		value := big.NewInt(tx.value)
+		as1 := ctx.GetAccountState(tx.From().ID())
+		if bal := as1.GetBalance(); bal.Cmp(value) < 0 {
+			status = module.StatusOutOfBalance
+		} else {
+			as1.SetBalance(new(big.Int).Sub(bal, value))
+			as2 := ctx.GetAccountState(tx.to.ID())
+			as2.SetBalance(new(big.Int).Add(as2.GetBalance(), value))
+			rct.AddLog(tx.to, [][]byte{[]byte("Transfer(int)")},
+				[][]byte{intconv.Int64ToBytes(tx.value)})
+		}
+	case peIncrement, peExpect:
+		as := ctx.GetAccountState(tx.to.ID())
+		bs, err := as.GetValue(peCounterKey)
+		if err != nil {
+			return nil, err
+		}
+		counter := intconv.BytesToInt64(bs)
+		if tx.op == peExpect {
+			if counter != tx.value {
+				return nil, errors.InvalidStateError.Errorf(
+					"UnexpectedCounter(exp=%d,real=%d)", tx.value, counter)
+			}
+		} else {
+			counter += 1
+			if _, err := as.SetValue(peCounterKey, intconv.Int64ToBytes(counter)); err != nil {
+				return nil, err
+			}
+		}
+		rct.AddLog(tx.to, [][]byte{[]byte("Counter(int)")},
+			[][]byte{intconv.Int64ToBytes(counter)})
+	}
+	rct.SetResult(status, big.NewInt(1000), big.NewInt(1), nil)
+	return rct, nil
+}
+
+func (h *peHandler) Dispose() {
+}
+
+func newPETransaction(i int, from module.Address, op int, to module.Address, value int64) *peTransaction {
+	id := []byte(fmt.Sprintf("tx%04d", i))
+	return &peTransaction{
+		mockTransaction: newMockTransaction(id, from, 0),
+		op:              op,
+		to:              to,
+		value:           value,
+	}
+}
+
+func executeTxsWithLevel(t *testing.T, mdb db.Database, wss state.WorldSnapshot, l module.TransactionList, level int) ([][]byte, []byte) {
+	tr := &transition{
+		db:    mdb,
+		chain: &peChain{level: level},
+		bi:    common.NewBlockInfo(1, 1000),
+		log:   log.New(),
+	}
+	ws, err := state.WorldStateFromSnapshot(wss)
+	assert.NoError(t, err)
+	ctx := contract.NewContext(state.NewWorldContext(ws, tr.bi),
+		nil, nil, tr.chain, tr.log, nil)
+	var cnt int
+	for itr := l.Iterator(); itr.Has(); itr.Next() {
+		cnt++
+	}
+	rctBuf := make([]txresult.Receipt, cnt)
+	assert.NoError(t, tr.executeTxs(l, ctx, rctBuf))
+
+	rcts := make([][]byte, cnt)
+	for i, rct := range rctBuf {
+		rcts[i] = rct.Bytes()
+	}
+	return rcts, ctx.GetSnapshot().StateHash()
+}
+
+func TestTransition_ExecuteTxsConcurrent(t *testing.T) {
+	mdb := db.NewMapDB()
+	ws := state.NewWorldState(mdb, nil, nil)
+	var eoas []module.Address
+	for i := 0; i < 4; i++ {
+		addr := common.NewAddressFromString(fmt.Sprintf("hx%040x", i+1))
+		eoas = append(eoas, addr)
+	}
+	ws.GetAccountState(eoas[0].ID()).SetBalance(big.NewInt(1000))
+	score := common.NewAddressFromString("cx0000000000000000000000000000000000000100")
+
+	// transfers between same accounts depend on the previous ones, and
+	// increments of the counter race for the storage. peExpect fails on
+	// the stale state, so it should be executed again on the committed one.
+	var txs []module.Transaction
+	counter := int64(0)
+	for i := 0; i < 200; i++ {
+		var tx *peTransaction
+		switch i % 5 {
+		case 0:
+			tx = newPETransaction(i, eoas[0], peTransfer, eoas[1], 10)
+		case 1:
+			tx = newPETransaction(i, eoas[1], peTransfer, eoas[2], 7)
+		case 2:
+			tx = newPETransaction(i, eoas[2], peTransfer, eoas[0], 5)
+		case 3:
+			counter++
+			tx = newPETransaction(i, eoas[3], peIncrement, score, 0)
+		case 4:
+			tx = newPETransaction(i, eoas[3], peExpect, score, counter)
+		}
+		txs = append(txs, tx)
+	}
+	l := transaction.NewTransactionListV1FromSlice(txs)
+	wss := ws.GetSnapshot()
+
+	rcts1, hash1 := executeTxsWithLevel(t, mdb, wss, l, 1)
+	for i := 0; i < 10; i++ {
+		rcts2, hash2 := executeTxsWithLevel(t, mdb, wss, l, 4)
+		assert.Equal(t, rcts1, rcts2)
+		assert.Equal(t, hash1, hash2)
+	}
+
+	ws, err := state.WorldStateFromSnapshot(wss)
+	assert.NoError(t, err)
+	ws.GetAccountState(score.ID()).SetValue(peCounterKey, intconv.Int64ToBytes(1))
+	txs = []module.Transaction{
+		newPETransaction(0, eoas[3], peExpect, score, 0),
+	}
+	tr := &transition{
+		db:    mdb,
+		chain: &peChain{level: 4},
+		bi:    common.NewBlockInfo(1, 1000),
+		log:   log.New(),
+	}
+	ctx := contract.NewContext(state.NewWorldContext(ws, tr.bi),
+		nil, nil, tr.chain, tr.log, nil)
+	err = tr.executeTxs(transaction.NewTransactionListV1FromSlice(txs), ctx,
+		make([]txresult.Receipt, 1))
+	assert.True(t, errors.InvalidStateError.Equals(err))
+}
+
+func TestTransition_ExecuteTxsConcurrentCancel(t *testing.T) {
+	mdb := db.NewMapDB()
+	wss := state.NewWorldState(mdb, nil, nil).GetSnapshot()
+	from := common.NewAddressFromString("hx0000000000000000000000000000000000000001")
+	score := common.NewAddressFromString("cx0000000000000000000000000000000000000100")
+
+	// the transition is canceled while a transaction in the middle is
+	// executed, then it should return without waiting for the rest.
+	for i := 0; i < 20; i++ {
+		tr := &transition{
+			db:    mdb,
+			chain: &peChain{level: 4},
+			bi:    common.NewBlockInfo(1, 1000),
+			log:   log.New(),
+		}
+		var txs []module.Transaction
+		for j := 0; j < 100; j++ {
+			tx := newPETransaction(j, from, peIncrement, score, 0)
+			if j == 10+i*4 {
+				tx.onExecute = func() {
+					tr.cancelExecution()
+				}
+			}
+			txs = append(txs, tx)
+		}
+		ws, err := state.WorldStateFromSnapshot(wss)
+		assert.NoError(t, err)
+		ctx := contract.NewContext(state.NewWorldContext(ws, tr.bi),
+			nil, nil, tr.chain, tr.log, nil)
+
+		done := make(chan error, 1)
+		go func() {
+			done <- tr.executeTxs(transaction.NewTransactionListV1FromSlice(txs),
+				ctx, make([]txresult.Receipt, len(txs)))
+		}()
+		select {
+		case err := <-done:
+			assert.Equal(t, ErrTransitionInterrupted, err)
+		case <-time.After(5 * time.Second):
+			assert.FailNow(t, "blocked after cancel")
+		}
+	}
+}
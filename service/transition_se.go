@@ -22,6 +22,7 @@ func (t *transition) executeTxsSequential(l module.TransactionList, ctx contract
 		}
 		txo := txi.(transaction.Transaction)
 		t.log.Tracef("START TX <0x%x>", txo.ID())
+		tracker, scb := t.stateTraceOf(txo.Group(), cnt)
 		for trial := 0; ; trial++ {
 			txh, err := txo.GetHandler(t.cm)
 			if err != nil {
@@ -37,10 +38,16 @@ func (t *transition) executeTxsSequential(l module.TransactionList, ctx contract
 				From:      txo.From(),
 			})
 			ctx.UpdateSystemInfo()
+			if tracker != nil {
+				tracker.Start()
+			}
 			rct, err := txh.Execute(ctx, false)
 			txh.Dispose()
 			if err == nil {
 				rctBuf[cnt] = rct
+				if scb != nil {
+					scb.OnStateDiff(tracker.Diff())
+				}
 				break
 			}
 			if !errors.ExecutionFailError.Equals(err) {
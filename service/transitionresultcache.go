@@ -118,6 +118,30 @@ func (c *transitionResultCache) GetReceipts(result []byte, group module.Transact
 	}
 }
 
+// CheckState checks whether the world state of the result is in the
+// database. States of old blocks may be removed by pruning.
+func (c *transitionResultCache) CheckState(result []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	item, err := c.getItemInLock(result)
+	if err != nil {
+		return err
+	}
+	stateHash := item.transactionResult.StateHash
+	if item.worldSnapshot != nil || len(stateHash) == 0 {
+		return nil
+	}
+	bk, err := c.database.GetBucket(db.MerkleTrie)
+	if err != nil {
+		return err
+	}
+	if !bk.Has(stateHash) {
+		return PrunedStateError.Errorf("StatePruned(hash=%#x)", stateHash)
+	}
+	return nil
+}
+
 func (c *transitionResultCache) GetWorldSnapshot(result []byte, vh []byte) (state.WorldSnapshot, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
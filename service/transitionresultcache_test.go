@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+)
+
+func TestTransitionResultCache_CheckState(t *testing.T) {
+	mdb := db.NewMapDB()
+	trc := newTransitionResultCache(mdb, 10, 1024, log.New())
+
+	node := []byte("root node of the state")
+	stateHash := crypto.SHA3Sum256(node)
+	result := (&transitionResult{StateHash: stateHash}).Bytes()
+
+	err := trc.CheckState(result)
+	assert.True(t, PrunedStateError.Equals(err))
+
+	bk, err := mdb.GetBucket(db.MerkleTrie)
+	assert.NoError(t, err)
+	assert.NoError(t, bk.Set(stateHash, node))
+	assert.NoError(t, trc.CheckState(result))
+
+	empty := (&transitionResult{}).Bytes()
+	assert.NoError(t, trc.CheckState(empty))
+}
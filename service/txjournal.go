@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+const (
+	// txJournalKeyPrefix is the prefix of the keys of journaled
+	// transactions, so they are not confused with the entries of other
+	// buckets which some backends return for the range of the bucket.
+	txJournalKeyPrefix = "txpool.journal."
+
+	// txJournalMarker is the first bytes of the values of journaled
+	// transactions.
+	txJournalMarker = "TXJ1"
+)
+
+// txJournal keeps transactions in the pools in the database, so that they
+// can be loaded again after restart. It maps txJournalKeyPrefix followed by
+// transaction ID to txJournalMarker followed by the encoded txJournalEntry.
+// Entries without them are not touched.
+type txJournal struct {
+	bucket db.Bucket
+	log    log.Logger
+}
+
+type txJournalEntry struct {
+	Direct bool
+	Bytes  []byte
+}
+
+// Add records the transaction added to the pool. Failure is only logged,
+// because the journal is not needed to handle the transaction.
+func (j *txJournal) Add(tx transaction.Transaction, direct bool) {
+	if j == nil {
+		return
+	}
+	bs, err := codec.BC.MarshalToBytes(&txJournalEntry{direct, tx.Bytes()})
+	if err == nil {
+		value := append([]byte(txJournalMarker), bs...)
+		err = j.bucket.Set(txJournalKeyOf(tx.ID()), value)
+	}
+	if err != nil {
+		j.log.Warnf("FAIL to journal transaction id=%#x err=%+v", tx.ID(), err)
+	}
+}
+
+// Remove removes the transaction removed from the pool.
+func (j *txJournal) Remove(id []byte) {
+	if j == nil {
+		return
+	}
+	if err := j.bucket.Delete(txJournalKeyOf(id)); err != nil {
+		j.log.Warnf("FAIL to remove journaled transaction id=%#x err=%+v", id, err)
+	}
+}
+
+// IsEmpty returns whether there is no journaled transaction.
+func (j *txJournal) IsEmpty() bool {
+	if j == nil {
+		return true
+	}
+	it := j.bucket.NewIterator(db.BytesPrefix([]byte(txJournalKeyPrefix)))
+	defer it.Release()
+	for it.Next() {
+		if bytes.HasPrefix(it.Value(), []byte(txJournalMarker)) {
+			return false
+		}
+	}
+	return true
+}
+
+func txJournalKeyOf(id []byte) []byte {
+	return append([]byte(txJournalKeyPrefix), id...)
+}
+
+type txJournalItem struct {
+	tx     transaction.Transaction
+	direct bool
+}
+
+// Load returns journaled transactions in order of their timestamps.
+// Entries of the journal failing to be parsed are removed.
+func (j *txJournal) Load() []txJournalItem {
+	if j == nil {
+		return nil
+	}
+	it := j.bucket.NewIterator(db.BytesPrefix([]byte(txJournalKeyPrefix)))
+	defer it.Release()
+
+	var items []txJournalItem
+	var invalid [][]byte
+	for it.Next() {
+		value := it.Value()
+		if !bytes.HasPrefix(value, []byte(txJournalMarker)) {
+			continue
+		}
+		id := it.Key()[len(txJournalKeyPrefix):]
+		var entry txJournalEntry
+		if _, err := codec.BC.UnmarshalFromBytes(value[len(txJournalMarker):], &entry); err != nil {
+			j.log.Warnf("InvalidJournalEntry(id=%#x) err=%+v", id, err)
+			invalid = append(invalid, id)
+			continue
+		}
+		tx, err := transaction.NewTransaction(entry.Bytes)
+		if err != nil {
+			j.log.Warnf("InvalidJournalEntry(id=%#x) err=%+v", id, err)
+			invalid = append(invalid, id)
+			continue
+		}
+		items = append(items, txJournalItem{tx, entry.Direct})
+	}
+	if err := it.Error(); err != nil {
+		j.log.Warnf("FAIL to load journaled transactions err=%+v", err)
+	}
+	for _, id := range invalid {
+		j.Remove(id)
+	}
+	sort.SliceStable(items, func(i, k int) bool {
+		return items[i].tx.Timestamp() < items[k].tx.Timestamp()
+	})
+	return items
+}
+
+func newTxJournal(database db.Database, logger log.Logger) (*txJournal, error) {
+	bk, err := database.GetBucket(db.TransactionPoolJournal)
+	if err != nil {
+		return nil, err
+	}
+	return &txJournal{bucket: bk, log: logger}, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/log"
+	"github.com/icon-project/goloop/module"
+	"github.com/icon-project/goloop/service/transaction"
+)
+
+func TestTxJournal(t *testing.T) {
+	dbase := db.NewMapDB()
+	bk, _ := dbase.GetBucket(db.TransactionLocatorByHash)
+	journal, err := newTxJournal(dbase, log.New())
+	assert.NoError(t, err)
+	assert.True(t, journal.IsEmpty())
+
+	pool := NewTransactionPool(module.TransactionGroupNormal, 5000, bk, &mockMonitor{}, log.New())
+	pool.SetJournal(journal)
+
+	tx, err := transaction.NewTransactionFromJSON([]byte("{\"from\": \"hx54f7853dc6481b670caf69c5a27c7c8fe5be8269\", \"to\": \"hx49a23bd156932485471f582897bf1bec5f875751\", \"value\": \"0x56bc75e2d63100000\", \"fee\": \"0x2386f26fc10000\", \"nonce\": \"0x1\", \"tx_hash\": \"375540830d475a73b704cf8dee9fa9eba2798f9d2af1fa55a85482e48daefd3b\", \"signature\": \"bjarKeF3izGy469dpSciP3TT9caBQVYgHdaNgjY+8wJTOVSFm4o/ODXycFOdXUJcIwqvcE9If8x6Zmgt//XmkQE=\", \"method\": \"icx_sendTransaction\"}"))
+	assert.NoError(t, err)
+	assert.NoError(t, pool.Add(tx, true))
+	assert.False(t, journal.IsEmpty())
+
+	// journal survives the pool
+	journal2, err := newTxJournal(dbase, log.New())
+	assert.NoError(t, err)
+	items := journal2.Load()
+	assert.Len(t, items, 1)
+	assert.Equal(t, tx.ID(), items[0].tx.ID())
+	assert.True(t, items[0].direct)
+
+	pool.Evict([][]byte{tx.ID()}, ErrEvictedTransaction)
+	assert.True(t, journal.IsEmpty())
+
+	// invalid entries of the journal are removed on load, but the entries
+	// without the prefix or the marker are not touched.
+	jbk, _ := dbase.GetBucket(db.TransactionPoolJournal)
+	invalid := txJournalKeyOf([]byte("invalid"))
+	assert.NoError(t, jbk.Set(invalid, append([]byte(txJournalMarker), 0x01, 0x02)))
+	assert.False(t, journal.IsEmpty())
+	unmarked := txJournalKeyOf([]byte("unmarked"))
+	assert.NoError(t, jbk.Set(unmarked, []byte{0x01, 0x02}))
+	assert.NoError(t, jbk.Set([]byte("other"), []byte{0x01, 0x02}))
+	assert.Len(t, journal.Load(), 0)
+	assert.True(t, journal.IsEmpty())
+	assert.False(t, jbk.Has(invalid))
+	assert.True(t, jbk.Has(unmarked))
+	assert.True(t, jbk.Has([]byte("other")))
+}
+
+// TestTxJournal_SharedKeyspace checks that the journal doesn't touch merkle
+// trie nodes on the backend keeping all buckets in one keyspace, whose keys
+// start with the id of the bucket of the journal.
+func TestTxJournal_SharedKeyspace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txjournal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dbase, err := db.Open(dir, string(db.GoLevelDBBackend), "test")
+	assert.NoError(t, err)
+	defer dbase.Close()
+
+	mt, err := dbase.GetBucket(db.MerkleTrie)
+	assert.NoError(t, err)
+	var nodes [][]byte
+	for i := 0; len(nodes) < 3; i++ {
+		node := []byte(fmt.Sprintf("node%d", i))
+		key := crypto.SHA3Sum256(node)
+		if key[0] != db.TransactionPoolJournal[0] {
+			continue
+		}
+		assert.NoError(t, mt.Set(key, node))
+		nodes = append(nodes, key)
+	}
+
+	journal, err := newTxJournal(dbase, log.New())
+	assert.NoError(t, err)
+	assert.True(t, journal.IsEmpty())
+	assert.Len(t, journal.Load(), 0)
+	for _, key := range nodes {
+		assert.True(t, mt.Has(key))
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"container/heap"
+
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+// Ordering policies of the transactions for block candidates.
+// Transactions of a sender always keep their order in the pool, so
+// cumulative checks(balance and nonce) are not broken by re-ordering.
+const (
+	// TxOrderingArrival selects transactions in arrival order.
+	TxOrderingArrival = "arrival"
+	// TxOrderingStepLimit selects transactions with higher step limit
+	// first. Transactions don't bid step price, which is same for all of
+	// them in the chain, so the step limit bounds the fee they pay.
+	TxOrderingStepLimit = "step_limit"
+	// TxOrderingFairness selects transactions of senders in round-robin.
+	TxOrderingFairness = "fairness"
+	// TxOrderingPriority selects transactions from the priority accounts
+	// registered by the governance first.
+	TxOrderingPriority = "priority"
+
+	TxOrderingDefault = TxOrderingArrival
+)
+
+var TxOrderingOptions = [...]string{
+	TxOrderingArrival, TxOrderingStepLimit, TxOrderingFairness, TxOrderingPriority,
+}
+
+// txOrdering returns re-ordered transactions. It may modify the slice.
+type txOrdering func(wc state.WorldContext, txs []*txElement) []*txElement
+
+func IsTxOrderingOption(s string) bool {
+	for _, k := range TxOrderingOptions {
+		if k == s {
+			return true
+		}
+	}
+	return false
+}
+
+// txOrderingFor returns ordering for the policy. It returns nil for
+// TxOrderingArrival, which keeps the order of the pool.
+func txOrderingFor(name string) (txOrdering, error) {
+	switch name {
+	case "", TxOrderingArrival:
+		return nil, nil
+	case TxOrderingStepLimit:
+		return orderByStepLimit, nil
+	case TxOrderingFairness:
+		return orderByFairness, nil
+	case TxOrderingPriority:
+		return orderByPriority, nil
+	default:
+		return nil, errors.IllegalArgumentError.Errorf("InvalidTxOrdering(%s)", name)
+	}
+}
+
+// groupBySender returns indexes of the transactions grouped by sender in
+// order of their first appearance.
+func groupBySender(txs []*txElement) [][]int {
+	index := make(map[string]int)
+	var groups [][]int
+	for i, e := range txs {
+		key := string(e.Value().From().ID())
+		if g, ok := index[key]; ok {
+			groups[g] = append(groups[g], i)
+		} else {
+			index[key] = len(groups)
+			groups = append(groups, []int{i})
+		}
+	}
+	return groups
+}
+
+func orderByFairness(wc state.WorldContext, txs []*txElement) []*txElement {
+	groups := groupBySender(txs)
+	ordered := make([]*txElement, 0, len(txs))
+	for round := 0; len(ordered) < len(txs); round++ {
+		for _, g := range groups {
+			if round < len(g) {
+				ordered = append(ordered, txs[g[round]])
+			}
+		}
+	}
+	return ordered
+}
+
+// senderHeap has pending transactions of senders. The sender with the
+// highest step limit at its head comes first, and an earlier one wins a tie.
+type senderHeap struct {
+	txs    []*txElement
+	groups [][]int
+}
+
+func (h *senderHeap) Len() int {
+	return len(h.groups)
+}
+
+func (h *senderHeap) Less(i, j int) bool {
+	ii, ij := h.groups[i][0], h.groups[j][0]
+	if c := h.txs[ii].Value().GetStepLimit().Cmp(h.txs[ij].Value().GetStepLimit()); c != 0 {
+		return c > 0
+	}
+	return ii < ij
+}
+
+func (h *senderHeap) Swap(i, j int) {
+	h.groups[i], h.groups[j] = h.groups[j], h.groups[i]
+}
+
+func (h *senderHeap) Push(x interface{}) {
+	h.groups = append(h.groups, x.([]int))
+}
+
+func (h *senderHeap) Pop() interface{} {
+	n := len(h.groups)
+	g := h.groups[n-1]
+	h.groups = h.groups[:n-1]
+	return g
+}
+
+func orderByStepLimit(wc state.WorldContext, txs []*txElement) []*txElement {
+	h := &senderHeap{txs: txs, groups: groupBySender(txs)}
+	heap.Init(h)
+	ordered := make([]*txElement, 0, len(txs))
+	for h.Len() > 0 {
+		g := h.groups[0]
+		ordered = append(ordered, txs[g[0]])
+		if len(g) > 1 {
+			h.groups[0] = g[1:]
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return ordered
+}
+
+func priorityAccountsOf(wc state.WorldContext) map[string]bool {
+	ass := wc.GetAccountSnapshot(state.SystemID)
+	as := scoredb.NewStateStoreWith(ass)
+	db := scoredb.NewArrayDB(as, state.VarPriorityAccounts)
+	accounts := make(map[string]bool, db.Size())
+	for i := 0; i < db.Size(); i++ {
+		accounts[db.Get(i).Address().String()] = true
+	}
+	return accounts
+}
+
+func orderByPriority(wc state.WorldContext, txs []*txElement) []*txElement {
+	accounts := priorityAccountsOf(wc)
+	if len(accounts) == 0 {
+		return txs
+	}
+	ordered := make([]*txElement, 0, len(txs))
+	others := make([]*txElement, 0, len(txs))
+	for _, e := range txs {
+		if accounts[e.Value().From().String()] {
+			ordered = append(ordered, e)
+		} else {
+			others = append(others, e)
+		}
+	}
+	return append(ordered, others...)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/service/scoredb"
+	"github.com/icon-project/goloop/service/state"
+)
+
+func TestTxOrdering(t *testing.T) {
+	addr1 := common.NewAddressFromString("hx1111111111111111111111111111111111111111")
+	addr2 := common.NewAddressFromString("hx2222222222222222222222222222222222222222")
+	addr3 := common.NewAddressFromString("hx3333333333333333333333333333333333333333")
+
+	newElement := func(id string, from *common.Address, stepLimit int64) *txElement {
+		tx := newMockTransaction([]byte(id), from, 0)
+		tx.stepLimit = big.NewInt(stepLimit)
+		return &txElement{value: tx}
+	}
+	// addr1 floods the pool in front of the others.
+	txs := []*txElement{
+		newElement("a1", addr1, 100),
+		newElement("a2", addr1, 300),
+		newElement("a3", addr1, 100),
+		newElement("b1", addr2, 200),
+		newElement("c1", addr3, 100),
+		newElement("b2", addr2, 400),
+	}
+	idsOf := func(es []*txElement) []string {
+		ids := make([]string, len(es))
+		for i, e := range es {
+			ids[i] = string(e.Value().ID())
+		}
+		return ids
+	}
+
+	ws := state.NewWorldState(db.NewMapDB(), nil, nil)
+	wc := state.NewWorldContext(ws, common.NewBlockInfo(1, 0))
+
+	ordering, err := txOrderingFor(TxOrderingArrival)
+	assert.NoError(t, err)
+	assert.Nil(t, ordering)
+
+	_, err = txOrderingFor("unknown")
+	assert.Error(t, err)
+
+	ordering, err = txOrderingFor(TxOrderingFairness)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "b2", "a3"},
+		idsOf(ordering(wc, append([]*txElement{}, txs...))))
+
+	ordering, err = txOrderingFor(TxOrderingStepLimit)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b1", "b2", "a1", "a2", "a3", "c1"},
+		idsOf(ordering(wc, append([]*txElement{}, txs...))))
+
+	ordering, err = txOrderingFor(TxOrderingPriority)
+	assert.NoError(t, err)
+	assert.Equal(t, idsOf(txs), idsOf(ordering(wc, append([]*txElement{}, txs...))))
+
+	as := ws.GetAccountState(state.SystemID)
+	assert.NoError(t, scoredb.NewArrayDB(as, state.VarPriorityAccounts).Put(addr3))
+	assert.Equal(t, []string{"c1", "a1", "a2", "a3", "b1", "b2"},
+		idsOf(ordering(wc, append([]*txElement{}, txs...))))
+
+	assert.Equal(t, []string{"a1", "a2"}, idsOf(limitTxElements(txs, 100, 2)))
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/icon-project/goloop/module"
+)
+
+// txRateLimiter limits the number of transactions received from each peer.
+// Each peer has a token bucket filled with rate tokens per second, and it
+// may have tokens for one second at most.
+type txRateLimiter struct {
+	lock  sync.Mutex
+	rate  int
+	peers map[string]*txTokenBucket
+	now   func() time.Time
+}
+
+type txTokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Allow consumes a token of the peer. It returns false if the peer has no
+// token. It always returns true if the rate is not positive.
+func (l *txRateLimiter) Allow(id module.PeerID) bool {
+	if l == nil || l.rate <= 0 {
+		return true
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	key := id.String()
+	b, ok := l.peers[key]
+	if !ok {
+		b = &txTokenBucket{tokens: float64(l.rate), last: now}
+		l.peers[key] = b
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * float64(l.rate)
+		if b.tokens > float64(l.rate) {
+			b.tokens = float64(l.rate)
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens -= 1
+	return true
+}
+
+// Remove removes the bucket of the peer.
+func (l *txRateLimiter) Remove(id module.PeerID) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.peers, id.String())
+}
+
+func newTxRateLimiter(rate int) *txRateLimiter {
+	return &txRateLimiter{
+		rate:  rate,
+		peers: make(map[string]*txTokenBucket),
+		now:   time.Now,
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/network"
+)
+
+func TestTxRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newTxRateLimiter(2)
+	l.now = func() time.Time { return now }
+
+	p1 := network.NewPeerIDFromString("hx1111111111111111111111111111111111111111")
+	p2 := network.NewPeerIDFromString("hx2222222222222222222222222222222222222222")
+	assert.True(t, l.Allow(p1))
+	assert.True(t, l.Allow(p1))
+	assert.False(t, l.Allow(p1))
+	assert.True(t, l.Allow(p2))
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow(p1))
+	assert.False(t, l.Allow(p1))
+
+	// tokens are not accumulated over the rate
+	now = now.Add(10 * time.Second)
+	assert.True(t, l.Allow(p1))
+	assert.True(t, l.Allow(p1))
+	assert.False(t, l.Allow(p1))
+
+	l.Remove(p1)
+	assert.True(t, l.Allow(p1))
+
+	var unlimited *txRateLimiter
+	assert.True(t, unlimited.Allow(p1))
+	assert.True(t, newTxRateLimiter(0).Allow(p1))
+}
@@ -0,0 +1,194 @@
+package txresult
+
+import (
+	"encoding/binary"
+
+	"github.com/icon-project/goloop/common/codec"
+	"github.com/icon-project/goloop/common/crypto"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/common/errors"
+	"github.com/icon-project/goloop/module"
+)
+
+const (
+	keyEventLogIndexRange = "eventlog.indexRange"
+
+	// key = address(21) | sha3(signature)(32) | height(8) | tx(4) | log(4)
+	eventLogIndexAddrLen = 21
+	eventLogIndexSigLen  = 32
+	eventLogIndexPrefix  = eventLogIndexAddrLen + eventLogIndexSigLen
+	eventLogIndexKeyLen  = eventLogIndexPrefix + 8 + 4 + 4
+)
+
+// EventLogLocator locates an event log in the chain.
+// Height is the height of the block including the transaction.
+type EventLogLocator struct {
+	Height   int64
+	TxIndex  int
+	LogIndex int
+}
+
+type eventLogIndexRange struct {
+	Base int64
+	Last int64
+}
+
+// EventLogIndex is the index of event logs of normal transactions by
+// SCORE address and event signature. Range of heights covered by the index
+// is recorded in the database, so it's safe to use the index only for
+// the heights in the range.
+type EventLogIndex struct {
+	dbase  db.Database
+	bucket db.Bucket
+	props  db.Bucket
+}
+
+func NewEventLogIndex(dbase db.Database) (*EventLogIndex, error) {
+	bk, err := dbase.GetBucket(db.EventLogIndex)
+	if err != nil {
+		return nil, errors.CriticalIOError.Wrap(err, "FailToGetBucket")
+	}
+	props, err := dbase.GetBucket(db.ChainProperty)
+	if err != nil {
+		return nil, errors.CriticalIOError.Wrap(err, "FailToGetBucket")
+	}
+	return &EventLogIndex{
+		dbase:  dbase,
+		bucket: bk,
+		props:  props,
+	}, nil
+}
+
+func (idx *EventLogIndex) getRange() (*eventLogIndexRange, error) {
+	bs, err := idx.props.Get([]byte(keyEventLogIndexRange))
+	if err != nil {
+		return nil, errors.CriticalIOError.Wrap(err, "FailToGetIndexRange")
+	}
+	if bs == nil {
+		return nil, nil
+	}
+	r := new(eventLogIndexRange)
+	if _, err := codec.BC.UnmarshalFromBytes(bs, r); err != nil {
+		return nil, errors.CriticalFormatError.Wrap(err, "InvalidIndexRange")
+	}
+	return r, nil
+}
+
+// Range returns the lowest and the highest height covered by the index.
+// ok is false if nothing is indexed.
+func (idx *EventLogIndex) Range() (base int64, last int64, ok bool, err error) {
+	r, err := idx.getRange()
+	if err != nil || r == nil {
+		return 0, 0, false, err
+	}
+	return r.Base, r.Last, true, nil
+}
+
+// Covers returns whether the index covers all heights from the height
+// from to the height to (inclusive).
+func (idx *EventLogIndex) Covers(from, to int64) bool {
+	r, err := idx.getRange()
+	if err != nil || r == nil {
+		return false
+	}
+	return r.Base <= from && to <= r.Last
+}
+
+// Add indexes event logs in the receipts of normal transactions of the
+// block at the height. If the height doesn't follow the last indexed height,
+// the covered range restarts from the height.
+func (idx *EventLogIndex) Add(height int64, rl module.ReceiptList) error {
+	r, err := idx.getRange()
+	if err != nil {
+		return err
+	}
+	if r == nil || r.Last+1 != height {
+		r = &eventLogIndexRange{Base: height}
+	}
+	r.Last = height
+
+	batch := idx.dbase.NewBatch()
+	txIndex := 0
+	for rit := rl.Iterator(); rit.Has(); rit.Next() {
+		rct, err := rit.Get()
+		if err != nil {
+			return err
+		}
+		logIndex := 0
+		for eit := rct.EventLogIterator(); eit.Has(); eit.Next() {
+			el, err := eit.Get()
+			if err != nil {
+				return err
+			}
+			indexed := el.Indexed()
+			if len(indexed) > 0 {
+				key := eventLogIndexKey(el.Address(), indexed[0],
+					height, txIndex, logIndex)
+				if err := batch.Set(db.EventLogIndex, key, []byte{}); err != nil {
+					return err
+				}
+			}
+			logIndex++
+		}
+		txIndex++
+	}
+	bs, err := codec.BC.MarshalToBytes(r)
+	if err != nil {
+		return err
+	}
+	if err := batch.Set(db.ChainProperty, []byte(keyEventLogIndexRange), bs); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return errors.CriticalIOError.Wrap(err, "FailToWriteIndex")
+	}
+	return nil
+}
+
+// Reset drops the covered range, then the next Add starts a new range.
+// Entries are kept because they are always same for the same height.
+func (idx *EventLogIndex) Reset() error {
+	return idx.props.Delete([]byte(keyEventLogIndexRange))
+}
+
+// Find returns locators of event logs of the SCORE with the signature in
+// the blocks from the height from to the height to (inclusive) in the order
+// of their locations. If limit is positive, it returns at most limit
+// locators.
+func (idx *EventLogIndex) Find(addr module.Address, sig []byte, from, to int64, limit int) ([]EventLogLocator, error) {
+	it := idx.bucket.NewIterator(&db.Range{
+		Start: eventLogIndexKey(addr, sig, from, 0, 0),
+		Limit: eventLogIndexKey(addr, sig, to+1, 0, 0),
+	})
+	defer it.Release()
+
+	var locs []EventLogLocator
+	for it.Next() {
+		key := it.Key()
+		if len(key) != eventLogIndexKeyLen {
+			return nil, errors.CriticalFormatError.Errorf("InvalidIndexKey(%x)", key)
+		}
+		locs = append(locs, EventLogLocator{
+			Height:   int64(binary.BigEndian.Uint64(key[eventLogIndexPrefix:])),
+			TxIndex:  int(binary.BigEndian.Uint32(key[eventLogIndexPrefix+8:])),
+			LogIndex: int(binary.BigEndian.Uint32(key[eventLogIndexPrefix+12:])),
+		})
+		if limit > 0 && len(locs) >= limit {
+			break
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, errors.CriticalIOError.Wrap(err, "FailToIterateIndex")
+	}
+	return locs, nil
+}
+
+func eventLogIndexKey(addr module.Address, sig []byte, height int64, txIndex, logIndex int) []byte {
+	key := make([]byte, eventLogIndexKeyLen)
+	copy(key, addr.Bytes())
+	copy(key[eventLogIndexAddrLen:], crypto.SHA3Sum256(sig))
+	binary.BigEndian.PutUint64(key[eventLogIndexPrefix:], uint64(height))
+	binary.BigEndian.PutUint32(key[eventLogIndexPrefix+8:], uint32(txIndex))
+	binary.BigEndian.PutUint32(key[eventLogIndexPrefix+12:], uint32(logIndex))
+	return key
+}
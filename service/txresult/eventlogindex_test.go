@@ -0,0 +1,83 @@
+package txresult
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/icon-project/goloop/common"
+	"github.com/icon-project/goloop/common/db"
+	"github.com/icon-project/goloop/module"
+)
+
+func TestEventLogIndex(t *testing.T) {
+	mdb := db.NewMapDB()
+	score1 := common.NewAddressFromString("cx0000000000000000000000000000000000000001")
+	score2 := common.NewAddressFromString("cx0000000000000000000000000000000000000002")
+	transfer := []byte("Transfer(Address,Address,int)")
+	approval := []byte("Approval(Address,int)")
+
+	newReceipts := func() module.ReceiptList {
+		r1 := NewReceipt(mdb, module.LatestRevision, score1)
+		r1.AddLog(score1, [][]byte{transfer, {0x01}, {0x02}}, [][]byte{{0x10}})
+		r1.AddLog(score2, [][]byte{transfer, {0x01}, {0x02}}, [][]byte{{0x10}})
+		r1.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+		r2 := NewReceipt(mdb, module.LatestRevision, score1)
+		r2.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+		r3 := NewReceipt(mdb, module.LatestRevision, score1)
+		r3.AddLog(score1, [][]byte{approval, {0x01}}, [][]byte{{0x10}})
+		r3.AddLog(score1, [][]byte{transfer, {0x02}, {0x01}}, [][]byte{{0x20}})
+		r3.SetResult(module.StatusSuccess, big.NewInt(100), big.NewInt(10), nil)
+		return NewReceiptListFromSlice(mdb, []Receipt{r1, r2, r3})
+	}
+
+	eli, err := NewEventLogIndex(mdb)
+	assert.NoError(t, err)
+	_, _, ok, err := eli.Range()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, eli.Covers(0, 0))
+
+	assert.NoError(t, eli.Add(5, newReceipts()))
+	assert.NoError(t, eli.Add(6, newReceipts()))
+	base, last, ok, err := eli.Range()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), base)
+	assert.Equal(t, int64(6), last)
+	assert.True(t, eli.Covers(5, 6))
+	assert.False(t, eli.Covers(4, 6))
+	assert.False(t, eli.Covers(5, 7))
+
+	locs, err := eli.Find(score1, transfer, 5, 6, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []EventLogLocator{
+		{Height: 5, TxIndex: 0, LogIndex: 0},
+		{Height: 5, TxIndex: 2, LogIndex: 1},
+		{Height: 6, TxIndex: 0, LogIndex: 0},
+		{Height: 6, TxIndex: 2, LogIndex: 1},
+	}, locs)
+
+	locs, err = eli.Find(score1, transfer, 6, 6, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []EventLogLocator{{Height: 6, TxIndex: 0, LogIndex: 0}}, locs)
+
+	locs, err = eli.Find(score2, transfer, 5, 6, 0)
+	assert.NoError(t, err)
+	assert.Len(t, locs, 2)
+
+	locs, err = eli.Find(score2, approval, 5, 6, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, locs)
+
+	// a gap restarts the covered range
+	assert.NoError(t, eli.Add(8, newReceipts()))
+	base, last, _, _ = eli.Range()
+	assert.Equal(t, int64(8), base)
+	assert.Equal(t, int64(8), last)
+
+	assert.NoError(t, eli.Reset())
+	_, _, ok, _ = eli.Range()
+	assert.False(t, ok)
+}
@@ -115,6 +115,27 @@ func (log *eventLog) ToJSON(module.JSONVersion) (*eventLogJSON, error) {
 	return eljson, nil
 }
 
+// EventLogToJSON returns JSON object of the event log, which has same
+// fields as an element of eventLogs in the receipt.
+func EventLogToJSON(e module.EventLog) (map[string]interface{}, error) {
+	el, ok := e.(*eventLog)
+	if !ok {
+		el = new(eventLog)
+		el.eventLogData.Addr.SetBytes(e.Address().Bytes())
+		el.eventLogData.Indexed = e.Indexed()
+		el.eventLogData.Data = e.Data()
+	}
+	eljson, err := el.ToJSON(module.JSONVersion3)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"scoreAddress": &eljson.Addr,
+		"indexed":      eljson.Indexed,
+		"data":         eljson.Data,
+	}, nil
+}
+
 type Version int
 
 const (
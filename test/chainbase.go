@@ -48,10 +48,30 @@ func (_r *ChainBase) PatchTxPoolSize() int {
 	panic("not implemented")
 }
 
+func (_r *ChainBase) SenderTxPoolSize() int {
+	panic("not implemented")
+}
+
+func (_r *ChainBase) SenderTxBytes() int {
+	panic("not implemented")
+}
+
+func (_r *ChainBase) PeerTxRate() int {
+	panic("not implemented")
+}
+
 func (_r *ChainBase) MaxBlockTxBytes() int {
 	panic("not implemented")
 }
 
+func (_r *ChainBase) EventLogIndex() bool {
+	panic("not implemented")
+}
+
+func (_r *ChainBase) TxOrdering() string {
+	panic("not implemented")
+}
+
 func (_r *ChainBase) DefaultWaitTimeout() time.Duration {
 	panic("not implemented")
 }
@@ -120,6 +140,22 @@ func (_r *ChainBase) Backup(file string, extra []string) error {
 	panic("not implemented")
 }
 
+func (_r *ChainBase) BackupLive(file string, extra []string) error {
+	panic("not implemented")
+}
+
+func (_r *ChainBase) BackupIncremental(file string, base int64, hash []byte) error {
+	panic("not implemented")
+}
+
+func (_r *ChainBase) ExportArchive(file string, from, to int64) error {
+	panic("not implemented")
+}
+
+func (_r *ChainBase) ImportArchive(src string) error {
+	panic("not implemented")
+}
+
 func (_r *ChainBase) Term() error {
 	panic("not implemented")
 }
@@ -136,6 +172,10 @@ func (_r *ChainBase) IsStopped() bool {
 	panic("not implemented")
 }
 
+func (_r *ChainBase) Reindex() error {
+	panic("not implemented")
+}
+
 func (_r *ChainBase) Reset() error {
 	panic("not implemented")
 }
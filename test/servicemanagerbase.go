@@ -90,6 +90,10 @@ func (_r *ServiceManagerBase) GetBalance(result []byte, addr module.Address) (*b
 	panic("not implemented")
 }
 
+func (_r *ServiceManagerBase) GetNonce(result []byte, addr module.Address) (*big.Int, error) {
+	panic("not implemented")
+}
+
 func (_r *ServiceManagerBase) GetTotalSupply(result []byte) (*big.Int, error) {
 	panic("not implemented")
 }
@@ -106,6 +110,38 @@ func (_r *ServiceManagerBase) GetAPIInfo(result []byte, addr module.Address) (mo
 	panic("not implemented")
 }
 
+func (_r *ServiceManagerBase) AddTransactionMonitor(tm module.TransactionMonitor) {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) RemoveTransactionMonitor(tm module.TransactionMonitor) {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) GetPoolTransactions(g module.TransactionGroup, offset, limit int) ([]module.Transaction, int) {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) GetPoolStatus(g module.TransactionGroup) *module.TransactionPoolStatus {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) GetTransactionDrop(id []byte) (*module.TransactionDrop, error) {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) EvictTransactions(ids [][]byte) [][]byte {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) GetProofForAccount(result []byte, addr module.Address) ([][]byte, error) {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) GetProofForStorage(result []byte, addr module.Address, key []byte) ([][]byte, error) {
+	panic("not implemented")
+}
+
 func (_r *ServiceManagerBase) GetMembers(result []byte) (module.MemberList, error) {
 	panic("not implemented")
 }
@@ -134,6 +170,18 @@ func (_r *ServiceManagerBase) ExportResult(result []byte, vh []byte, dst db.Data
 	panic("not implemented")
 }
 
+func (_r *ServiceManagerBase) ExportWorldState(result []byte, dst db.Database) error {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) ExportReceipts(result []byte, dst db.Database) error {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) ExportTransactions(hash []byte, dst db.Database) error {
+	panic("not implemented")
+}
+
 func (_r *ServiceManagerBase) ImportResult(result []byte, vh []byte, src db.Database) error {
 	panic("not implemented")
 }
@@ -141,3 +189,11 @@ func (_r *ServiceManagerBase) ImportResult(result []byte, vh []byte, src db.Data
 func (_r *ServiceManagerBase) ExecuteTransaction(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, error) {
 	panic("not implemented")
 }
+
+func (_r *ServiceManagerBase) ExecuteTransactionWithDiff(result []byte, vh []byte, js []byte, bi module.BlockInfo) (module.Receipt, module.StateDiff, error) {
+	panic("not implemented")
+}
+
+func (_r *ServiceManagerBase) SimulateTransactions(result []byte, vh []byte, txs [][]byte, overrides []*module.AccountOverride, bi module.BlockInfo) ([]module.Receipt, error) {
+	panic("not implemented")
+}